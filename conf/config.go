@@ -54,6 +54,26 @@ type Configuration struct {
 	MAILCHIMP_KEY                  string
 	MAILCHIMP_LIST_ID              string
 	SERVER_NAME                    string
+	MONGO_REQUEST_TIMEOUT_SEC      int
+	// station creation defaults, zero values keep the built-in defaults
+	STATION_DEFAULT_RETENTION_TYPE        string
+	STATION_DEFAULT_RETENTION_VALUE       int
+	STATION_DEFAULT_STORAGE_TYPE          string
+	STATION_DEFAULT_REPLICAS              int
+	STATION_DEFAULT_IDEMPOTENCY_WINDOW_MS int
+	// "true" or "false", applied when a station is created without a DLS configuration
+	STATION_DEFAULT_DLS_POISON      string
+	STATION_DEFAULT_DLS_SCHEMAVERSE string
+	// used by consumers when neither the consumer nor its station set max deliveries
+	CONSUMER_DEFAULT_MAX_MSG_DELIVERIES int
+	// cap of consumer groups per station for stations that do not set their own, 0 means unlimited
+	CONSUMER_GROUPS_DEFAULT_MAX_PER_STATION int
+	// amount of DLS messages pulled per batch when acking or resending poison messages
+	DLS_FETCH_BATCH_SIZE int
+	// hours the streams of a removed station are kept before they are destroyed, 0 destroys them on the next sweep
+	STATION_DELETION_GRACE_PERIOD_HOURS int
+	// hours a consumer group can stay with no connected member before its durable consumer is removed, 0 disables the cleanup
+	CONSUMER_GROUPS_INACTIVE_THRESHOLD_HOURS int
 }
 
 func GetConfig() Configuration {
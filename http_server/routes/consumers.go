@@ -24,4 +24,6 @@ func InitializeConsumersRoutes(router *gin.RouterGroup, h *server.Handlers) {
 	consumersRoutes := router.Group("/consumers")
 	consumersRoutes.GET("/getAllConsumers", consumersHandler.GetAllConsumers)
 	consumersRoutes.GET("/getAllConsumersByStation", consumersHandler.GetAllConsumersByStation)
+	consumersRoutes.POST("/resetConsumerGroup", consumersHandler.ResetConsumerGroup)
+	consumersRoutes.GET("/getInactiveConsumerGroups", consumersHandler.GetInactiveConsumerGroups)
 }
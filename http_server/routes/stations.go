@@ -23,17 +23,63 @@ func InitializeStationsRoutes(router *gin.RouterGroup, h *server.Handlers) {
 	stationsHandler := h.Stations
 	stationsRoutes := router.Group("/stations")
 	stationsRoutes.GET("/getStation", stationsHandler.GetStation)
+	stationsRoutes.GET("/stationExists", stationsHandler.StationExists)
+	stationsRoutes.GET("/getStationsByNames", stationsHandler.GetStationsByNames)
 	stationsRoutes.GET("/getMessageDetails", stationsHandler.GetMessageDetails)
+	stationsRoutes.GET("/getMessageById", stationsHandler.GetMessageById)
+	stationsRoutes.GET("/messageExists", stationsHandler.MessageExists)
+	stationsRoutes.GET("/getMessagesDetails", stationsHandler.GetMessagesDetails)
+	stationsRoutes.GET("/getMessagesByTimeRange", stationsHandler.GetMessagesByTimeRange)
 	stationsRoutes.GET("/getAllStations", stationsHandler.GetAllStations)
 	stationsRoutes.GET("/getStations", stationsHandler.GetStations)
+	stationsRoutes.GET("/getSchemalessStations", stationsHandler.GetSchemalessStations)
 	stationsRoutes.GET("/getPoisonMessageJourney", stationsHandler.GetPoisonMessageJourney)
+	stationsRoutes.GET("/getDlsMessageRaw", stationsHandler.GetDlsMessageRaw)
+	stationsRoutes.GET("/getStationSchemaSkew", stationsHandler.GetStationSchemaSkew)
+	stationsRoutes.GET("/getPoisonMessageTrend", stationsHandler.GetPoisonMessageTrend)
+	stationsRoutes.GET("/getAllPoisonMessages", stationsHandler.GetAllPoisonMessages)
+	stationsRoutes.GET("/getStationPoisonByCg", stationsHandler.GetStationPoisonByCg)
+	stationsRoutes.GET("/getStationConsumerGroups", stationsHandler.GetStationConsumerGroups)
+	stationsRoutes.GET("/getStationDlsRate", stationsHandler.GetStationDlsRate)
+	stationsRoutes.GET("/suggestRetention", stationsHandler.SuggestRetention)
+	stationsRoutes.GET("/getRetentionStats", stationsHandler.GetRetentionStats)
+	stationsRoutes.GET("/getStationDedupStats", stationsHandler.GetStationDedupStats)
+	stationsRoutes.GET("/getStationAuditLogs", stationsHandler.GetStationAuditLogs)
+	stationsRoutes.GET("/getStationSchemaVersionBreakdown", stationsHandler.GetStationSchemaVersionBreakdown)
+	stationsRoutes.GET("/getStationProducersSchemaStatus", stationsHandler.GetStationProducersSchemaStatus)
+	stationsRoutes.GET("/getStationActiveSchema", stationsHandler.GetStationActiveSchema)
+	stationsRoutes.GET("/getStationStreamName", stationsHandler.GetStationStreamName)
+	stationsRoutes.GET("/getStationSubjects", stationsHandler.GetStationSubjects)
+	stationsRoutes.GET("/getStationByStreamName", stationsHandler.GetStationByStreamName)
+	stationsRoutes.POST("/diffStation", stationsHandler.DiffStation)
+	stationsRoutes.GET("/getStationConfigDrift", stationsHandler.GetStationConfigDrift)
 	stationsRoutes.POST("/createStation", stationsHandler.CreateStation)
+	stationsRoutes.POST("/validateStationConfig", stationsHandler.ValidateStationConfig)
+	stationsRoutes.GET("/exportStationDefinitions", stationsHandler.ExportStationDefinitions)
+	stationsRoutes.POST("/importStationDefinitions", stationsHandler.ImportStationDefinitions)
 	stationsRoutes.POST("/resendPoisonMessages", stationsHandler.ResendPoisonMessages)
+	stationsRoutes.GET("/getResendJobStatus", stationsHandler.GetResendJobStatus)
 	stationsRoutes.POST("/ackPoisonMessages", stationsHandler.AckPoisonMessages)
+	stationsRoutes.POST("/reprocessSchemaFailedMessages", stationsHandler.ReprocessSchemaFailedMessages)
+	stationsRoutes.GET("/getStationDeletionImpact", stationsHandler.GetStationDeletionImpact)
 	stationsRoutes.DELETE("/removeStation", stationsHandler.RemoveStation)
+	stationsRoutes.DELETE("/deleteMessages", stationsHandler.DeleteMessages)
 	stationsRoutes.POST("/useSchema", stationsHandler.UseSchema)
+	stationsRoutes.POST("/useSchemaByTag", stationsHandler.UseSchemaByTag)
+	stationsRoutes.POST("/validateMessageAgainstStationSchema", stationsHandler.ValidateMessageAgainstStationSchema)
 	stationsRoutes.DELETE("/removeSchemaFromStation", stationsHandler.RemoveSchemaFromStation)
 	stationsRoutes.GET("/getUpdatesForSchemaByStation", stationsHandler.GetUpdatesForSchemaByStation)
+	stationsRoutes.GET("/watchStationSchemaChanges", stationsHandler.WatchStationSchemaChanges)
 	stationsRoutes.GET("/tierdStorageClicked", stationsHandler.TierdStorageClicked) // TODO to be deleted
 	stationsRoutes.PUT("/updateDlsConfig", stationsHandler.UpdateDlsConfig)
+	stationsRoutes.PUT("/updateMaxMsgSize", stationsHandler.UpdateMaxMsgSize)
+	stationsRoutes.PUT("/pinRetention", stationsHandler.PinRetention)
+	stationsRoutes.PUT("/updateDeletionProtection", stationsHandler.UpdateDeletionProtection)
+	stationsRoutes.PUT("/updateSchemaRequired", stationsHandler.UpdateSchemaRequired)
+	stationsRoutes.PUT("/updateStationMetadata", stationsHandler.UpdateStationMetadata)
+	stationsRoutes.PUT("/updateSchemaEnforcement", stationsHandler.UpdateSchemaEnforcement)
+	stationsRoutes.PUT("/pauseStation", stationsHandler.PauseStation)
+	stationsRoutes.PUT("/resumeStation", stationsHandler.ResumeStation)
+	stationsRoutes.PUT("/enableReadOnly", stationsHandler.EnableReadOnly)
+	stationsRoutes.PUT("/disableReadOnly", stationsHandler.DisableReadOnly)
 }
@@ -31,3 +31,11 @@ type AuditLog struct {
 type GetAllAuditLogsByStationSchema struct {
 	StationName string `form:"station_name" binding:"required"`
 }
+
+type GetStationAuditLogsSchema struct {
+	StationName string    `form:"station_name" json:"station_name" binding:"required"`
+	From        time.Time `form:"from" json:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To          time.Time `form:"to" json:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Page        int       `form:"page" json:"page" binding:"min=0"`
+	PageSize    int       `form:"page_size" json:"page_size" binding:"min=0"`
+}
@@ -62,6 +62,17 @@ type Cg struct {
 	LastStatusChangeDate  time.Time          `json:"last_status_change_date" bson:"last_status_change_date"`
 }
 
+type StationConsumerGroup struct {
+	Name                string     `json:"name"`
+	MaxAckTimeMs        int64      `json:"max_ack_time_ms"`
+	MaxMsgDeliveries    int        `json:"max_msg_deliveries"`
+	UnprocessedMessages int        `json:"unprocessed_messages"`
+	InProcessMessages   int        `json:"in_process_messages"`
+	IsActive            bool       `json:"is_active"`
+	IsDeleted           bool       `json:"is_deleted"`
+	Members             []CgMember `json:"members"`
+}
+
 type GetAllConsumersByStationSchema struct {
 	StationName string `form:"station_name" binding:"required" bson:"station_name"`
 }
@@ -81,6 +92,25 @@ type DestroyConsumerSchema struct {
 	StationName string `json:"station_name" binding:"required"`
 }
 
+type ResetConsumerGroupSchema struct {
+	StationName string    `json:"station_name" binding:"required"`
+	CgName      string    `json:"cg_name" binding:"required"`
+	Target      string    `json:"target" binding:"required"`
+	Sequence    uint64    `json:"sequence"`
+	Time        time.Time `json:"time"`
+}
+
+type GetInactiveConsumerGroupsSchema struct {
+	ThresholdHours int `form:"threshold_hours" json:"threshold_hours" binding:"min=0"`
+}
+
+type InactiveConsumerGroup struct {
+	StationName  string    `json:"station_name"`
+	CgName       string    `json:"cg_name"`
+	Members      int       `json:"members"`
+	LastActivity time.Time `json:"last_activity"`
+}
+
 type CgMember struct {
 	Name             string `json:"name" bson:"name"`
 	ClientAddress    string `json:"client_address" bson:"client_address"`
@@ -30,7 +30,8 @@ type MainOverviewData struct {
 }
 
 type GetStationOverviewDataSchema struct {
-	StationName string `form:"station_name" json:"station_name"  binding:"required"`
+	StationName   string `form:"station_name" json:"station_name"  binding:"required"`
+	MessagesOrder string `form:"messages_order" json:"messages_order"`
 }
 
 type SystemLogsRequest struct {
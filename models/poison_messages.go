@@ -14,6 +14,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -34,10 +35,13 @@ type MsgHeader struct {
 }
 
 type MessagePayload struct {
-	TimeSent time.Time         `json:"time_sent"`
-	Size     int               `json:"size"`
-	Data     string            `json:"data"`
-	Headers  map[string]string `json:"headers"`
+	TimeSent      time.Time         `json:"time_sent"`
+	Size          int               `json:"size"`
+	Data          string            `json:"data"`
+	Headers       map[string]string `json:"headers"`
+	SchemaVersion int               `json:"schema_version,omitempty"`
+	Decoded       json.RawMessage   `json:"decoded,omitempty"`
+	DecodeError   string            `json:"decode_error,omitempty"`
 }
 
 type MessagePayloadDls struct {
@@ -51,6 +55,7 @@ type PoisonedCg struct {
 	CgName              string     `json:"cg_name" bson:"cg_name"`
 	PoisoningTime       time.Time  `json:"poisoning_time" bson:"poisoning_time"`
 	DeliveriesCount     int        `json:"deliveries_count" bson:"deliveries_count"`
+	DeliveryCount       int        `json:"delivery_count" bson:"delivery_count"`
 	UnprocessedMessages int        `json:"unprocessed_messages" bson:"unprocessed_messages"`
 	MaxAckTimeMs        int64      `json:"max_ack_time_ms" bson:"max_ack_time_ms"`
 	InProcessMessages   int        `json:"in_process_messages" bson:"in_process_messages"`
@@ -59,6 +64,7 @@ type PoisonedCg struct {
 	CgMembers           []CgMember `json:"cg_members" bson:"cg_members"`
 	IsActive            bool       `json:"is_active" bson:"is_active"`
 	IsDeleted           bool       `json:"is_deleted" bson:"is_deleted"`
+	InfoUnavailable     bool       `json:"info_unavailable,omitempty" bson:"-"`
 }
 
 type DlsMessage struct {
@@ -69,6 +75,7 @@ type DlsMessage struct {
 	PoisonedCg   PoisonedCg        `json:"poisoned_cg"`
 	Message      MessagePayloadDls `json:"message"`
 	CreationDate time.Time         `json:"creation_date"`
+	Reason       string            `json:"reason,omitempty"`
 }
 
 type DlsMessageResponse struct {
@@ -79,6 +86,18 @@ type DlsMessageResponse struct {
 	PoisonedCgs  []PoisonedCg      `json:"poisoned_cgs"`
 	Message      MessagePayloadDls `json:"message"`
 	CreationDate time.Time         `json:"creation_date"`
+	AgeInDls     int64             `json:"age_in_dls"` // in seconds
+	Reason       string            `json:"reason"`
+}
+
+type PoisonMessagesBucket struct {
+	Start time.Time `json:"start"`
+	Count int       `json:"count"`
+}
+
+type CgPoisonCount struct {
+	CgName         string `json:"cg_name"`
+	PoisonMessages int    `json:"poison_messages"`
 }
 
 type PmAckMsg struct {
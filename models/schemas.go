@@ -81,6 +81,7 @@ type ProducerSchemaUpdateInit struct {
 	SchemaName    string                      `json:"schema_name"`
 	ActiveVersion ProducerSchemaUpdateVersion `json:"active_version"`
 	SchemaType    string                      `json:"type"`
+	Enforcement   string                      `json:"enforcement"`
 }
 
 type ProducerSchemaUpdateVersion struct {
@@ -90,6 +91,16 @@ type ProducerSchemaUpdateVersion struct {
 	MessageStructName string `json:"message_struct_name"`
 }
 
+type StationActiveSchemaResponse struct {
+	SchemaName        string `json:"schema_name"`
+	SchemaType        string `json:"type"`
+	VersionNumber     int    `json:"version_number"`
+	SchemaContent     string `json:"schema_content"`
+	MessageStructName string `json:"message_struct_name"`
+	Descriptor        string `json:"descriptor"`
+	Enforcement       string `json:"enforcement"`
+}
+
 type GetSchemaDetails struct {
 	SchemaName string `form:"schema_name" json:"schema_name"`
 }
@@ -56,112 +56,424 @@ type MessageDetails struct {
 }
 
 type Station struct {
-	ID                primitive.ObjectID `json:"id" bson:"_id"`
-	Name              string             `json:"name" bson:"name"`
-	RetentionType     string             `json:"retention_type" bson:"retention_type"`
-	RetentionValue    int                `json:"retention_value" bson:"retention_value"`
-	StorageType       string             `json:"storage_type" bson:"storage_type"`
-	Replicas          int                `json:"replicas" bson:"replicas"`
-	DedupEnabled      bool               `json:"dedup_enabled" bson:"dedup_enabled"`           // TODO deprecated
-	DedupWindowInMs   int                `json:"dedup_window_in_ms" bson:"dedup_window_in_ms"` // TODO deprecated
-	CreatedByUser     string             `json:"created_by_user" bson:"created_by_user"`
-	CreationDate      time.Time          `json:"creation_date" bson:"creation_date"`
-	LastUpdate        time.Time          `json:"last_update" bson:"last_update"`
-	Functions         []Function         `json:"functions" bson:"functions"`
-	IsDeleted         bool               `json:"is_deleted" bson:"is_deleted"`
-	Schema            SchemaDetails      `json:"schema" bson:"schema"`
-	IdempotencyWindow int                `json:"idempotency_window_in_ms" bson:"idempotency_window_in_ms"`
-	IsNative          bool               `json:"is_native" bson:"is_native"`
-	DlsConfiguration  DlsConfiguration   `json:"dls_configuration" bson:"dls_configuration"`
+	ID                 primitive.ObjectID `json:"id" bson:"_id"`
+	Name               string             `json:"name" bson:"name"`
+	RetentionType      string             `json:"retention_type" bson:"retention_type"`
+	RetentionValue     int                `json:"retention_value" bson:"retention_value"`
+	StorageType        string             `json:"storage_type" bson:"storage_type"`
+	Replicas           int                `json:"replicas" bson:"replicas"`
+	DedupEnabled       bool               `json:"dedup_enabled" bson:"dedup_enabled"`           // TODO deprecated
+	DedupWindowInMs    int                `json:"dedup_window_in_ms" bson:"dedup_window_in_ms"` // TODO deprecated
+	CreatedByUser      string             `json:"created_by_user" bson:"created_by_user"`
+	CreationDate       time.Time          `json:"creation_date" bson:"creation_date"`
+	LastUpdate         time.Time          `json:"last_update" bson:"last_update"`
+	Functions          []Function         `json:"functions" bson:"functions"`
+	IsDeleted          bool               `json:"is_deleted" bson:"is_deleted"`
+	Schema             SchemaDetails      `json:"schema" bson:"schema"`
+	IdempotencyWindow  int                `json:"idempotency_window_in_ms" bson:"idempotency_window_in_ms"`
+	IsNative           bool               `json:"is_native" bson:"is_native"`
+	DlsConfiguration   DlsConfiguration   `json:"dls_configuration" bson:"dls_configuration"`
+	PartitionKeyHeader string             `json:"partition_key_header" bson:"partition_key_header"`
+	MaxMsgSizeBytes    int                `json:"max_msg_size_bytes" bson:"max_msg_size_bytes"`
+	DeletionProtected  bool               `json:"deletion_protected" bson:"deletion_protected"`
+	SchemaEnforcement  string             `json:"schema_enforcement" bson:"schema_enforcement"`
+	IsPaused           bool               `json:"is_paused" bson:"is_paused"`
+	ReadOnly           bool               `json:"read_only" bson:"read_only"`
+	AllowedProducers   []string           `json:"allowed_producers" bson:"allowed_producers"`
+	AllowedConsumers   []string           `json:"allowed_consumers" bson:"allowed_consumers"`
+	CentralDlsStation  string             `json:"central_dls_station" bson:"central_dls_station"`
+	MaxMsgDeliveries   int                `json:"max_msg_deliveries" bson:"max_msg_deliveries"`
+	Subjects           []string           `json:"subjects" bson:"subjects"`
+	MaxConsumerGroups  int                `json:"max_consumer_groups" bson:"max_consumer_groups"`
+	SchemaRequired     bool               `json:"schema_required" bson:"schema_required"`
+	CompactionKey      string             `json:"compaction_key_header" bson:"compaction_key_header"`
+	Description        string             `json:"description" bson:"description"`
+	Metadata           map[string]string  `json:"metadata" bson:"metadata"`
+	DeletedAt          time.Time          `json:"deleted_at" bson:"deleted_at"`
+	ResourcesRemoved   bool               `json:"resources_removed" bson:"resources_removed"`
+	PendingSchema      *PendingSchema     `json:"pending_schema,omitempty" bson:"pending_schema,omitempty"`
+	RetentionPin       *RetentionPin      `json:"retention_pin,omitempty" bson:"retention_pin,omitempty"`
+	AllowNonNative     *bool              `json:"allow_non_native,omitempty" bson:"allow_non_native,omitempty"`
+	Mirror             string             `json:"mirror,omitempty" bson:"mirror,omitempty"`
+}
+
+type StationDeletionImpact struct {
+	StationName       string        `json:"station_name"`
+	TotalMessages     int           `json:"total_messages"`
+	TotalBytes        int64         `json:"total_bytes"`
+	PoisonMessages    int           `json:"poison_messages"`
+	ActiveProducers   int64         `json:"active_producers"`
+	ActiveConsumers   int64         `json:"active_consumers"`
+	Schema            SchemaDetails `json:"schema"`
+	Tags              []CreateTag   `json:"tags"`
+	DeletionProtected bool          `json:"deletion_protected"`
 }
 
 type GetStationResponseSchema struct {
-	ID                primitive.ObjectID `json:"id" bson:"_id"`
-	Name              string             `json:"name" bson:"name"`
-	RetentionType     string             `json:"retention_type" bson:"retention_type"`
-	RetentionValue    int                `json:"retention_value" bson:"retention_value"`
-	StorageType       string             `json:"storage_type" bson:"storage_type"`
-	Replicas          int                `json:"replicas" bson:"replicas"`
-	DedupEnabled      bool               `json:"dedup_enabled" bson:"dedup_enabled"`           // TODO deprecated
-	DedupWindowInMs   int                `json:"dedup_window_in_ms" bson:"dedup_window_in_ms"` // TODO deprecated
-	CreatedByUser     string             `json:"created_by_user" bson:"created_by_user"`
-	CreationDate      time.Time          `json:"creation_date" bson:"creation_date"`
-	LastUpdate        time.Time          `json:"last_update" bson:"last_update"`
-	Functions         []Function         `json:"functions" bson:"functions"`
-	IsDeleted         bool               `json:"is_deleted" bson:"is_deleted"`
-	Tags              []CreateTag        `json:"tags"`
-	IdempotencyWindow int                `json:"idempotency_window_in_ms" bson:"idempotency_window_in_ms"`
-	IsNative          bool               `json:"is_native" bson:"is_native"`
-	DlsConfiguration  DlsConfiguration   `json:"dls_configuration" bson:"dls_configuration"`
+	ID                  primitive.ObjectID `json:"id" bson:"_id"`
+	Name                string             `json:"name" bson:"name"`
+	RetentionType       string             `json:"retention_type" bson:"retention_type"`
+	RetentionValue      int                `json:"retention_value" bson:"retention_value"`
+	StorageType         string             `json:"storage_type" bson:"storage_type"`
+	Replicas            int                `json:"replicas" bson:"replicas"`
+	DedupEnabled        bool               `json:"dedup_enabled" bson:"dedup_enabled"`           // TODO deprecated
+	DedupWindowInMs     int                `json:"dedup_window_in_ms" bson:"dedup_window_in_ms"` // TODO deprecated
+	CreatedByUser       string             `json:"created_by_user" bson:"created_by_user"`
+	CreationDate        time.Time          `json:"creation_date" bson:"creation_date"`
+	LastUpdate          time.Time          `json:"last_update" bson:"last_update"`
+	Functions           []Function         `json:"functions" bson:"functions"`
+	IsDeleted           bool               `json:"is_deleted" bson:"is_deleted"`
+	Tags                []CreateTag        `json:"tags"`
+	IdempotencyWindow   int                `json:"idempotency_window_in_ms" bson:"idempotency_window_in_ms"`
+	IsNative            bool               `json:"is_native" bson:"is_native"`
+	DlsConfiguration    DlsConfiguration   `json:"dls_configuration" bson:"dls_configuration"`
+	PartitionKeyHeader  string             `json:"partition_key_header" bson:"partition_key_header"`
+	MaxMsgSizeBytes     int                `json:"max_msg_size_bytes" bson:"max_msg_size_bytes"`
+	DeletionProtected   bool               `json:"deletion_protected" bson:"deletion_protected"`
+	SchemaEnforcement   string             `json:"schema_enforcement" bson:"schema_enforcement"`
+	IsPaused            bool               `json:"is_paused" bson:"is_paused"`
+	ReadOnly            bool               `json:"read_only" bson:"read_only"`
+	AllowedProducers    []string           `json:"allowed_producers" bson:"allowed_producers"`
+	AllowedConsumers    []string           `json:"allowed_consumers" bson:"allowed_consumers"`
+	CentralDlsStation   string             `json:"central_dls_station" bson:"central_dls_station"`
+	MaxMsgDeliveries    int                `json:"max_msg_deliveries" bson:"max_msg_deliveries"`
+	Subjects            []string           `json:"subjects" bson:"subjects"`
+	MaxConsumerGroups   int                `json:"max_consumer_groups" bson:"max_consumer_groups"`
+	SchemaRequired      bool               `json:"schema_required" bson:"schema_required"`
+	CompactionKey       string             `json:"compaction_key_header" bson:"compaction_key_header"`
+	Description         string             `json:"description" bson:"description"`
+	Metadata            map[string]string  `json:"metadata" bson:"metadata"`
+	PendingSchema       *PendingSchema     `json:"pending_schema,omitempty" bson:"pending_schema,omitempty"`
+	RetentionPin        *RetentionPin      `json:"retention_pin,omitempty" bson:"retention_pin,omitempty"`
+	AllowNonNative      *bool              `json:"allow_non_native,omitempty" bson:"allow_non_native,omitempty"`
+	Mirror              string             `json:"mirror,omitempty" bson:"mirror,omitempty"`
+	RetentionDescriptor string             `json:"retention_descriptor" bson:"-"`
+	RecentMessages      []MessageDetails   `json:"recent_messages,omitempty" bson:"-"`
 }
 
 type ExtendedStation struct {
-	ID                primitive.ObjectID `json:"id" bson:"_id"`
-	Name              string             `json:"name" bson:"name"`
-	RetentionType     string             `json:"retention_type" bson:"retention_type"`
-	RetentionValue    int                `json:"retention_value" bson:"retention_value"`
-	StorageType       string             `json:"storage_type" bson:"storage_type"`
-	Replicas          int                `json:"replicas" bson:"replicas"`
-	DedupEnabled      bool               `json:"dedup_enabled" bson:"dedup_enabled"`           // TODO deprecated
-	DedupWindowInMs   int                `json:"dedup_window_in_ms" bson:"dedup_window_in_ms"` // TODO deprecated
-	CreatedByUser     string             `json:"created_by_user" bson:"created_by_user"`
-	CreationDate      time.Time          `json:"creation_date" bson:"creation_date"`
-	LastUpdate        time.Time          `json:"last_update" bson:"last_update"`
-	Functions         []Function         `json:"functions" bson:"functions"`
-	TotalMessages     int                `json:"total_messages"`
-	PoisonMessages    int                `json:"posion_messages"`
-	Tags              []CreateTag        `json:"tags"`
-	IdempotencyWindow int                `json:"idempotency_window_in_ms" bson:"idempotency_window_in_ms"`
-	IsNative          bool               `json:"is_native" bson:"is_native"`
-	DlsConfiguration  DlsConfiguration   `json:"dls_configuration" bson:"dls_configuration"`
+	ID                 primitive.ObjectID `json:"id" bson:"_id"`
+	Name               string             `json:"name" bson:"name"`
+	RetentionType      string             `json:"retention_type" bson:"retention_type"`
+	RetentionValue     int                `json:"retention_value" bson:"retention_value"`
+	StorageType        string             `json:"storage_type" bson:"storage_type"`
+	Replicas           int                `json:"replicas" bson:"replicas"`
+	DedupEnabled       bool               `json:"dedup_enabled" bson:"dedup_enabled"`           // TODO deprecated
+	DedupWindowInMs    int                `json:"dedup_window_in_ms" bson:"dedup_window_in_ms"` // TODO deprecated
+	CreatedByUser      string             `json:"created_by_user" bson:"created_by_user"`
+	CreationDate       time.Time          `json:"creation_date" bson:"creation_date"`
+	LastUpdate         time.Time          `json:"last_update" bson:"last_update"`
+	Functions          []Function         `json:"functions" bson:"functions"`
+	TotalMessages      int                `json:"total_messages"`
+	TotalBytes         int64              `json:"total_bytes"`
+	PoisonMessages     int                `json:"posion_messages"`
+	LastProducedAt     *time.Time         `json:"last_produced_at"`
+	LastConsumedAt     *time.Time         `json:"last_consumed_at"`
+	Tags               []CreateTag        `json:"tags"`
+	IdempotencyWindow  int                `json:"idempotency_window_in_ms" bson:"idempotency_window_in_ms"`
+	IsNative           bool               `json:"is_native" bson:"is_native"`
+	DlsConfiguration   DlsConfiguration   `json:"dls_configuration" bson:"dls_configuration"`
+	PartitionKeyHeader string             `json:"partition_key_header" bson:"partition_key_header"`
+	MaxMsgSizeBytes    int                `json:"max_msg_size_bytes" bson:"max_msg_size_bytes"`
+	DeletionProtected  bool               `json:"deletion_protected" bson:"deletion_protected"`
+	SchemaEnforcement  string             `json:"schema_enforcement" bson:"schema_enforcement"`
+	IsPaused           bool               `json:"is_paused" bson:"is_paused"`
+	ReadOnly           bool               `json:"read_only" bson:"read_only"`
+	AllowedProducers   []string           `json:"allowed_producers" bson:"allowed_producers"`
+	AllowedConsumers   []string           `json:"allowed_consumers" bson:"allowed_consumers"`
+	CentralDlsStation  string             `json:"central_dls_station" bson:"central_dls_station"`
+	MaxMsgDeliveries   int                `json:"max_msg_deliveries" bson:"max_msg_deliveries"`
+	Subjects           []string           `json:"subjects" bson:"subjects"`
+	MaxConsumerGroups  int                `json:"max_consumer_groups" bson:"max_consumer_groups"`
+	SchemaRequired     bool               `json:"schema_required" bson:"schema_required"`
+	CompactionKey      string             `json:"compaction_key_header" bson:"compaction_key_header"`
+	Description        string             `json:"description" bson:"description"`
+	Metadata           map[string]string  `json:"metadata" bson:"metadata"`
+	PendingSchema      *PendingSchema     `json:"pending_schema,omitempty" bson:"pending_schema,omitempty"`
+	RetentionPin       *RetentionPin      `json:"retention_pin,omitempty" bson:"retention_pin,omitempty"`
+	AllowNonNative     *bool              `json:"allow_non_native,omitempty" bson:"allow_non_native,omitempty"`
+	Mirror             string             `json:"mirror,omitempty" bson:"mirror,omitempty"`
 }
 
 type ExtendedStationDetails struct {
 	Station        Station     `json:"station"`
 	TotalMessages  int         `json:"total_messages"`
+	TotalBytes     int64       `json:"total_bytes"`
 	PoisonMessages int         `json:"posion_messages"`
 	Tags           []CreateTag `json:"tags"`
+	LastProducedAt *time.Time  `json:"last_produced_at"`
+	LastConsumedAt *time.Time  `json:"last_consumed_at"`
+}
+
+type GetAllStationsSchema struct {
+	SortBy string   `form:"sort_by" json:"sort_by"`
+	Order  string   `form:"order" json:"order"`
+	Fields []string `form:"fields" json:"fields"`
+}
+
+type GetStationsSchema struct {
+	StorageType string `form:"storage_type" json:"storage_type"`
 }
 
 type GetStationSchema struct {
-	StationName string `form:"station_name" json:"station_name" binding:"required"`
+	StationName           string `form:"station_name" json:"station_name" binding:"required"`
+	IncludeRecentMessages int    `form:"include_recent_messages" json:"include_recent_messages" binding:"min=0"`
+	MessagesOrder         string `form:"messages_order" json:"messages_order"`
+}
+
+type GetStationByStreamNameSchema struct {
+	StreamName string `form:"stream_name" json:"stream_name" binding:"required"`
 }
 
 type CreateStationSchema struct {
-	Name              string           `json:"name" binding:"required,min=1,max=32"`
-	RetentionType     string           `json:"retention_type"`
-	RetentionValue    int              `json:"retention_value"`
-	Replicas          int              `json:"replicas"`
-	StorageType       string           `json:"storage_type"`
-	DedupEnabled      bool             `json:"dedup_enabled"`                      // TODO deprecated
-	DedupWindowInMs   int              `json:"dedup_window_in_ms" binding:"min=0"` // TODO deprecated
-	Tags              []CreateTag      `json:"tags"`
-	SchemaName        string           `json:"schema_name"`
-	IdempotencyWindow int              `json:"idempotency_window_in_ms"`
-	DlsConfiguration  DlsConfiguration `json:"dls_configuration"`
+	Name               string            `json:"name" binding:"required,min=1,max=32"`
+	RetentionType      string            `json:"retention_type"`
+	RetentionValue     int               `json:"retention_value"`
+	Replicas           int               `json:"replicas"`
+	StorageType        string            `json:"storage_type"`
+	DedupEnabled       bool              `json:"dedup_enabled"`                      // TODO deprecated
+	DedupWindowInMs    int               `json:"dedup_window_in_ms" binding:"min=0"` // TODO deprecated
+	Tags               []CreateTag       `json:"tags"`
+	SchemaName         string            `json:"schema_name"`
+	SchemaVersion      int               `json:"schema_version"`
+	IdempotencyWindow  int               `json:"idempotency_window_in_ms"`
+	DlsConfiguration   *DlsConfiguration `json:"dls_configuration"`
+	PartitionKeyHeader string            `json:"partition_key_header"`
+	MaxMsgSizeBytes    int               `json:"max_msg_size_bytes"`
+	DeletionProtected  bool              `json:"deletion_protected"`
+	WaitForReady       bool              `json:"wait_for_ready"`
+	SchemaEnforcement  string            `json:"schema_enforcement"`
+	ReadOnly           bool              `json:"read_only"`
+	AllowedProducers   []string          `json:"allowed_producers"`
+	AllowedConsumers   []string          `json:"allowed_consumers"`
+	CentralDlsStation  string            `json:"central_dls_station"`
+	MaxMsgDeliveries   int               `json:"max_msg_deliveries"`
+	Subjects           []string          `json:"subjects"`
+	MaxConsumerGroups  int               `json:"max_consumer_groups"`
+	SchemaRequired     bool              `json:"schema_required"`
+	CompactionKey      string            `json:"compaction_key_header"`
+	Description        string            `json:"description"`
+	Metadata           map[string]string `json:"metadata"`
+	AllowNonNative     *bool             `json:"allow_non_native"`
+	Mirror             string            `json:"mirror"`
+}
+
+type ImportStationDefinitionsSchema struct {
+	Stations []CreateStationSchema `json:"stations" binding:"required"`
+}
+
+type StationImportResult struct {
+	StationName string `json:"station_name"`
+	Status      string `json:"status"`
+	Message     string `json:"message,omitempty"`
+}
+
+type StationFieldDiff struct {
+	Field   string      `json:"field"`
+	Current interface{} `json:"current"`
+	Desired interface{} `json:"desired"`
+}
+
+type StationFieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type StationConfigDrift struct {
+	Field  string      `json:"field"`
+	Stored interface{} `json:"stored"`
+	Actual interface{} `json:"actual"`
 }
 
 type DlsConfiguration struct {
-	Poison      bool `json:"poison" bson:"poison"`
-	Schemaverse bool `json:"schemaverse" bson:"schemaverse"`
+	Poison      bool        `json:"poison" bson:"poison"`
+	Schemaverse bool        `json:"schemaverse" bson:"schemaverse"`
+	Webhook     *DlsWebhook `json:"webhook,omitempty" bson:"webhook,omitempty"`
+}
+
+type DlsWebhook struct {
+	Url           string            `json:"url" bson:"url"`
+	Headers       map[string]string `json:"headers,omitempty" bson:"headers,omitempty"`
+	TlsSkipVerify bool              `json:"tls_skip_verify" bson:"tls_skip_verify"`
+	TlsCaCert     string            `json:"tls_ca_cert,omitempty" bson:"tls_ca_cert,omitempty"`
 }
 
 type UpdateDlsConfigSchema struct {
+	StationName string      `json:"station_name" binding:"required"`
+	Poison      bool        `json:"poison"`
+	Schemaverse bool        `json:"schemaverse"`
+	Webhook     *DlsWebhook `json:"webhook"`
+}
+
+type PinRetentionSchema struct {
+	StationName     string `json:"station_name" binding:"required"`
+	DurationMinutes int    `json:"duration_minutes" binding:"required,min=1"`
+}
+
+type RetentionPin struct {
+	ExpiresAt time.Time `json:"expires_at" bson:"expires_at"`
+	PinnedBy  string    `json:"pinned_by" bson:"pinned_by"`
+	UserType  string    `json:"user_type" bson:"user_type"`
+}
+
+type UpdateMaxMsgSizeSchema struct {
+	StationName     string `json:"station_name" binding:"required"`
+	MaxMsgSizeBytes int    `json:"max_msg_size_bytes" binding:"required"`
+}
+
+type GetStationDlsRateSchema struct {
+	StationName string `form:"station_name" json:"station_name" binding:"required"`
+	Minutes     int    `form:"minutes" json:"minutes"`
+}
+
+type SuggestRetentionSchema struct {
+	StationName string `form:"station_name" json:"station_name" binding:"required"`
+	TargetBytes int64  `form:"target_bytes" json:"target_bytes" binding:"required,min=1"`
+	Minutes     int    `form:"minutes" json:"minutes"`
+}
+
+type GetRetentionStatsSchema struct {
+	StationName string `form:"station_name" json:"station_name" binding:"required"`
+	Minutes     int    `form:"minutes" json:"minutes"`
+}
+
+type RetentionLimitStats struct {
+	Current       int64      `json:"current"`
+	Limit         int64      `json:"limit"`
+	UsagePercent  float64    `json:"usage_percent"`
+	Trimming      bool       `json:"trimming"`
+	TrimmingStart *time.Time `json:"estimated_trimming_start"`
+}
+
+type UpdateSchemaEnforcementSchema struct {
 	StationName string `json:"station_name" binding:"required"`
-	Poison      bool   `json:"poison"`
-	Schemaverse bool   `json:"schemaverse"`
+	Enforcement string `json:"enforcement" binding:"required"`
+}
+
+type PauseStationSchema struct {
+	StationName string `json:"station_name" binding:"required"`
+}
+
+type ReadOnlyStationSchema struct {
+	StationName string `json:"station_name" binding:"required"`
+}
+
+type GetStationsByNamesSchema struct {
+	StationNames []string `form:"station_names" json:"station_names" binding:"required"`
+}
+
+type GetStationSchemaVersionBreakdownSchema struct {
+	StationName string `form:"station_name" json:"station_name" binding:"required"`
+	SampleSize  int    `form:"sample_size" json:"sample_size"`
+}
+
+type GetStationProducersSchemaStatusSchema struct {
+	StationName string `form:"station_name" json:"station_name" binding:"required"`
+	SampleSize  int    `form:"sample_size" json:"sample_size"`
+}
+
+type ProducerSchemaStatus struct {
+	Name          string             `json:"name"`
+	ConnectionId  primitive.ObjectID `json:"connection_id"`
+	SchemaVersion string             `json:"schema_version"`
+	LastSeen      *time.Time         `json:"last_seen"`
+	UpToDate      bool               `json:"up_to_date"`
+}
+
+type SchemaSkew struct {
+	ActiveProducers  int            `json:"active_producers"`
+	CurrentVersion   int            `json:"on_current_version"`
+	OlderVersions    int            `json:"on_older_versions"`
+	NewerVersions    int            `json:"on_newer_versions"`
+	UnknownVersion   int            `json:"unknown_version"`
+	LaggingProducers []string       `json:"lagging_producers"`
+	AheadProducers   []string       `json:"ahead_producers"`
+	Versions         map[string]int `json:"versions"`
+}
+
+type GetPoisonMessageTrendSchema struct {
+	StationName     string `form:"station_name" json:"station_name" binding:"required"`
+	Hours           int    `form:"hours" json:"hours"`
+	IntervalMinutes int    `form:"interval_minutes" json:"interval_minutes"`
+}
+
+type StationExistsSchema struct {
+	StationName string `form:"station_name" json:"station_name" binding:"required"`
+}
+
+type GetStationPoisonByCgSchema struct {
+	StationName string `form:"station_name" json:"station_name" binding:"required"`
+}
+
+type GetAllPoisonMessagesSchema struct {
+	StationName string    `form:"station_name" json:"station_name"`
+	CgName      string    `form:"cg_name" json:"cg_name"`
+	Reason      string    `form:"reason" json:"reason"`
+	From        time.Time `form:"from" json:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To          time.Time `form:"to" json:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Page        int       `form:"page" json:"page" binding:"min=0"`
+	PageSize    int       `form:"page_size" json:"page_size" binding:"min=0"`
 }
 
 type AckPoisonMessagesSchema struct {
 	PoisonMessageIds []string `json:"poison_message_ids" binding:"required"`
+	FetchBatchSize   int      `json:"fetch_batch_size"`
+}
+
+type ReprocessSchemaFailedMessagesSchema struct {
+	StationName string `json:"station_name" binding:"required"`
 }
 
 type ResendPoisonMessagesSchema struct {
 	PoisonMessageIds []string `json:"poison_message_ids" binding:"required"`
+	StripHeaders     []string `json:"strip_headers"`
+	FetchBatchSize   int      `json:"fetch_batch_size"`
+	Async            bool     `json:"async"`
+}
+
+type GetResendJobStatusSchema struct {
+	JobId string `form:"job_id" json:"job_id" binding:"required"`
+}
+
+type ResendJob struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id"`
+	StationName  string             `json:"station_name" bson:"station_name"`
+	Broker       string             `json:"broker" bson:"broker"`
+	Status       string             `json:"status" bson:"status"`
+	TotalIds     int                `json:"total_ids" bson:"total_ids"`
+	ProcessedIds int                `json:"processed_ids" bson:"processed_ids"`
+	ResentMsgs   int                `json:"resent_messages" bson:"resent_messages"`
+	Error        string             `json:"error,omitempty" bson:"error"`
+	CreatedBy    string             `json:"created_by" bson:"created_by"`
+	CreationDate time.Time          `json:"creation_date" bson:"creation_date"`
+	FinishedAt   *time.Time         `json:"finished_at" bson:"finished_at"`
 }
 
 type RemoveStationSchema struct {
-	StationNames []string `json:"station_names" binding:"required"`
+	StationNames               []string `json:"station_names" binding:"required"`
+	OverrideDeletionProtection bool     `json:"override_deletion_protection"`
+}
+
+type FailedStationRemoval struct {
+	StationName string `json:"station_name"`
+	Message     string `json:"message"`
+	Code        string `json:"code"`
+}
+
+type UpdateStationMetadataSchema struct {
+	StationName string            `json:"station_name" binding:"required"`
+	Description string            `json:"description"`
+	Metadata    map[string]string `json:"metadata"`
+}
+
+type UpdateSchemaRequiredSchema struct {
+	StationName    string `json:"station_name" binding:"required"`
+	SchemaRequired bool   `json:"schema_required"`
+}
+
+type UpdateDeletionProtectionSchema struct {
+	StationName       string `json:"station_name" binding:"required"`
+	DeletionProtected bool   `json:"deletion_protected"`
 }
 
 type GetPoisonMessageJourneySchema struct {
@@ -173,15 +485,76 @@ type GetMessageDetailsSchema struct {
 	MessageId       string `form:"message_id" json:"message_id"`
 	MessageSeq      int    `form:"message_seq" json:"message_seq"`
 	StationName     string `form:"station_name" json:"station_name" binding:"required"`
+	Decode          bool   `form:"decode" json:"decode"`
+}
+
+type MessageExistsSchema struct {
+	StationName string `form:"station_name" json:"station_name" binding:"required"`
+	MessageSeq  int    `form:"message_seq" json:"message_seq" binding:"required,min=1"`
+}
+
+type GetMessageByIdSchema struct {
+	MessageId string `form:"message_id" json:"message_id" binding:"required"`
+}
+
+type GetMessagesDetailsSchema struct {
+	StationName string `form:"station_name" json:"station_name" binding:"required"`
+	MessageSeqs []int  `form:"message_seqs" json:"message_seqs"`
+	FromSeq     int    `form:"from_seq" json:"from_seq"`
+	ToSeq       int    `form:"to_seq" json:"to_seq"`
+}
+
+type GetMessagesByTimeRangeSchema struct {
+	StationName string    `form:"station_name" json:"station_name" binding:"required"`
+	From        time.Time `form:"from" json:"from" time_format:"2006-01-02T15:04:05Z07:00" binding:"required"`
+	To          time.Time `form:"to" json:"to" time_format:"2006-01-02T15:04:05Z07:00" binding:"required"`
+	PageSize    int       `form:"page_size" json:"page_size" binding:"min=0"`
+	Cursor      uint64    `form:"cursor" json:"cursor"`
+}
+
+type DeleteMessagesSchema struct {
+	StationName string   `json:"station_name" binding:"required"`
+	MessageSeqs []uint64 `json:"message_seqs" binding:"required"`
+}
+
+type DeletedMessageResult struct {
+	MessageSeq uint64 `json:"message_seq"`
+	Deleted    bool   `json:"deleted"`
+	Error      string `json:"error,omitempty"`
+}
+
+type ValidateStationMessageSchema struct {
+	StationName     string `json:"station_name" binding:"required"`
+	Payload         string `json:"payload" binding:"required"`
+	PayloadEncoding string `json:"payload_encoding"`
+}
+
+type MessageByIdResponse struct {
+	Source     string              `json:"source"`
+	DlsMessage *DlsMessageResponse `json:"dls_message,omitempty"`
+	Message    *MessageResponse    `json:"message,omitempty"`
 }
 
 type UseSchema struct {
-	StationNames []string `json:"station_names" binding:"required"`
-	SchemaName   string   `json:"schema_name" binding:"required"`
+	StationNames []string  `json:"station_names" binding:"required"`
+	SchemaName   string    `json:"schema_name" binding:"required"`
+	EffectiveAt  time.Time `json:"effective_at"`
+}
+
+type UseSchemaByTagSchema struct {
+	TagName    string `json:"tag_name" binding:"required"`
+	SchemaName string `json:"schema_name" binding:"required"`
+}
+
+type StationSchemaAttachResult struct {
+	StationName string `json:"station_name"`
+	Attached    bool   `json:"attached"`
+	Error       string `json:"error,omitempty"`
 }
 
 type RemoveSchemaFromStation struct {
-	StationName string `json:"station_name" binding:"required"`
+	StationName            string `json:"station_name" binding:"required"`
+	OverrideSchemaRequired bool   `json:"override_schema_required"`
 }
 
 type SchemaDetails struct {
@@ -189,6 +562,27 @@ type SchemaDetails struct {
 	VersionNumber int    `json:"version_number" bson:"version_number"`
 }
 
+type PendingSchema struct {
+	SchemaName    string    `json:"name" bson:"name"`
+	VersionNumber int       `json:"version_number" bson:"version_number"`
+	EffectiveAt   time.Time `json:"effective_at" bson:"effective_at"`
+	ScheduledBy   string    `json:"scheduled_by,omitempty" bson:"scheduled_by,omitempty"`
+	UserType      string    `json:"user_type,omitempty" bson:"user_type,omitempty"`
+}
+
+type ReplicaHealth struct {
+	Configured      int  `json:"configured"`
+	InSync          int  `json:"in_sync"`
+	UnderReplicated bool `json:"under_replicated"`
+}
+
+// IdempotencyWindowState compares the stored idempotency window, with the defaults applied, to the stream's duplicates window
+type IdempotencyWindowState struct {
+	ConfiguredMs int64 `json:"configured_ms"`
+	ActualMs     int64 `json:"actual_ms"`
+	Diverged     bool  `json:"diverged"`
+}
+
 type StationOverviewSchemaDetails struct {
 	SchemaName       string `json:"name" bson:"name"`
 	VersionNumber    int    `json:"version_number" bson:"version_number"`
@@ -198,3 +592,20 @@ type StationOverviewSchemaDetails struct {
 type GetUpdatesForSchema struct {
 	StationName string `form:"station_name" json:"station_name" binding:"required"`
 }
+
+type WatchStationSchemaChangesSchema struct {
+	StationName   string    `form:"station_name" json:"station_name" binding:"required"`
+	TimeoutSec    int       `form:"timeout_sec" json:"timeout_sec"`
+	SchemaName    string    `form:"schema_name" json:"schema_name"`
+	VersionNumber int       `form:"version_number" json:"version_number"`
+	LastUpdate    time.Time `form:"last_update" json:"last_update"`
+}
+
+type StationSchemaChange struct {
+	StationName   string    `json:"station_name"`
+	UpdateType    string    `json:"update_type"`
+	SchemaName    string    `json:"schema_name"`
+	VersionNumber int       `json:"version_number"`
+	Enforcement   string    `json:"enforcement"`
+	Time          time.Time `json:"time"`
+}
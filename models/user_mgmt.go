@@ -78,6 +78,11 @@ type EditAnalyticsSchema struct {
 	SendAnalytics bool `json:"send_analytics"`
 }
 
+type EditAnalyticsCategorySchema struct {
+	Category      string `json:"category" binding:"required"`
+	SendAnalytics bool   `json:"send_analytics"`
+}
+
 type GetFilterDetailsSchema struct {
 	Route string `form:"route" json:"route"`
 }
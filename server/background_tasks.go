@@ -145,15 +145,25 @@ func ackPoisonMsgV0(msgId string, cgName string) error {
 	if err != nil {
 		return err
 	}
-	streamName := fmt.Sprintf(dlsStreamName, sn.Intern())
+	station, err := getDlsStation(sn)
+	if err != nil {
+		return err
+	}
+	streamName, _, err := getStationDlsLocation(station)
+	if err != nil {
+		return err
+	}
 	uid := serv.memphis.nuid.Next()
-	durableName := "$memphis_fetch_dls_consumer_" + uid
+	durableName := dlsFetchConsumerPrefix + uid
 	var msgs []StoredMsg
 	streamInfo, err := serv.memphisStreamInfo(streamName)
 	if err != nil {
 		return err
 	}
-	filter := GetDlsSubject("poison", sn.Intern(), msgId)
+	filter, err := getStationDlsSubject(station, "poison", msgId)
+	if err != nil {
+		return err
+	}
 	amount := streamInfo.State.Msgs
 	cc := ConsumerConfig{
 		DeliverPolicy: DeliverAll,
@@ -257,7 +267,16 @@ func (s *Server) ListenForPoisonMsgAcks() error {
 					s.Errorf("ListenForPoisonMsgAcks: " + err.Error())
 					return
 				}
-				streamName := fmt.Sprintf(dlsStreamName, sn.Intern())
+				station, err := getDlsStation(sn)
+				if err != nil {
+					s.Errorf("ListenForPoisonMsgAcks: " + err.Error())
+					return
+				}
+				streamName, _, err := getStationDlsLocation(station)
+				if err != nil {
+					s.Errorf("ListenForPoisonMsgAcks: " + err.Error())
+					return
+				}
 				seq, err := strconv.ParseInt(msgToAck.Sequence, 10, 64)
 				if err != nil {
 					s.Errorf("ListenForPoisonMsgAcks: " + err.Error())
@@ -278,8 +297,153 @@ func (s *Server) ListenForPoisonMsgAcks() error {
 	return nil
 }
 
+const (
+	staleDlsConsumersSweepInterval = 10 * time.Minute
+	staleDlsConsumerThreshold      = 10 * time.Minute
+)
+
+// sweepStaleDlsConsumers removes the ephemeral consumers DLS reads leave behind when they fail midway,
+// those reads last seconds so a consumer older than the threshold is no longer used by anyone
+func (s *Server) sweepStaleDlsConsumers() {
+	streams, err := s.memphisAllStreamsInfo()
+	if err != nil {
+		s.Errorf("sweepStaleDlsConsumers: " + err.Error())
+		return
+	}
+
+	for _, streamInfo := range streams {
+		streamName := streamInfo.Config.Name
+		if !strings.HasPrefix(streamName, "$memphis-") || !strings.HasSuffix(streamName, "-dls") {
+			continue
+		}
+		consumers, err := s.memphisAllConsumersInfo(streamName)
+		if err != nil {
+			s.Errorf("sweepStaleDlsConsumers: Stream " + streamName + ": " + err.Error())
+			continue
+		}
+		for _, consumer := range consumers {
+			if !strings.HasPrefix(consumer.Name, dlsFetchConsumerPrefix) && !strings.HasPrefix(consumer.Name, pcgFetchConsumerPrefix) {
+				continue
+			}
+			if time.Since(consumer.Created) < staleDlsConsumerThreshold {
+				continue
+			}
+			err = s.memphisRemoveConsumer(streamName, consumer.Name)
+			if err != nil && !IsNatsErr(err, JSConsumerNotFoundErr) {
+				s.Errorf("sweepStaleDlsConsumers: Stream " + streamName + ": " + err.Error())
+			}
+		}
+	}
+}
+
+func (s *Server) startStaleDlsConsumersSweeper() {
+	go func() {
+		ticker := time.NewTicker(staleDlsConsumersSweepInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			s.sweepStaleDlsConsumers()
+		}
+	}()
+}
+
+const deletedStationsSweepInterval = 10 * time.Minute
+
+// sweepDeletedStations destroys the streams of the stations removed longer than the deletion grace period ago
+func (s *Server) sweepDeletedStations() {
+	var stations []models.Station
+	filter := bson.M{
+		"is_deleted":        true,
+		"resources_removed": bson.M{"$ne": true},
+		"deleted_at":        bson.M{"$lte": time.Now().Add(-stationDeletionGracePeriod())},
+	}
+	cursor, err := stationsCollection.Find(context.TODO(), filter)
+	if err != nil {
+		s.Errorf("sweepDeletedStations: " + err.Error())
+		return
+	}
+	if err = cursor.All(context.TODO(), &stations); err != nil {
+		s.Errorf("sweepDeletedStations: " + err.Error())
+		return
+	}
+
+	for _, station := range stations {
+		sn, err := StationNameFromStr(station.Name)
+		if err != nil {
+			s.Errorf("sweepDeletedStations: Station " + station.Name + ": " + err.Error())
+			continue
+		}
+		unlock, err := s.lockStationCreation(sn)
+		if err != nil {
+			s.Errorf("sweepDeletedStations: Station " + station.Name + ": " + err.Error())
+			continue
+		}
+		err = destroyDeletedStations(s, bson.M{"_id": station.ID})
+		unlock()
+		if err != nil {
+			s.Errorf("sweepDeletedStations: Station " + station.Name + ": " + err.Error())
+		}
+	}
+}
+
+func (s *Server) startDeletedStationsSweeper() {
+	go func() {
+		ticker := time.NewTicker(deletedStationsSweepInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			s.sweepDeletedStations()
+		}
+	}()
+}
+
+const pendingSchemasActivationInterval = time.Minute
+
+func (s *Server) startPendingSchemasActivator() {
+	go func() {
+		s.activatePendingSchemas()
+		ticker := time.NewTicker(pendingSchemasActivationInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			s.activatePendingSchemas()
+		}
+	}()
+}
+
+const retentionPinsRevertInterval = time.Minute
+
+func (s *Server) startRetentionPinsReverter() {
+	go func() {
+		s.revertExpiredRetentionPins()
+		ticker := time.NewTicker(retentionPinsRevertInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			s.revertExpiredRetentionPins()
+		}
+	}()
+}
+
+const inactiveConsumerGroupsCleanupInterval = time.Hour
+
+func (s *Server) startInactiveConsumerGroupsCleaner() {
+	go func() {
+		ticker := time.NewTicker(inactiveConsumerGroupsCleanupInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			threshold := consumerGroupsInactiveThreshold()
+			if threshold > 0 {
+				s.removeInactiveConsumerGroups(threshold)
+			}
+		}
+	}()
+}
+
 func (s *Server) StartBackgroundTasks() error {
 	s.ListenForPoisonMessages()
+	s.ListenForSchemaFailedMessages()
+	s.startStaleDlsConsumersSweeper()
+	s.startDeletedStationsSweeper()
+	s.startPendingSchemasActivator()
+	s.startRetentionPinsReverter()
+	s.startInactiveConsumerGroupsCleaner()
 	err := s.ListenForZombieConnCheckRequests()
 	if err != nil {
 		return errors.New("Failed subscribing for zombie conns check requests: " + err.Error())
@@ -258,6 +258,7 @@ type consumer struct {
 	rdq               []uint64
 	rdqi              map[uint64]struct{}
 	rdc               map[uint64]uint64
+	mnak              map[uint64]struct{}
 	maxdc             uint64
 	waiting           *waitQueue
 	cfg               ConsumerConfig
@@ -1860,6 +1861,12 @@ func (o *consumer) processNak(sseq, dseq, dc uint64, nak []byte) {
 
 	o.sendAdvisory(o.nakEventT, j)
 
+	// Memphis: remember the client failed the message explicitly, to tell it from messages redelivered on ack timeouts
+	if o.mnak == nil {
+		o.mnak = make(map[uint64]struct{})
+	}
+	o.mnak[sseq] = struct{}{}
+
 	// Check to see if we have delays attached.
 	if len(nak) > len(AckNak) {
 		arg := bytes.TrimSpace(nak[len(AckNak):])
@@ -1960,6 +1967,7 @@ func (o *consumer) checkRedelivered(slseq uint64) {
 	for sseq := range o.rdc {
 		if sseq < o.asflr || sseq > lseq {
 			delete(o.rdc, sseq)
+			delete(o.mnak, sseq)
 			o.removeFromRedeliverQueue(sseq)
 			shouldUpdateState = true
 		}
@@ -2229,6 +2237,7 @@ func (o *consumer) processAckMsg(sseq, dseq, dc uint64, doSample bool) {
 		}
 		// We do these regardless.
 		delete(o.rdc, sseq)
+		delete(o.mnak, sseq)
 		o.removeFromRedeliverQueue(sseq)
 	case AckAll:
 		// no-op
@@ -2244,6 +2253,7 @@ func (o *consumer) processAckMsg(sseq, dseq, dc uint64, doSample bool) {
 		for seq := sseq; seq > sseq-sagap; seq-- {
 			delete(o.pending, seq)
 			delete(o.rdc, seq)
+			delete(o.mnak, seq)
 			o.removeFromRedeliverQueue(seq)
 		}
 	case AckNone:
@@ -2758,6 +2768,18 @@ func trackDownAccountAndInterest(acc *Account, interest string) (*Account, strin
 	return acc, interest
 }
 
+// memphisDeliveryCount returns how many times the message has been delivered,
+// it is known only while the consumer still tracks the message as pending or redelivered.
+func (o *consumer) memphisDeliveryCount(sseq uint64) (uint64, bool) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	rdc, redelivered := o.rdc[sseq]
+	if _, pending := o.pending[sseq]; !pending && !redelivered {
+		return 0, false
+	}
+	return rdc + 1, true
+}
+
 // Increase the delivery count for this message.
 // ONLY used on redelivery semantics.
 // Lock should be held.
@@ -2770,18 +2792,27 @@ func (o *consumer) incDeliveryCount(sseq uint64) uint64 {
 }
 
 // send a delivery exceeded advisory.
+// Lock should be held.
 func (o *consumer) notifyDeliveryExceeded(sseq, dc uint64) {
+	// Memphis: a message the client never failed explicitly ran out of deliveries on ack timeouts
+	reason := DlsReasonAckTimeout
+	if _, naked := o.mnak[sseq]; naked {
+		reason = DlsReasonMaxDeliveries
+	}
+	delete(o.mnak, sseq)
+
 	e := JSConsumerDeliveryExceededAdvisory{
 		TypedEvent: TypedEvent{
 			Type: JSConsumerDeliveryExceededAdvisoryType,
 			ID:   nuid.Next(),
 			Time: time.Now().UTC(),
 		},
-		Stream:     o.stream,
-		Consumer:   o.name,
-		StreamSeq:  sseq,
-		Deliveries: dc,
-		Domain:     o.srv.getOpts().JetStreamDomain,
+		Stream:        o.stream,
+		Consumer:      o.name,
+		StreamSeq:     sseq,
+		Deliveries:    dc,
+		Domain:        o.srv.getOpts().JetStreamDomain,
+		MemphisReason: reason,
 	}
 
 	j, err := json.Marshal(e)
@@ -3037,6 +3068,8 @@ func (o *consumer) loopAndGatherMsgs(qch chan struct{}) {
 	}
 	// Interest changes.
 	inch := o.inch
+	// The broker's internal consumers keep reading a paused Memphis station.
+	memphisInternal := strings.HasPrefix(o.name, "$memphis")
 	o.mu.Unlock()
 
 	// Grab the stream's retention policy
@@ -3074,6 +3107,11 @@ func (o *consumer) loopAndGatherMsgs(qch chan struct{}) {
 			goto waitForMsgs
 		}
 
+		// Nothing is delivered from a paused Memphis station until it is resumed.
+		if !memphisInternal && mset.isMemphisPaused() {
+			goto waitForMsgs
+		}
+
 		// Grab our next msg.
 		pmsg, dc, err = o.getNextMsg()
 
@@ -3565,6 +3603,7 @@ func (o *consumer) checkPending() {
 		if seq < fseq {
 			delete(o.pending, seq)
 			delete(o.rdc, seq)
+			delete(o.mnak, seq)
 			o.removeFromRedeliverQueue(seq)
 			shouldUpdateState = true
 			continue
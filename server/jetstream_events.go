@@ -105,6 +105,8 @@ type JSConsumerDeliveryExceededAdvisory struct {
 	StreamSeq  uint64 `json:"stream_seq"`
 	Deliveries uint64 `json:"deliveries"`
 	Domain     string `json:"domain,omitempty"`
+	// Memphis: whether the message ran out of deliveries on explicit failures or on ack timeouts
+	MemphisReason string `json:"memphis_reason,omitempty"`
 }
 
 // JSConsumerDeliveryExceededAdvisoryType is the schema type for JSConsumerDeliveryExceededAdvisory
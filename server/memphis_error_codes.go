@@ -0,0 +1,61 @@
+// Credit for The NATS.IO Authors
+// Copyright 2021-2022 The Memphis Authors
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.package server
+package server
+
+import "errors"
+
+// error codes returned next to the message of HTTP errors, so clients can branch on the error type
+const (
+	ErrCodeServerError               = "SERVER_ERROR"
+	ErrCodeUnauthorized              = "UNAUTHORIZED"
+	ErrCodeInvalidRequest            = "INVALID_REQUEST"
+	ErrCodeStationNameInvalid        = "STATION_NAME_INVALID"
+	ErrCodeStationNotFound           = "STATION_NOT_FOUND"
+	ErrCodeStationExists             = "STATION_ALREADY_EXISTS"
+	ErrCodeStationDeletionInProgress = "STATION_DELETION_IN_PROGRESS"
+	ErrCodeStationProtected          = "STATION_PROTECTED"
+	ErrCodeStationInUse              = "STATION_IN_USE"
+	ErrCodeSchemaMissing             = "SCHEMA_MISSING"
+	ErrCodeRetentionInvalid          = "RETENTION_INVALID"
+	ErrCodeStorageTypeInvalid        = "STORAGE_TYPE_INVALID"
+	ErrCodeReplicasExceeded          = "REPLICAS_EXCEEDED"
+	ErrCodeMessageNotFound           = "MESSAGE_NOT_FOUND"
+	ErrCodeIdempotencyWindowInvalid  = "IDEMPOTENCY_WINDOW_INVALID"
+)
+
+// codedError attaches an error code to an error that is shown to the client, its message is left untouched
+type codedError struct {
+	code string
+	error
+}
+
+func (e codedError) Unwrap() error {
+	return e.error
+}
+
+func withErrorCode(code string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return codedError{code: code, error: err}
+}
+
+// errorCode returns the code attached to the error, errors without one are considered invalid requests
+func errorCode(err error) string {
+	var ce codedError
+	if errors.As(err, &ce) {
+		return ce.code
+	}
+	return ErrCodeInvalidRequest
+}
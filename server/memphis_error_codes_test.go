@@ -0,0 +1,42 @@
+// Credit for The NATS.IO Authors
+// Copyright 2021-2022 The Memphis Authors
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrorCode(t *testing.T) {
+	if code := errorCode(validateReplicas(6)); code != ErrCodeReplicasExceeded {
+		t.Fatalf("expected %v, got %v", ErrCodeReplicasExceeded, code)
+	}
+	if code := errorCode(validateRetentionType("forever")); code != ErrCodeRetentionInvalid {
+		t.Fatalf("expected %v, got %v", ErrCodeRetentionInvalid, code)
+	}
+
+	_, err := StationNameFromStr("")
+	if code := errorCode(err); code != ErrCodeStationNameInvalid {
+		t.Fatalf("expected %v, got %v", ErrCodeStationNameInvalid, code)
+	}
+	// the code must not change the message shown to the client
+	if err.Error() != "Station name can not be empty" {
+		t.Fatalf("unexpected message %v", err.Error())
+	}
+
+	if code := errorCode(errors.New("some error")); code != ErrCodeInvalidRequest {
+		t.Fatalf("expected %v, got %v", ErrCodeInvalidRequest, code)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"unicode"
 
 	"github.com/gin-gonic/gin"
 	"github.com/nats-io/nuid"
@@ -60,6 +61,8 @@ var schemaVersionCollection *mongo.Collection
 var sandboxUsersCollection *mongo.Collection
 var integrationsCollection *mongo.Collection
 var configurationsCollection *mongo.Collection
+var stationLocksCollection *mongo.Collection
+var resendJobsCollection *mongo.Collection
 var serv *Server
 var configuration = conf.GetConfig()
 
@@ -73,6 +76,14 @@ type srvMemphis struct {
 	fallbackLogQ           *ipQueue
 	jsApiMu                sync.Mutex
 	ws                     memphisWS
+	stationCreationMu      sync.Mutex
+	stationCreationLocks   map[string]*stationCreationLock
+	resendJobsMu           sync.Mutex
+}
+
+type stationCreationLock struct {
+	mu   sync.Mutex
+	refs int
 }
 
 type memphisWS struct {
@@ -103,7 +114,10 @@ func (s *Server) InitializeMemphisHandlers(dbInstance db.DbInstance) {
 	sandboxUsersCollection = db.GetCollection("sandbox_users", serv.memphis.dbClient)
 	integrationsCollection = db.GetCollection("integrations", dbInstance.Client)
 	configurationsCollection = db.GetCollection("configurations", dbInstance.Client)
+	stationLocksCollection = db.GetCollection("station_locks", dbInstance.Client)
+	resendJobsCollection = db.GetCollection("resend_jobs", dbInstance.Client)
 
+	s.failInterruptedResendJobs()
 	s.initializeSDKHandlers()
 	s.initializeConfigurations()
 	s.initWS()
@@ -130,7 +144,23 @@ func IsUserExist(username string) (bool, models.User, error) {
 	return true, user, nil
 }
 
+const defaultMongoRequestTimeout = 30 * time.Second
+
+// requestContext derives the context of Mongo operations made on behalf of an HTTP request,
+// it is cancelled once the client goes away or the configured deadline passes
+func requestContext(c *gin.Context) (context.Context, context.CancelFunc) {
+	timeout := defaultMongoRequestTimeout
+	if configuration.MONGO_REQUEST_TIMEOUT_SEC > 0 {
+		timeout = time.Duration(configuration.MONGO_REQUEST_TIMEOUT_SEC) * time.Second
+	}
+	return context.WithTimeout(c.Request.Context(), timeout)
+}
+
 func IsStationExist(sn StationName) (bool, models.Station, error) {
+	return IsStationExistWithContext(context.TODO(), sn)
+}
+
+func IsStationExistWithContext(ctx context.Context, sn StationName) (bool, models.Station, error) {
 	stationName := sn.Ext()
 	filter := bson.M{
 		"name": stationName,
@@ -140,7 +170,7 @@ func IsStationExist(sn StationName) (bool, models.Station, error) {
 		},
 	}
 	var station models.Station
-	err := stationsCollection.FindOne(context.TODO(), filter).Decode(&station)
+	err := stationsCollection.FindOne(ctx, filter).Decode(&station)
 	if err == mongo.ErrNoDocuments {
 		return false, station, nil
 	} else if err != nil {
@@ -149,6 +179,24 @@ func IsStationExist(sn StationName) (bool, models.Station, error) {
 	return true, station, nil
 }
 
+// isStationNameTaken checks a non deleted station exists without decoding it, only its id is read
+func isStationNameTaken(ctx context.Context, sn StationName) (bool, error) {
+	filter := bson.M{
+		"name": sn.Ext(),
+		"$or": []interface{}{
+			bson.M{"is_deleted": false},
+			bson.M{"is_deleted": bson.M{"$exists": false}},
+		},
+	}
+	err := stationsCollection.FindOne(ctx, filter, options.FindOne().SetProjection(bson.M{"_id": 1})).Err()
+	if err == mongo.ErrNoDocuments {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func IsTagExist(tagName string) (bool, models.Tag, error) {
 	filter := bson.M{
 		"name": tagName,
@@ -199,26 +247,158 @@ func IsProducerExist(producerName string, stationId primitive.ObjectID) (bool, m
 	return true, producer, nil
 }
 
+const (
+	stationLockTTL           = 2 * time.Minute
+	stationLockWaitTimeout   = 30 * time.Second
+	stationLockRetryInterval = 100 * time.Millisecond
+)
+
+// tryStationClusterLock takes a lock shared by all the brokers of the cluster, the document id is the lock
+// and the token identifies its holder. a lock left behind by a broker which went down is dropped once it expires
+func tryStationClusterLock(lockId, token string) (bool, error) {
+	now := time.Now()
+	_, err := stationLocksCollection.InsertOne(context.TODO(), bson.M{"_id": lockId, "token": token, "expires_at": now.Add(stationLockTTL)})
+	if err == nil {
+		return true, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return false, err
+	}
+	_, err = stationLocksCollection.DeleteOne(context.TODO(), bson.M{"_id": lockId, "expires_at": bson.M{"$lt": now}})
+	return false, err
+}
+
+func releaseStationClusterLock(lockId, token string) {
+	_, err := stationLocksCollection.DeleteOne(context.TODO(), bson.M{"_id": lockId, "token": token})
+	if err != nil {
+		serv.Errorf("releaseStationClusterLock: Lock " + lockId + ": " + err.Error())
+	}
+}
+
+// lockStationCreation serializes concurrent creations of the same station, within the broker through a mutex
+// and across the brokers of the cluster through a lock document, the returned function releases the lock
+func (s *Server) lockStationCreation(sn StationName) (func(), error) {
+	s.memphis.stationCreationMu.Lock()
+	if s.memphis.stationCreationLocks == nil {
+		s.memphis.stationCreationLocks = make(map[string]*stationCreationLock)
+	}
+	lock, ok := s.memphis.stationCreationLocks[sn.Intern()]
+	if !ok {
+		lock = &stationCreationLock{}
+		s.memphis.stationCreationLocks[sn.Intern()] = lock
+	}
+	lock.refs++
+	s.memphis.stationCreationMu.Unlock()
+
+	lock.mu.Lock()
+	unlock := func() {
+		lock.mu.Unlock()
+		s.memphis.stationCreationMu.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(s.memphis.stationCreationLocks, sn.Intern())
+		}
+		s.memphis.stationCreationMu.Unlock()
+	}
+
+	lockId := "creation_" + sn.Intern()
+	token := primitive.NewObjectID().Hex()
+	deadline := time.Now().Add(stationLockWaitTimeout)
+	for {
+		locked, err := tryStationClusterLock(lockId, token)
+		if err != nil {
+			unlock()
+			return nil, err
+		}
+		if locked {
+			break
+		}
+		if time.Now().After(deadline) {
+			unlock()
+			return nil, ErrStationCreationInProgress
+		}
+		time.Sleep(stationLockRetryInterval)
+	}
+
+	return func() {
+		releaseStationClusterLock(lockId, token)
+		unlock()
+	}, nil
+}
+
+// markStationDeletion flags the station's stream as being removed so creations of the same name on any
+// broker can detect it, each deletion has its own flag document, the returned function clears it
+func (s *Server) markStationDeletion(sn StationName) (func(), error) {
+	lockId := "deletion_" + sn.Intern() + "_" + primitive.NewObjectID().Hex()
+	_, err := stationLocksCollection.InsertOne(context.TODO(), bson.M{"_id": lockId, "deleted_station": sn.Intern(), "expires_at": time.Now().Add(stationLockTTL)})
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		_, err := stationLocksCollection.DeleteOne(context.TODO(), bson.M{"_id": lockId})
+		if err != nil {
+			serv.Errorf("markStationDeletion: Station " + sn.Ext() + ": " + err.Error())
+		}
+	}, nil
+}
+
+func (s *Server) isStationDeletionInProgress(sn StationName) (bool, error) {
+	count, err := stationLocksCollection.CountDocuments(context.TODO(), bson.M{"deleted_station": sn.Intern(), "expires_at": bson.M{"$gt": time.Now()}})
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 func CreateDefaultStation(s *Server, sn StationName, username string) (models.Station, bool, error) {
+	unlock, err := s.lockStationCreation(sn)
+	if err != nil {
+		return models.Station{}, false, err
+	}
+	defer unlock()
+
+	deletionInProgress, err := s.isStationDeletionInProgress(sn)
+	if err != nil {
+		return models.Station{}, false, err
+	}
+	if deletionInProgress {
+		return models.Station{}, false, ErrStationDeletionInProgress
+	}
+
+	exist, station, err := IsStationExist(sn)
+	if err != nil {
+		return station, false, err
+	}
+	if exist {
+		return station, false, nil
+	}
+
 	var newStation models.Station
 	stationName := sn.Ext()
+	defaults := getStationDefaults()
 	newStation = models.Station{
 		ID:                primitive.NewObjectID(),
 		Name:              stationName,
-		RetentionType:     "message_age_sec",
-		RetentionValue:    604800,
-		StorageType:       "file",
-		Replicas:          1,
+		RetentionType:     defaults.RetentionType,
+		RetentionValue:    defaults.RetentionValue,
+		StorageType:       defaults.StorageType,
+		Replicas:          defaults.Replicas,
 		DedupEnabled:      false, // TODO deprecated
 		DedupWindowInMs:   0,     // TODO deprecated
 		CreatedByUser:     username,
 		CreationDate:      time.Now(),
 		LastUpdate:        time.Now(),
 		Functions:         []models.Function{},
-		IdempotencyWindow: 120000,
+		IdempotencyWindow: defaults.IdempotencyWindow,
+	}
+
+	err = s.purgeDeletedStation(sn)
+	if err != nil {
+		return newStation, false, err
 	}
 
-	err := s.CreateStream(sn, newStation)
+	err = s.CreateStream(sn, newStation)
 	if err != nil {
 		return newStation, false, err
 	}
@@ -273,6 +453,54 @@ func shouldSendAnalytics() (bool, error) {
 	}
 }
 
+const (
+	analyticsCategoryStation = "station"
+	analyticsCategorySchema  = "schema"
+	analyticsCategoryPoison  = "poison"
+	analyticsCategoryBrowse  = "browse"
+)
+
+func validateAnalyticsCategory(category string) error {
+	if category != analyticsCategoryStation && category != analyticsCategorySchema && category != analyticsCategoryPoison && category != analyticsCategoryBrowse {
+		return errors.New("analytics category has to be one of the following station/schema/poison/browse")
+	}
+
+	return nil
+}
+
+// shouldSendAnalyticsForCategory checks the global analytics flag and then the category's own flag,
+// categories are enabled unless explicitly disabled
+func shouldSendAnalyticsForCategory(category string) (bool, error) {
+	send, err := shouldSendAnalytics()
+	if err != nil || !send {
+		return false, err
+	}
+
+	filter := bson.M{"key": "analytics_" + category}
+	var systemKey models.SystemKey
+	err = systemKeysCollection.FindOne(context.TODO(), filter).Decode(&systemKey)
+	if err == mongo.ErrNoDocuments {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return systemKey.Value != "false", nil
+}
+
+// validateNameCharacters rejects non ASCII characters before any case mapping is applied, since unicode
+// lowercasing can turn a character into several runes (e.g. the Turkish 'İ') or into an ASCII letter (e.g. the Kelvin sign)
+func validateNameCharacters(name, objectType string) error {
+	for i, r := range []rune(name) {
+		if r > unicode.MaxASCII {
+			return fmt.Errorf("%v name contains the non ASCII character %q (%U) at position %v, only alphanumeric and the '_', '-', '.' characters are allowed", objectType, r, r, i+1)
+		}
+	}
+
+	return nil
+}
+
 func validateName(name, objectType string) error {
 	emptyErrStr := fmt.Sprintf("%v name can not be empty", objectType)
 	tooLongErrStr := fmt.Sprintf("%v should be under 32 characters", objectType)
@@ -288,6 +516,11 @@ func validateName(name, objectType string) error {
 		return emptyErr
 	}
 
+	err := validateNameCharacters(name, objectType)
+	if err != nil {
+		return err
+	}
+
 	if len(name) > 32 {
 		return tooLongErr
 	}
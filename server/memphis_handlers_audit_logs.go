@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type AuditLogsHandler struct{}
@@ -52,6 +53,41 @@ func (ah AuditLogsHandler) GetAuditLogsByStation(station models.Station) ([]mode
 	return auditLogs, nil
 }
 
+// getStationAuditLogsPage returns a page of the station's audit logs in chronological order and the total amount
+// of logs matching the filter, zero from and to times leave the range open
+func getStationAuditLogsPage(ctx context.Context, stationName StationName, from, to time.Time, page, pageSize int) ([]models.AuditLog, int64, error) {
+	// some of the logs were written with the internal station name
+	filter := bson.M{"station_name": bson.M{"$in": []string{stationName.Ext(), stationName.Intern()}}}
+	dateFilter := bson.M{}
+	if !from.IsZero() {
+		dateFilter["$gte"] = from
+	}
+	if !to.IsZero() {
+		dateFilter["$lte"] = to
+	}
+	if len(dateFilter) > 0 {
+		filter["creation_date"] = dateFilter
+	}
+
+	total, err := auditLogsCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{"creation_date", 1}, {"_id", 1}}).SetSkip(int64(page * pageSize)).SetLimit(int64(pageSize))
+	cursor, err := auditLogsCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	auditLogs := []models.AuditLog{}
+	if err = cursor.All(ctx, &auditLogs); err != nil {
+		return nil, 0, err
+	}
+
+	return auditLogs, total, nil
+}
+
 func RemoveAllAuditLogsByStation(stationName string) error {
 	_, err := auditLogsCollection.DeleteMany(context.TODO(), bson.M{"station_name": stationName})
 	if err != nil {
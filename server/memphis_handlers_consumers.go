@@ -17,11 +17,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"sort"
 
 	"memphis-broker/analytics"
 	"memphis-broker/models"
 	"memphis-broker/utils"
+	"strconv"
 	"strings"
 	"time"
 
@@ -36,9 +38,49 @@ import (
 type ConsumersHandler struct{ S *Server }
 
 const (
-	consumerObjectName = "Consumer"
+	consumerObjectName       = "Consumer"
+	maxConsumerMsgDeliveries = 10
 )
 
+// resolveMaxMsgDeliveries picks the max deliveries of a new consumer, a value set on the consumer overrides
+// the station default which overrides the server default, it also returns the level that supplied the value.
+// a consumer value above the cap is clamped here so the stored and logged value is the one actually applied
+func resolveMaxMsgDeliveries(requested int, station models.Station) (int, string) {
+	if requested > maxConsumerMsgDeliveries {
+		return maxConsumerMsgDeliveries, "consumer"
+	}
+	if requested > 0 {
+		return requested, "consumer"
+	}
+	if station.MaxMsgDeliveries > 0 {
+		return station.MaxMsgDeliveries, "station"
+	}
+	if configuration.CONSUMER_DEFAULT_MAX_MSG_DELIVERIES > 0 && configuration.CONSUMER_DEFAULT_MAX_MSG_DELIVERIES <= maxConsumerMsgDeliveries {
+		return configuration.CONSUMER_DEFAULT_MAX_MSG_DELIVERIES, "server"
+	}
+	return maxConsumerMsgDeliveries, "built-in"
+}
+
+// resolveMaxConsumerGroups returns the cap of consumer groups of the station, 0 means unlimited
+func resolveMaxConsumerGroups(station models.Station) int {
+	if station.MaxConsumerGroups > 0 {
+		return station.MaxConsumerGroups
+	}
+	if configuration.CONSUMER_GROUPS_DEFAULT_MAX_PER_STATION > 0 {
+		return configuration.CONSUMER_GROUPS_DEFAULT_MAX_PER_STATION
+	}
+	return 0
+}
+
+// countStationConsumerGroups counts the distinct consumer groups of the station's non deleted consumers
+func countStationConsumerGroups(stationId primitive.ObjectID) (int, error) {
+	cgs, err := consumersCollection.Distinct(context.TODO(), "consumers_group", bson.M{"station_id": stationId, "is_deleted": false})
+	if err != nil {
+		return 0, err
+	}
+	return len(cgs), nil
+}
+
 func validateConsumerName(consumerName string) error {
 	return validateName(consumerName, consumerObjectName)
 }
@@ -62,10 +104,10 @@ func isConsumerGroupExist(consumerGroup string, stationId primitive.ObjectID) (b
 	return true, consumer, nil
 }
 
-func GetConsumerGroupMembers(cgName string, station models.Station) ([]models.CgMember, error) {
+func GetConsumerGroupMembers(ctx context.Context, cgName string, station models.Station) ([]models.CgMember, error) {
 	var consumers []models.CgMember
 
-	cursor, err := consumersCollection.Aggregate(context.TODO(), mongo.Pipeline{
+	cursor, err := consumersCollection.Aggregate(ctx, mongo.Pipeline{
 		bson.D{{"$match", bson.D{{"consumers_group", cgName}, {"station_id", station.ID}}}},
 		bson.D{{"$sort", bson.D{{"creation_date", -1}}}},
 		bson.D{{"$lookup", bson.D{{"from", "connections"}, {"localField", "connection_id"}, {"foreignField", "_id"}, {"as", "connection"}}}},
@@ -77,7 +119,7 @@ func GetConsumerGroupMembers(cgName string, station models.Station) ([]models.Cg
 		return consumers, err
 	}
 
-	if err = cursor.All(context.TODO(), &consumers); err != nil {
+	if err = cursor.All(ctx, &consumers); err != nil {
 		return consumers, err
 	}
 
@@ -199,7 +241,7 @@ func (s *Server) createConsumerDirect(c *client, reply string, msg []byte) {
 				serv.Errorf("createConsumerDirect: " + errMsg)
 			}
 
-			shouldSendAnalytics, _ := shouldSendAnalytics()
+			shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategoryStation)
 			if shouldSendAnalytics {
 				param := analytics.EventParam{
 					Name:  "station-name",
@@ -211,6 +253,27 @@ func (s *Server) createConsumerDirect(c *client, reply string, msg []byte) {
 		}
 	}
 
+	if !isClientAllowed(station.AllowedConsumers, name) {
+		errMsg := "Consumer " + name + " is not allowed to consume from station " + stationName.Ext()
+		serv.Warnf("createConsumerDirect: " + errMsg)
+		var auditLogs []interface{}
+		newAuditLog := models.AuditLog{
+			ID:            primitive.NewObjectID(),
+			StationName:   stationName.Ext(),
+			Message:       errMsg + ", connection was rejected",
+			CreatedByUser: c.memphisInfo.username,
+			CreationDate:  time.Now(),
+			UserType:      "application",
+		}
+		auditLogs = append(auditLogs, newAuditLog)
+		err = CreateAuditLogs(auditLogs)
+		if err != nil {
+			serv.Errorf("createConsumerDirect: Consumer " + ccr.Name + " at station " + ccr.StationName + ": " + err.Error())
+		}
+		respondWithErr(s, reply, errors.New("memphis: "+errMsg))
+		return
+	}
+
 	exist, _, err = IsConsumerExist(name, station.ID)
 	if err != nil {
 		errMsg := "Consumer " + ccr.Name + " at station " + ccr.StationName + ": " + err.Error()
@@ -232,6 +295,24 @@ func (s *Server) createConsumerDirect(c *client, reply string, msg []byte) {
 		respondWithErr(s, reply, err)
 		return
 	}
+	if maxConsumerGroups := resolveMaxConsumerGroups(station); !consumerGroupExist && maxConsumerGroups > 0 {
+		cgsCount, err := countStationConsumerGroups(station.ID)
+		if err != nil {
+			errMsg := "Consumer " + ccr.Name + " at station " + ccr.StationName + ": " + err.Error()
+			serv.Errorf("createConsumerDirect: " + errMsg)
+			respondWithErr(s, reply, err)
+			return
+		}
+		if cgsCount >= maxConsumerGroups {
+			errMsg := "Consumer " + ccr.Name + " at station " + ccr.StationName + ": Station has reached its limit of " + strconv.Itoa(maxConsumerGroups) + " consumer groups"
+			serv.Warnf("createConsumerDirect: " + errMsg)
+			respondWithErr(s, reply, errors.New("memphis: "+errMsg))
+			return
+		}
+	}
+
+	maxMsgDeliveries, maxMsgDeliveriesSource := resolveMaxMsgDeliveries(ccr.MaxMsgDeliveries, station)
+	serv.Noticef("createConsumerDirect: Consumer " + name + " at station " + stationName.Ext() + ": max message deliveries " + strconv.Itoa(maxMsgDeliveries) + " set by the " + maxMsgDeliveriesSource + " level")
 
 	newConsumer := models.Consumer{
 		ID:               primitive.NewObjectID(),
@@ -245,7 +326,7 @@ func (s *Server) createConsumerDirect(c *client, reply string, msg []byte) {
 		CreationDate:     time.Now(),
 		IsDeleted:        false,
 		MaxAckTimeMs:     int64(ccr.MaxAckTimeMillis),
-		MaxMsgDeliveries: ccr.MaxMsgDeliveries,
+		MaxMsgDeliveries: maxMsgDeliveries,
 	}
 
 	if consumerGroupExist {
@@ -309,7 +390,7 @@ func (s *Server) createConsumerDirect(c *client, reply string, msg []byte) {
 			serv.Errorf("createConsumerDirect: " + errMsg)
 		}
 
-		shouldSendAnalytics, _ := shouldSendAnalytics()
+		shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategoryStation)
 		if shouldSendAnalytics {
 			param := analytics.EventParam{
 				Name:  "consumer-name",
@@ -325,8 +406,11 @@ func (s *Server) createConsumerDirect(c *client, reply string, msg []byte) {
 }
 
 func (ch ConsumersHandler) GetAllConsumers(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
 	var consumers []models.ExtendedConsumer
-	cursor, err := consumersCollection.Aggregate(context.TODO(), mongo.Pipeline{
+	cursor, err := consumersCollection.Aggregate(ctx, mongo.Pipeline{
 		bson.D{{"$match", bson.D{}}},
 		bson.D{{"$lookup", bson.D{{"from", "stations"}, {"localField", "station_id"}, {"foreignField", "_id"}, {"as", "station"}}}},
 		bson.D{{"$unwind", bson.D{{"path", "$station"}, {"preserveNullAndEmptyArrays", true}}}},
@@ -340,7 +424,7 @@ func (ch ConsumersHandler) GetAllConsumers(c *gin.Context) {
 		return
 	}
 
-	if err = cursor.All(context.TODO(), &consumers); err != nil {
+	if err = cursor.All(ctx, &consumers); err != nil {
 		serv.Errorf("GetAllConsumers: " + err.Error())
 		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
 		return
@@ -353,11 +437,11 @@ func (ch ConsumersHandler) GetAllConsumers(c *gin.Context) {
 	}
 }
 
-func (ch ConsumersHandler) GetCgsByStation(stationName StationName, station models.Station) ([]models.Cg, []models.Cg, []models.Cg, error) { // for socket io endpoint
+func (ch ConsumersHandler) GetCgsByStation(ctx context.Context, stationName StationName, station models.Station) ([]models.Cg, []models.Cg, []models.Cg, error) { // for socket io endpoint
 	var cgs []models.Cg
 	var consumers []models.ExtendedConsumer
 
-	cursor, err := consumersCollection.Aggregate(context.TODO(), mongo.Pipeline{
+	cursor, err := consumersCollection.Aggregate(ctx, mongo.Pipeline{
 		bson.D{{"$match", bson.D{{"station_id", station.ID}}}},
 		bson.D{{"$sort", bson.D{{"creation_date", -1}}}},
 		bson.D{{"$lookup", bson.D{{"from", "connections"}, {"localField", "connection_id"}, {"foreignField", "_id"}, {"as", "connection"}}}},
@@ -369,7 +453,7 @@ func (ch ConsumersHandler) GetCgsByStation(stationName StationName, station mode
 		return cgs, cgs, cgs, err
 	}
 
-	if err = cursor.All(context.TODO(), &consumers); err != nil {
+	if err = cursor.All(ctx, &consumers); err != nil {
 		return cgs, cgs, cgs, err
 	}
 
@@ -472,6 +556,9 @@ func (ch ConsumersHandler) GetCgsByStation(stationName StationName, station mode
 
 // TODO fix it
 func (ch ConsumersHandler) GetAllConsumersByStation(c *gin.Context) { // for REST endpoint
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
 	var body models.GetAllConsumersByStationSchema
 	ok := utils.Validate(c, &body, false, nil)
 	if !ok {
@@ -484,7 +571,7 @@ func (ch ConsumersHandler) GetAllConsumersByStation(c *gin.Context) { // for RES
 		return
 	}
 
-	exist, station, err := IsStationExist(sn)
+	exist, station, err := IsStationExistWithContext(ctx, sn)
 	if err != nil {
 		serv.Errorf("GetAllConsumersByStation: At station " + body.StationName + ": " + err.Error())
 		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
@@ -497,7 +584,7 @@ func (ch ConsumersHandler) GetAllConsumersByStation(c *gin.Context) { // for RES
 	}
 
 	var consumers []models.ExtendedConsumer
-	cursor, err := consumersCollection.Aggregate(context.TODO(), mongo.Pipeline{
+	cursor, err := consumersCollection.Aggregate(ctx, mongo.Pipeline{
 		bson.D{{"$match", bson.D{{"station_id", station.ID}}}},
 		bson.D{{"$lookup", bson.D{{"from", "stations"}, {"localField", "station_id"}, {"foreignField", "_id"}, {"as", "station"}}}},
 		bson.D{{"$unwind", bson.D{{"path", "$station"}, {"preserveNullAndEmptyArrays", true}}}},
@@ -513,7 +600,7 @@ func (ch ConsumersHandler) GetAllConsumersByStation(c *gin.Context) { // for RES
 		return
 	}
 
-	if err = cursor.All(context.TODO(), &consumers); err != nil {
+	if err = cursor.All(ctx, &consumers); err != nil {
 		errMsg := "Station " + body.StationName + ": " + err.Error()
 		serv.Errorf("GetAllConsumersByStation: " + errMsg)
 		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
@@ -527,6 +614,142 @@ func (ch ConsumersHandler) GetAllConsumersByStation(c *gin.Context) { // for RES
 	}
 }
 
+// ResetConsumerGroup moves a consumer group back (or forward) to a sequence, a time or the start of the station
+// so its messages can be replayed without recreating the consumer group
+func (ch ConsumersHandler) ResetConsumerGroup(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if err := DenyForSandboxEnv(c); err != nil {
+		return
+	}
+	var body models.ResetConsumerGroupSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	target := strings.ToLower(body.Target)
+	if target != "seq" && target != "time" && target != "all" {
+		errMsg := "target can be one of the following seq/time/all"
+		serv.Warnf("ResetConsumerGroup: Station " + body.StationName + ": " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg})
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("ResetConsumerGroup: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error()})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("ResetConsumerGroup: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("ResetConsumerGroup: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg})
+		return
+	}
+
+	cgName := strings.ToLower(body.CgName)
+	exist, _, err = isConsumerGroupExist(cgName, station.ID)
+	if err != nil {
+		serv.Errorf("ResetConsumerGroup: Station " + body.StationName + ": Consumer group " + body.CgName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		return
+	}
+	if !exist {
+		errMsg := "Consumer group " + cgName + " does not exist in station " + stationName.Ext()
+		serv.Warnf("ResetConsumerGroup: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg})
+		return
+	}
+
+	streamInfo, err := ch.S.memphisStreamInfo(stationName.Intern())
+	if err != nil {
+		serv.Errorf("ResetConsumerGroup: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		return
+	}
+
+	// the target has to be within the messages the station still retains
+	var position string
+	deliverPolicy := DeliverAll
+	var startSeq uint64
+	var startTime *time.Time
+	switch target {
+	case "seq":
+		if streamInfo.State.Msgs == 0 || body.Sequence < streamInfo.State.FirstSeq || body.Sequence > streamInfo.State.LastSeq {
+			errMsg := fmt.Sprintf("Sequence %v is out of the retained range of station %v (%v-%v)", body.Sequence, stationName.Ext(), streamInfo.State.FirstSeq, streamInfo.State.LastSeq)
+			serv.Warnf("ResetConsumerGroup: " + errMsg)
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg})
+			return
+		}
+		deliverPolicy = DeliverByStartSequence
+		startSeq = body.Sequence
+		position = "sequence " + strconv.FormatUint(body.Sequence, 10)
+	case "time":
+		if streamInfo.State.Msgs == 0 || body.Time.Before(streamInfo.State.FirstTime) || body.Time.After(time.Now()) {
+			errMsg := "Time " + body.Time.Format(time.RFC3339) + " is out of the retained range of station " + stationName.Ext()
+			serv.Warnf("ResetConsumerGroup: " + errMsg)
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg})
+			return
+		}
+		deliverPolicy = DeliverByStartTime
+		startTime = &body.Time
+		position = "time " + body.Time.Format(time.RFC3339)
+	default:
+		position = "the first retained message"
+	}
+
+	user, err := getUserDetailsFromMiddleware(c)
+	if err != nil {
+		serv.Errorf("ResetConsumerGroup: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(401, gin.H{"message": "Unauthorized"})
+		return
+	}
+
+	err = ch.S.ResetCgPosition(stationName, cgName, deliverPolicy, startSeq, startTime)
+	if errors.Is(err, ErrCgPositionRefused) {
+		serv.Warnf("ResetConsumerGroup: Station " + body.StationName + ": Consumer group " + body.CgName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error()})
+		return
+	} else if errors.Is(err, ErrCgRecreatedAtOriginalStart) {
+		serv.Errorf("ResetConsumerGroup: Station " + body.StationName + ": Consumer group " + body.CgName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Consumer group " + cgName + ": " + ErrCgRecreatedAtOriginalStart.Error()})
+		return
+	} else if err != nil {
+		serv.Errorf("ResetConsumerGroup: Station " + body.StationName + ": Consumer group " + body.CgName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		return
+	}
+
+	message := "Consumer group " + cgName + " of station " + stationName.Ext() + " has been reset to " + position + " by user " + user.Username
+	serv.Noticef(message)
+	var auditLogs []interface{}
+	newAuditLog := models.AuditLog{
+		ID:            primitive.NewObjectID(),
+		StationName:   stationName.Ext(),
+		Message:       message,
+		CreatedByUser: user.Username,
+		CreationDate:  time.Now(),
+		UserType:      user.UserType,
+	}
+	auditLogs = append(auditLogs, newAuditLog)
+	err = CreateAuditLogs(auditLogs)
+	if err != nil {
+		serv.Warnf("ResetConsumerGroup: Station " + body.StationName + " - create audit logs error: " + err.Error())
+	}
+
+	c.IndentedJSON(200, gin.H{})
+}
+
 func (s *Server) destroyConsumerDirect(c *client, reply string, msg []byte) {
 	var dcr destroyConsumerRequest
 	if err := json.Unmarshal(msg, &dcr); err != nil {
@@ -626,7 +849,7 @@ func (s *Server) destroyConsumerDirect(c *client, reply string, msg []byte) {
 		serv.Errorf("DestroyConsumer: " + errMsg)
 	}
 
-	shouldSendAnalytics, _ := shouldSendAnalytics()
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategoryStation)
 	if shouldSendAnalytics {
 		analytics.SendEvent(c.memphisInfo.username, "user-remove-consumer")
 	}
@@ -635,6 +858,196 @@ func (s *Server) destroyConsumerDirect(c *client, reply string, msg []byte) {
 	return
 }
 
+func consumerGroupsInactiveThreshold() time.Duration {
+	return time.Duration(configuration.CONSUMER_GROUPS_INACTIVE_THRESHOLD_HOURS) * time.Hour
+}
+
+// cgLastActivity returns the last time the consumer group's durable consumer delivered or had a message acked,
+// its creation time when it has not done either yet
+func cgLastActivity(info *ConsumerInfo) time.Time {
+	lastActivity := info.Created
+	if info.Delivered.Last != nil && info.Delivered.Last.After(lastActivity) {
+		lastActivity = *info.Delivered.Last
+	}
+	if info.AckFloor.Last != nil && info.AckFloor.Last.After(lastActivity) {
+		lastActivity = *info.AckFloor.Last
+	}
+	return lastActivity
+}
+
+// findInactiveConsumerGroups lists the consumer groups with no connected member and no activity for longer than the threshold,
+// a group whose durable consumer is already gone is listed with no last activity
+func (s *Server) findInactiveConsumerGroups(threshold time.Duration) ([]models.InactiveConsumerGroup, error) {
+	inactiveCgs := []models.InactiveConsumerGroup{}
+	var stations []models.Station
+	cursor, err := stationsCollection.Find(context.TODO(), bson.M{"$or": []interface{}{
+		bson.M{"is_deleted": false},
+		bson.M{"is_deleted": bson.M{"$exists": false}},
+	}})
+	if err != nil {
+		return inactiveCgs, err
+	}
+	if err = cursor.All(context.TODO(), &stations); err != nil {
+		return inactiveCgs, err
+	}
+
+	for _, station := range stations {
+		sn, err := StationNameFromStr(station.Name)
+		if err != nil {
+			return inactiveCgs, err
+		}
+		cgs, err := consumersCollection.Distinct(context.TODO(), "consumers_group", bson.M{"station_id": station.ID, "is_deleted": false})
+		if err != nil {
+			return inactiveCgs, err
+		}
+		for _, cg := range cgs {
+			cgName, ok := cg.(string)
+			if !ok {
+				continue
+			}
+			members, err := GetConsumerGroupMembers(context.TODO(), cgName, station)
+			if err != nil {
+				return inactiveCgs, err
+			}
+			isActive, isDeleted := getCgStatus(members)
+			if isActive || isDeleted {
+				continue
+			}
+
+			var lastActivity time.Time
+			info, err := s.GetCgInfo(sn, cgName)
+			if err != nil && !IsNatsErr(err, JSConsumerNotFoundErr) {
+				return inactiveCgs, err
+			}
+			if err == nil {
+				lastActivity = cgLastActivity(info)
+				if time.Since(lastActivity) < threshold {
+					continue
+				}
+			}
+
+			inactiveCgs = append(inactiveCgs, models.InactiveConsumerGroup{
+				StationName:  sn.Ext(),
+				CgName:       cgName,
+				Members:      len(members),
+				LastActivity: lastActivity,
+			})
+		}
+	}
+
+	return inactiveCgs, nil
+}
+
+// removeInactiveConsumerGroup marks the group's members deleted and removes its durable consumer and poison messages,
+// the same way destroying the group's last consumer does
+func (s *Server) removeInactiveConsumerGroup(sn StationName, cgName string) error {
+	exist, station, err := IsStationExist(sn)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return nil
+	}
+
+	_, err = consumersCollection.UpdateMany(context.TODO(),
+		bson.M{"station_id": station.ID, "consumers_group": cgName, "is_deleted": false, "is_active": false},
+		bson.M{"$set": bson.M{"is_deleted": true}},
+	)
+	if err != nil {
+		return err
+	}
+
+	// a member could have connected meanwhile
+	count, err := consumersCollection.CountDocuments(context.TODO(), bson.M{"station_id": station.ID, "consumers_group": cgName, "is_deleted": false})
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	err = s.RemoveConsumer(sn, cgName)
+	if err != nil && !IsNatsErr(err, JSConsumerNotFoundErr) {
+		return err
+	}
+
+	return RemovePoisonedCg(sn, cgName)
+}
+
+// removeInactiveConsumerGroups cleans up the consumer groups inactive for longer than the threshold
+func (s *Server) removeInactiveConsumerGroups(threshold time.Duration) {
+	inactiveCgs, err := s.findInactiveConsumerGroups(threshold)
+	if err != nil {
+		s.Errorf("removeInactiveConsumerGroups: " + err.Error())
+		return
+	}
+
+	var auditLogs []interface{}
+	for _, cg := range inactiveCgs {
+		sn, err := StationNameFromStr(cg.StationName)
+		if err != nil {
+			s.Errorf("removeInactiveConsumerGroups: Station " + cg.StationName + ": " + err.Error())
+			continue
+		}
+		err = s.removeInactiveConsumerGroup(sn, cg.CgName)
+		if err != nil {
+			s.Errorf("removeInactiveConsumerGroups: Station " + cg.StationName + ": Consumer group " + cg.CgName + ": " + err.Error())
+			continue
+		}
+
+		message := "Consumer group " + cg.CgName + " of station " + cg.StationName + " has been removed after being inactive for more than " + threshold.String()
+		s.Noticef(message)
+		auditLogs = append(auditLogs, models.AuditLog{
+			ID:            primitive.NewObjectID(),
+			StationName:   cg.StationName,
+			Message:       message,
+			CreatedByUser: "root",
+			CreationDate:  time.Now(),
+			UserType:      "root",
+		})
+	}
+	if len(auditLogs) > 0 {
+		err = CreateAuditLogs(auditLogs)
+		if err != nil {
+			s.Warnf("removeInactiveConsumerGroups: create audit logs error: " + err.Error())
+		}
+	}
+}
+
+// GetInactiveConsumerGroups is a dry run of the inactive consumer groups cleanup, it lists the groups it would remove.
+// threshold_hours overrides the configured threshold so the listing can be checked before the cleanup is enabled
+func (ch ConsumersHandler) GetInactiveConsumerGroups(c *gin.Context) {
+	var body models.GetInactiveConsumerGroupsSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	threshold := consumerGroupsInactiveThreshold()
+	if body.ThresholdHours > 0 {
+		threshold = time.Duration(body.ThresholdHours) * time.Hour
+	}
+	if threshold <= 0 {
+		errMsg := "No inactivity threshold is configured, pass threshold_hours to list the inactive consumer groups"
+		serv.Warnf("GetInactiveConsumerGroups: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg})
+		return
+	}
+
+	inactiveCgs, err := ch.S.findInactiveConsumerGroups(threshold)
+	if err != nil {
+		serv.Errorf("GetInactiveConsumerGroups: " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		return
+	}
+
+	c.IndentedJSON(200, gin.H{
+		"threshold_hours": int(threshold / time.Hour),
+		"cleanup_enabled": consumerGroupsInactiveThreshold() > 0,
+		"consumer_groups": inactiveCgs,
+	})
+}
+
 func (ch ConsumersHandler) KillConsumers(connectionId primitive.ObjectID) error {
 	var consumers []models.Consumer
 	var station models.Station
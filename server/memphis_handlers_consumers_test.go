@@ -0,0 +1,49 @@
+// Credit for The NATS.IO Authors
+// Copyright 2021-2022 The Memphis Authors
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"memphis-broker/models"
+	"testing"
+	"time"
+)
+
+func TestCgLastActivity(t *testing.T) {
+	created := time.Now().Add(-48 * time.Hour)
+	info := &ConsumerInfo{Created: created}
+	if got := cgLastActivity(info); !got.Equal(created) {
+		t.Fatalf("expected the creation time without deliveries, got %v", got)
+	}
+
+	delivered := created.Add(time.Hour)
+	acked := created.Add(2 * time.Hour)
+	info.Delivered.Last = &delivered
+	info.AckFloor.Last = &acked
+	if got := cgLastActivity(info); !got.Equal(acked) {
+		t.Fatalf("expected the latest ack time, got %v", got)
+	}
+}
+
+func TestResolveMaxMsgDeliveries(t *testing.T) {
+	if got, source := resolveMaxMsgDeliveries(25, models.Station{}); got != maxConsumerMsgDeliveries || source != "consumer" {
+		t.Fatalf("expected the consumer value to be clamped to %d, got %d from %s", maxConsumerMsgDeliveries, got, source)
+	}
+	if got, source := resolveMaxMsgDeliveries(3, models.Station{MaxMsgDeliveries: 5}); got != 3 || source != "consumer" {
+		t.Fatalf("expected the consumer value to win, got %d from %s", got, source)
+	}
+	if got, source := resolveMaxMsgDeliveries(0, models.Station{MaxMsgDeliveries: 5}); got != 5 || source != "station" {
+		t.Fatalf("expected the station default, got %d from %s", got, source)
+	}
+}
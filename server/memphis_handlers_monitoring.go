@@ -191,8 +191,11 @@ func (mh MonitoringHandler) GetClusterInfo(c *gin.Context) {
 }
 
 func (mh MonitoringHandler) GetMainOverviewData(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
 	stationsHandler := StationsHandler{S: mh.S}
-	stations, err := stationsHandler.GetAllStationsDetails()
+	stations, err := stationsHandler.GetAllStationsDetails(ctx)
 	if err != nil {
 		serv.Errorf("GetMainOverviewData: GetAllStationsDetails: " + err.Error())
 		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
@@ -222,6 +225,9 @@ func (mh MonitoringHandler) GetMainOverviewData(c *gin.Context) {
 }
 
 func (mh MonitoringHandler) GetStationOverviewData(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
 	stationsHandler := StationsHandler{S: mh.S}
 	producersHandler := ProducersHandler{S: mh.S}
 	consumersHandler := ConsumersHandler{S: mh.S}
@@ -234,6 +240,13 @@ func (mh MonitoringHandler) GetStationOverviewData(c *gin.Context) {
 	if !ok {
 		return
 	}
+	body.MessagesOrder = strings.ToLower(body.MessagesOrder)
+	err := validateMessagesOrder(body.MessagesOrder)
+	if err != nil {
+		serv.Warnf("GetStationOverviewData: At station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error()})
+		return
+	}
 
 	stationName, err := StationNameFromStr(body.StationName)
 	if err != nil {
@@ -241,7 +254,7 @@ func (mh MonitoringHandler) GetStationOverviewData(c *gin.Context) {
 		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
 		return
 	}
-	exist, station, err := IsStationExist(stationName)
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
 	if err != nil {
 		serv.Errorf("GetStationOverviewData: At station " + body.StationName + ": " + err.Error())
 		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
@@ -254,14 +267,14 @@ func (mh MonitoringHandler) GetStationOverviewData(c *gin.Context) {
 		return
 	}
 
-	connectedProducers, disconnectedProducers, deletedProducers, err := producersHandler.GetProducersByStation(station)
+	connectedProducers, disconnectedProducers, deletedProducers, err := producersHandler.GetProducersByStation(ctx, station)
 	if err != nil {
 		serv.Errorf("GetStationOverviewData: At station " + body.StationName + ": " + err.Error())
 		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
 		return
 	}
 
-	connectedCgs, disconnectedCgs, deletedCgs, err := consumersHandler.GetCgsByStation(stationName, station)
+	connectedCgs, disconnectedCgs, deletedCgs, err := consumersHandler.GetCgsByStation(ctx, stationName, station)
 	if err != nil {
 		serv.Errorf("GetStationOverviewData: At station " + body.StationName + ": " + err.Error())
 		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
@@ -274,12 +287,13 @@ func (mh MonitoringHandler) GetStationOverviewData(c *gin.Context) {
 		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
 		return
 	}
-	totalMessages, err := stationsHandler.GetTotalMessages(station.Name)
+	streamInfo, err := mh.S.memphisStreamInfo(stationName.Intern())
 	if err != nil {
 		serv.Errorf("GetStationOverviewData: At station " + body.StationName + ": " + err.Error())
 		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
 		return
 	}
+	totalMessages := int(streamInfo.State.Msgs)
 	avgMsgSize, err := stationsHandler.GetAvgMsgSize(station)
 	if err != nil {
 		serv.Errorf("GetStationOverviewData: At station " + body.StationName + ": " + err.Error())
@@ -288,7 +302,7 @@ func (mh MonitoringHandler) GetStationOverviewData(c *gin.Context) {
 	}
 
 	messagesToFetch := 1000
-	messages, err := stationsHandler.GetMessages(station, messagesToFetch)
+	messages, err := stationsHandler.GetMessages(station, messagesToFetch, body.MessagesOrder)
 	if err != nil {
 		serv.Errorf("GetStationOverviewData: At station " + body.StationName + ": " + err.Error())
 		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
@@ -302,12 +316,31 @@ func (mh MonitoringHandler) GetStationOverviewData(c *gin.Context) {
 		return
 	}
 
+	schemaFailureRate, err := stationsHandler.GetSchemaFailureRate(station, schemaFailureRateWindow)
+	if err != nil {
+		serv.Errorf("GetStationOverviewData: At station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		return
+	}
+	lastProducedAt, lastConsumedAt, err := stationsHandler.GetStationActivity(station.Name)
+	if err != nil {
+		serv.Errorf("GetStationOverviewData: At station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		return
+	}
+
 	tags, err := tagsHandler.GetTagsByStation(station.ID)
 	if err != nil {
 		serv.Errorf("GetStationOverviewData: At station " + body.StationName + ": " + err.Error())
 		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
 		return
 	}
+	idempotencyWindow, err := stationsHandler.GetIdempotencyWindowState(station, streamInfo.Config)
+	if err != nil {
+		serv.Errorf("GetStationOverviewData: At station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		return
+	}
 	leader, followers, err := stationsHandler.GetLeaderAndFollowers(station)
 	if err != nil {
 		serv.Errorf("GetStationOverviewData: At station " + body.StationName + ": " + err.Error())
@@ -321,7 +354,7 @@ func (mh MonitoringHandler) GetStationOverviewData(c *gin.Context) {
 	//Check when the schema object in station is not empty
 	if station.Schema != emptySchemaDetailsObj {
 		var schema models.Schema
-		err = schemasCollection.FindOne(context.TODO(), bson.M{"name": station.Schema.SchemaName}).Decode(&schema)
+		err = schemasCollection.FindOne(ctx, bson.M{"name": station.Schema.SchemaName}).Decode(&schema)
 		if err != nil {
 			serv.Errorf("GetStationOverviewData: At station " + body.StationName + ": " + err.Error())
 			c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
@@ -350,12 +383,25 @@ func (mh MonitoringHandler) GetStationOverviewData(c *gin.Context) {
 			"messages":                 messages,
 			"poison_messages":          poisonMessages,
 			"schema_failed_messages":   schemaFailedMessages,
+			"poison_consumer_count":    len(poisonMessages),
+			"schema_failed_count":      len(schemaFailedMessages),
+			"schema_failure_rate":      schemaFailureRate,
+			"last_produced_at":         lastProducedAt,
+			"last_consumed_at":         lastConsumedAt,
 			"tags":                     tags,
 			"leader":                   leader,
 			"followers":                followers,
+			"replica_health":           getReplicaHealth(station, leader, streamInfo.Cluster),
 			"schema":                   schemaDetails,
+			"retention_type":           station.RetentionType,
+			"retention_descriptor":     getRetentionDescriptor(station.RetentionType, station.RetentionValue),
+			"unlimited_retention":      isUnlimitedRetention(station.RetentionType),
 			"idempotency_window_in_ms": station.IdempotencyWindow,
-			"dls_configuration":        station.DlsConfiguration,
+			"idempotency_window":       idempotencyWindow,
+			"dls_configuration":        redactDlsConfiguration(station.DlsConfiguration),
+			"max_msg_size_bytes":       getStationMaxMsgSize(station),
+			"description":              station.Description,
+			"metadata":                 station.Metadata,
 		}
 
 	} else {
@@ -373,16 +419,29 @@ func (mh MonitoringHandler) GetStationOverviewData(c *gin.Context) {
 			"messages":                 messages,
 			"poison_messages":          poisonMessages,
 			"schema_failed_messages":   schemaFailedMessages,
+			"poison_consumer_count":    len(poisonMessages),
+			"schema_failed_count":      len(schemaFailedMessages),
+			"schema_failure_rate":      schemaFailureRate,
+			"last_produced_at":         lastProducedAt,
+			"last_consumed_at":         lastConsumedAt,
 			"tags":                     tags,
 			"leader":                   leader,
 			"followers":                followers,
+			"replica_health":           getReplicaHealth(station, leader, streamInfo.Cluster),
 			"schema":                   emptyResponse,
+			"retention_type":           station.RetentionType,
+			"retention_descriptor":     getRetentionDescriptor(station.RetentionType, station.RetentionValue),
+			"unlimited_retention":      isUnlimitedRetention(station.RetentionType),
 			"idempotency_window_in_ms": station.IdempotencyWindow,
-			"dls_configuration":        station.DlsConfiguration,
+			"idempotency_window":       idempotencyWindow,
+			"dls_configuration":        redactDlsConfiguration(station.DlsConfiguration),
+			"max_msg_size_bytes":       getStationMaxMsgSize(station),
+			"description":              station.Description,
+			"metadata":                 station.Metadata,
 		}
 	}
 
-	shouldSendAnalytics, _ := shouldSendAnalytics()
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategoryBrowse)
 	if shouldSendAnalytics {
 		user, _ := getUserDetailsFromMiddleware(c)
 		analytics.SendEvent(user.Username, "user-enter-station-overview")
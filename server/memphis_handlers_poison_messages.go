@@ -14,16 +14,22 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"memphis-broker/models"
 	"memphis-broker/notifications"
+	"net/http"
+	"net/url"
 	"sort"
 	"strconv"
 	"strings"
-
+	"sync"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -31,10 +37,41 @@ import (
 )
 
 const (
-	PoisonMessageTitle = "Poison message"
-	dlsMsgSep          = "~"
+	PoisonMessageTitle     = "Poison message"
+	dlsMsgSep              = "~"
+	dlsFetchConsumerPrefix = "$memphis_fetch_dls_consumer_"
+	pcgFetchConsumerPrefix = "$memphis_fetch_pcg_consumer_"
+	dlsWebhookTimeout      = 10 * time.Second
+	// DLS messages pulled per batch when acking or resending poison messages
+	defaultDlsFetchBatchSize = 1000
+	maxDlsFetchBatchSize     = 10000
+	// reason categories of messages landing in the DLS, SDKs may set the reason of
+	// the schema-failed messages they store through the dlsReasonHeader header
+	DlsReasonMaxDeliveries       = "max-deliveries"
+	DlsReasonAckTimeout          = "ack-timeout"
+	DlsReasonSchemaTypeMismatch  = "schema-type-mismatch"
+	DlsReasonSchemaValidationErr = "schema-validation-error"
+	dlsReasonHeader              = "$memphis_dls_reason"
+	// shown instead of the webhook header values and CA cert, which may hold credentials
+	dlsWebhookRedactedValue = "******"
+	// how long the central DLS routing of a station is cached, the routing is set on creation only
+	centralDlsRoutesTTL = time.Minute
+)
+
+var (
+	// one client per TLS setup, so repeated webhook calls reuse their connections
+	dlsWebhookClients   = map[string]*http.Client{}
+	dlsWebhookClientsMu sync.Mutex
+	// the central DLS station of each station seen sending schema-failed messages, empty when it keeps its own DLS
+	centralDlsRoutes   = map[string]centralDlsRoute{}
+	centralDlsRoutesMu sync.Mutex
 )
 
+type centralDlsRoute struct {
+	station   models.Station
+	fetchedAt time.Time
+}
+
 type PoisonMessagesHandler struct{ S *Server }
 
 func (s *Server) ListenForPoisonMessages() {
@@ -49,6 +86,81 @@ func createPoisonMessageHandler(s *Server) simplifiedMsgHandler {
 	}
 }
 
+// ListenForSchemaFailedMessages listens to the schema-failed messages the SDKs publish to the station's own DLS,
+// forwarded messages carry an extra origin token so they never match this subscription again
+func (s *Server) ListenForSchemaFailedMessages() {
+	s.queueSubscribe("*.schema.*",
+		"$memphis_schema_failed_messages_listeners_group",
+		createSchemaFailedMessageHandler(s))
+}
+
+// most stations keep their own DLS, their routing is served from the cache so their messages cost no db lookup
+func createSchemaFailedMessageHandler(s *Server) simplifiedMsgHandler {
+	return func(_ *client, subject, _ string, msg []byte) {
+		streamName := tokenAt(subject, 1)
+		if !strings.HasPrefix(streamName, "$memphis-") || !strings.HasSuffix(streamName, "-dls") {
+			return
+		}
+		stationName := StationNameFromStreamName(strings.TrimSuffix(strings.TrimPrefix(streamName, "$memphis-"), "-dls"))
+		station, ok := getCachedCentralDlsRoute(stationName)
+		if !ok {
+			go s.lookupAndForwardSchemaFailedMessage(stationName, subject, copyBytes(msg))
+			return
+		}
+		if station.CentralDlsStation != "" {
+			s.forwardSchemaFailedMessage(station, subject, copyBytes(msg))
+		}
+	}
+}
+
+func getCachedCentralDlsRoute(sn StationName) (models.Station, bool) {
+	centralDlsRoutesMu.Lock()
+	defer centralDlsRoutesMu.Unlock()
+	route, ok := centralDlsRoutes[sn.Intern()]
+	if !ok || time.Since(route.fetchedAt) > centralDlsRoutesTTL {
+		return models.Station{}, false
+	}
+	return route.station, true
+}
+
+// lookupAndForwardSchemaFailedMessage fetches and caches the central DLS routing of a station before forwarding its message,
+// a station which does not exist yet is not cached since its document is stored only after its DLS stream is created
+func (s *Server) lookupAndForwardSchemaFailedMessage(sn StationName, subject string, msg []byte) {
+	exist, station, err := IsStationExist(sn)
+	if err != nil {
+		serv.Errorf("forwardSchemaFailedMessage: Station " + sn.Ext() + ": " + err.Error())
+		return
+	}
+	if !exist {
+		return
+	}
+	centralDlsRoutesMu.Lock()
+	for name, route := range centralDlsRoutes {
+		if time.Since(route.fetchedAt) > centralDlsRoutesTTL {
+			delete(centralDlsRoutes, name)
+		}
+	}
+	centralDlsRoutes[sn.Intern()] = centralDlsRoute{
+		station:   models.Station{Name: station.Name, CentralDlsStation: station.CentralDlsStation},
+		fetchedAt: time.Now(),
+	}
+	centralDlsRoutesMu.Unlock()
+	if station.CentralDlsStation != "" {
+		s.forwardSchemaFailedMessage(station, subject, msg)
+	}
+}
+
+// forwardSchemaFailedMessage copies a schema-failed message to the central DLS station of its station,
+// the copy kept in the station's own DLS ages out with the DLS retention
+func (s *Server) forwardSchemaFailedMessage(station models.Station, subject string, msg []byte) {
+	centralSubject, err := getStationDlsSubject(station, "schema", tokenAt(subject, 3))
+	if err != nil {
+		serv.Errorf("forwardSchemaFailedMessage: Station " + station.Name + ": " + err.Error())
+		return
+	}
+	s.sendInternalAccountMsg(s.GlobalAccount(), centralSubject, msg)
+}
+
 func (s *Server) handleNewPoisonMessage(msg []byte) {
 	var message map[string]interface{}
 	err := json.Unmarshal(msg, &message)
@@ -63,6 +175,10 @@ func (s *Server) handleNewPoisonMessage(msg []byte) {
 	cgName = revertDelimiters(cgName)
 	messageSeq := message["stream_seq"].(float64)
 	deliveriesCount := message["deliveries"].(float64)
+	reason, _ := message["memphis_reason"].(string)
+	if reason == "" {
+		reason = DlsReasonMaxDeliveries
+	}
 
 	poisonMessageContent, err := s.memphisGetMessage(stationName.Intern(), uint64(messageSeq))
 	if err != nil {
@@ -70,7 +186,7 @@ func (s *Server) handleNewPoisonMessage(msg []byte) {
 		return
 	}
 
-	_, station, err := IsStationExist(stationName)
+	station, err := getDlsStation(stationName)
 	if err != nil {
 		serv.Errorf("handleNewPoisonMessage: Error while getting notified about a poison message: " + err.Error())
 		return
@@ -141,6 +257,10 @@ func (s *Server) handleNewPoisonMessage(msg []byte) {
 
 		messagePayload.Headers = headersJson
 	}
+	if messagePayload.Headers == nil {
+		messagePayload.Headers = map[string]string{}
+	}
+	messagePayload.Headers[dlsReasonHeader] = reason
 
 	id := GetDlsMsgId(stationName.Intern(), int(messageSeq), producedByHeader, poisonMessageContent.Time)
 	pmMessage := models.DlsMessage{
@@ -151,8 +271,13 @@ func (s *Server) handleNewPoisonMessage(msg []byte) {
 		PoisonedCg:   poisonedCg,
 		Message:      messagePayload,
 		CreationDate: time.Now(),
+		Reason:       reason,
+	}
+	poisonSubjectName, err := getStationDlsSubject(station, "poison", id)
+	if err != nil {
+		serv.Errorf("handleNewPoisonMessage: Error while getting notified about a poison message: " + err.Error())
+		return
 	}
-	poisonSubjectName := GetDlsSubject("poison", stationName.Intern(), id)
 	msgToSend, err := json.Marshal(pmMessage)
 	if err != nil {
 		serv.Errorf("handleNewPoisonMessage: Error while getting notified about a poison message: " + err.Error())
@@ -160,6 +285,15 @@ func (s *Server) handleNewPoisonMessage(msg []byte) {
 	}
 	s.sendInternalAccountMsg(s.GlobalAccount(), poisonSubjectName, msgToSend)
 
+	if station.DlsConfiguration.Webhook != nil && station.DlsConfiguration.Webhook.Url != "" {
+		go func(webhook models.DlsWebhook, payload []byte) {
+			err := sendDlsWebhook(webhook, payload)
+			if err != nil {
+				serv.Warnf("handleNewPoisonMessage: At station " + stationName.Ext() + ": Error while calling the DLS webhook: " + err.Error())
+			}
+		}(*station.DlsConfiguration.Webhook, msgToSend)
+	}
+
 	idForUrl := pmMessage.ID
 	var msgUrl = idForUrl + "/stations/" + stationName.Ext() + "/" + idForUrl
 	err = notifications.SendNotification(PoisonMessageTitle, "Poison message has been identified, for more details head to: "+msgUrl, notifications.PoisonMAlert)
@@ -175,14 +309,13 @@ func (pmh PoisonMessagesHandler) GetDlsMsgsByStationLight(station models.Station
 
 	timeout := 1 * time.Second
 
-	sn, err := StationNameFromStr(station.Name)
+	streamName, filter, err := getStationDlsLocation(station)
 	if err != nil {
 		return []models.LightDlsMessageResponse{}, []models.LightDlsMessageResponse{}, err
 	}
-	streamName := fmt.Sprintf(dlsStreamName, sn.Intern())
 
 	uid := serv.memphis.nuid.Next()
-	durableName := "$memphis_fetch_dls_consumer_" + uid
+	durableName := dlsFetchConsumerPrefix + uid
 	var msgs []StoredMsg
 
 	streamInfo, err := serv.memphisStreamInfo(streamName)
@@ -201,6 +334,7 @@ func (pmh PoisonMessagesHandler) GetDlsMsgsByStationLight(station models.Station
 		DeliverPolicy: DeliverByStartSequence,
 		AckPolicy:     AckExplicit,
 		Durable:       durableName,
+		FilterSubject: filter,
 	}
 
 	err = serv.memphisAddConsumer(streamName, &cc)
@@ -267,6 +401,10 @@ cleanup:
 		if err != nil {
 			return []models.LightDlsMessageResponse{}, []models.LightDlsMessageResponse{}, err
 		}
+		// a central DLS station holds the dead letters of the stations routed to it as well
+		if dlsMsg.StationName != "" && dlsMsg.StationName != station.Name {
+			continue
+		}
 		msgId := dlsMsg.ID
 		if msgType == "poison" {
 			if _, value := idCheck[msgId]; !value {
@@ -305,10 +443,13 @@ func (pmh PoisonMessagesHandler) GetDlsMsgsByStationFull(station models.Station)
 	if err != nil {
 		return []models.DlsMessageResponse{}, []models.DlsMessageResponse{}, err
 	}
-	streamName := fmt.Sprintf(dlsStreamName, sn.Intern())
+	streamName, filter, err := getStationDlsLocation(station)
+	if err != nil {
+		return []models.DlsMessageResponse{}, []models.DlsMessageResponse{}, err
+	}
 
 	uid := serv.memphis.nuid.Next()
-	durableName := "$memphis_fetch_dls_consumer_" + uid
+	durableName := dlsFetchConsumerPrefix + uid
 	var msgs []StoredMsg
 
 	streamInfo, err := serv.memphisStreamInfo(streamName)
@@ -327,6 +468,7 @@ func (pmh PoisonMessagesHandler) GetDlsMsgsByStationFull(station models.Station)
 		DeliverPolicy: DeliverByStartSequence,
 		AckPolicy:     AckExplicit,
 		Durable:       durableName,
+		FilterSubject: filter,
 	}
 
 	err = serv.memphisAddConsumer(streamName, &cc)
@@ -385,6 +527,7 @@ cleanup:
 
 	cgToMsgListP := make(map[string]bool)
 	cgToMsgListS := make(map[string]bool)
+	schemaFailureReasons := stationSchemaFailureReasons(station)
 	for _, msg := range msgs {
 		splittedSubj := strings.Split(msg.Subject, tsep)
 		msgType := splittedSubj[1]
@@ -394,6 +537,10 @@ cleanup:
 		if err != nil {
 			return []models.DlsMessageResponse{}, []models.DlsMessageResponse{}, err
 		}
+		// a central DLS station holds the dead letters of the stations routed to it as well
+		if dlsMsg.StationName != "" && dlsMsg.StationName != station.Name {
+			continue
+		}
 
 		msgId := dlsMsg.ID
 		if msgType == "poison" {
@@ -418,6 +565,8 @@ cleanup:
 					Message:      dlsMsg.Message,
 					CreationDate: dlsMsg.CreationDate,
 					PoisonedCgs:  []models.PoisonedCg{pCg},
+					AgeInDls:     int64(time.Since(msg.Time).Seconds()),
+					Reason:       getDlsMsgReason(dlsMsg, msgType, schemaFailureReasons),
 				}
 			} else {
 				if _, value := cgToMsgListP[dlsMsg.PoisonedCg.CgName]; !value {
@@ -461,6 +610,8 @@ cleanup:
 					Message:      dlsMsg.Message,
 					CreationDate: dlsMsg.CreationDate,
 					PoisonedCgs:  []models.PoisonedCg{pCg},
+					AgeInDls:     int64(time.Since(msg.Time).Seconds()),
+					Reason:       getDlsMsgReason(dlsMsg, msgType, schemaFailureReasons),
 				}
 			} else {
 				if _, value := cgToMsgListS[dlsMsg.PoisonedCg.CgName]; !value {
@@ -512,10 +663,17 @@ func (pmh PoisonMessagesHandler) GetTotalPoisonMsgsByStation(stationName string)
 	if err != nil {
 		return 0, err
 	}
-	streamName := fmt.Sprintf(dlsStreamName, sn.Intern())
+	station, err := getDlsStation(sn)
+	if err != nil {
+		return 0, err
+	}
+	streamName, filter, err := getStationDlsLocation(station)
+	if err != nil {
+		return 0, err
+	}
 
 	uid := serv.memphis.nuid.Next()
-	durableName := "$memphis_fetch_dls_consumer_" + uid
+	durableName := dlsFetchConsumerPrefix + uid
 	var msgs []StoredMsg
 
 	streamInfo, err := serv.memphisStreamInfo(streamName)
@@ -534,6 +692,7 @@ func (pmh PoisonMessagesHandler) GetTotalPoisonMsgsByStation(stationName string)
 		DeliverPolicy: DeliverByStartSequence,
 		AckPolicy:     AckExplicit,
 		Durable:       durableName,
+		FilterSubject: filter,
 	}
 
 	err = serv.memphisAddConsumer(streamName, &cc)
@@ -598,6 +757,10 @@ cleanup:
 		if err != nil {
 			return 0, err
 		}
+		// a central DLS station holds the dead letters of the stations routed to it as well
+		if dlsMsg.StationName != "" && dlsMsg.StationName != station.Name {
+			continue
+		}
 		msgId := dlsMsg.ID
 		if msgType == "poison" {
 			if _, value := idCheck[msgId]; !value {
@@ -610,13 +773,105 @@ cleanup:
 	return count, nil
 }
 
+// GetPoisonMsgsTrendByStation counts the station's poison messages per interval, starting at from,
+// by the time they were stored in the DLS. A message poisoning several consumer groups is counted once
+func (pmh PoisonMessagesHandler) GetPoisonMsgsTrendByStation(station models.Station, from time.Time, interval time.Duration, bucketsAmount int) ([]models.PoisonMessagesBucket, error) {
+	buckets := make([]models.PoisonMessagesBucket, bucketsAmount)
+	for i := range buckets {
+		buckets[i].Start = from.Add(time.Duration(i) * interval)
+	}
+
+	streamName, filter, err := getStationDlsLocation(station)
+	if err != nil {
+		return buckets, err
+	}
+
+	idCheck := make(map[string]bool)
+	err = pmh.S.fetchDlsMsgs(streamName, filter, defaultDlsFetchBatchSize, 1*time.Second, func(msgs []StoredMsg) error {
+		for _, msg := range msgs {
+			splittedSubj := strings.Split(msg.Subject, tsep)
+			if splittedSubj[1] != "poison" || msg.Time.Before(from) {
+				continue
+			}
+			var dlsMsg models.DlsMessage
+			err := json.Unmarshal(msg.Data, &dlsMsg)
+			if err != nil {
+				return err
+			}
+			// a central DLS station holds the dead letters of the stations routed to it as well
+			if dlsMsg.StationName != "" && dlsMsg.StationName != station.Name {
+				continue
+			}
+			if idCheck[dlsMsg.ID] {
+				continue
+			}
+			idCheck[dlsMsg.ID] = true
+
+			i := int(msg.Time.Sub(from) / interval)
+			if i < bucketsAmount {
+				buckets[i].Count++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return buckets, err
+	}
+
+	return buckets, nil
+}
+
+// GetPoisonMsgsCountByCg counts the station's poison messages per poisoned consumer group in a single pass over the DLS,
+// it also returns the amount of distinct poison messages since a message poisoning several groups is stored once per group
+func (pmh PoisonMessagesHandler) GetPoisonMsgsCountByCg(station models.Station) (map[string]int, int, error) {
+	counts := make(map[string]int)
+	streamName, filter, err := getStationDlsLocation(station)
+	if err != nil {
+		return counts, 0, err
+	}
+
+	idCheck := make(map[string]bool)
+	err = pmh.S.fetchDlsMsgs(streamName, filter, defaultDlsFetchBatchSize, 1*time.Second, func(msgs []StoredMsg) error {
+		for _, msg := range msgs {
+			splittedSubj := strings.Split(msg.Subject, tsep)
+			if splittedSubj[1] != "poison" {
+				continue
+			}
+			var dlsMsg models.DlsMessage
+			err := json.Unmarshal(msg.Data, &dlsMsg)
+			if err != nil {
+				return err
+			}
+			// a central DLS station holds the dead letters of the stations routed to it as well
+			if dlsMsg.StationName != "" && dlsMsg.StationName != station.Name {
+				continue
+			}
+			counts[dlsMsg.PoisonedCg.CgName]++
+			idCheck[dlsMsg.ID] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return counts, 0, err
+	}
+
+	return counts, len(idCheck), nil
+}
+
 func RemovePoisonedCg(stationName StationName, cgName string) error {
 	timeout := 1 * time.Second
 
-	streamName := fmt.Sprintf(dlsStreamName, stationName.Intern())
+	station, err := getDlsStation(stationName)
+	if err != nil {
+		return err
+	}
+	streamName, filter, err := getStationDlsLocation(station)
+	if err != nil {
+		return err
+	}
 
 	uid := serv.memphis.nuid.Next()
-	durableName := "$memphis_fetch_dls_consumer_" + uid
+	durableName := dlsFetchConsumerPrefix + uid
 	var msgs []StoredMsg
 
 	streamInfo, err := serv.memphisStreamInfo(streamName)
@@ -635,6 +890,7 @@ func RemovePoisonedCg(stationName StationName, cgName string) error {
 		DeliverPolicy: DeliverByStartSequence,
 		AckPolicy:     AckExplicit,
 		Durable:       durableName,
+		FilterSubject: filter,
 	}
 
 	err = serv.memphisAddConsumer(streamName, &cc)
@@ -699,6 +955,10 @@ cleanup:
 		if err != nil {
 			return err
 		}
+		// a central DLS station holds the dead letters of the stations routed to it as well
+		if dlsMsg.StationName != "" && dlsMsg.StationName != station.Name {
+			continue
+		}
 		if msgType == "poison" {
 			if dlsMsg.PoisonedCg.CgName == cgName {
 				_, err = serv.memphisDeleteMsgFromStream(streamName, msg.Sequence)
@@ -720,10 +980,17 @@ func GetTotalPoisonMsgsByCg(stationName, cgName string) (int, error) {
 	if err != nil {
 		return 0, err
 	}
-	streamName := fmt.Sprintf(dlsStreamName, sn.Intern())
+	station, err := getDlsStation(sn)
+	if err != nil {
+		return 0, err
+	}
+	streamName, filter, err := getStationDlsLocation(station)
+	if err != nil {
+		return 0, err
+	}
 
 	uid := serv.memphis.nuid.Next()
-	durableName := "$memphis_fetch_dls_consumer_" + uid
+	durableName := dlsFetchConsumerPrefix + uid
 	var msgs []StoredMsg
 
 	streamInfo, err := serv.memphisStreamInfo(streamName)
@@ -742,6 +1009,7 @@ func GetTotalPoisonMsgsByCg(stationName, cgName string) (int, error) {
 		DeliverPolicy: DeliverByStartSequence,
 		AckPolicy:     AckExplicit,
 		Durable:       durableName,
+		FilterSubject: filter,
 	}
 
 	err = serv.memphisAddConsumer(streamName, &cc)
@@ -806,6 +1074,10 @@ cleanup:
 		if err != nil {
 			return 0, err
 		}
+		// a central DLS station holds the dead letters of the stations routed to it as well
+		if dlsMsg.StationName != "" && dlsMsg.StationName != station.Name {
+			continue
+		}
 		if msgType == "poison" {
 			if dlsMsg.PoisonedCg.CgName == cgName {
 				count++
@@ -825,10 +1097,17 @@ func GetTotalSchemaFailMsgsByCg(stationName, cgName string) (int, error) {
 	if err != nil {
 		return 0, err
 	}
-	streamName := fmt.Sprintf(dlsStreamName, sn.Intern())
+	station, err := getDlsStation(sn)
+	if err != nil {
+		return 0, err
+	}
+	streamName, filter, err := getStationDlsLocation(station)
+	if err != nil {
+		return 0, err
+	}
 
 	uid := serv.memphis.nuid.Next()
-	durableName := "$memphis_fetch_dls_consumer_" + uid
+	durableName := dlsFetchConsumerPrefix + uid
 	var msgs []StoredMsg
 
 	streamInfo, err := serv.memphisStreamInfo(streamName)
@@ -847,6 +1126,7 @@ func GetTotalSchemaFailMsgsByCg(stationName, cgName string) (int, error) {
 		DeliverPolicy: DeliverByStartSequence,
 		AckPolicy:     AckExplicit,
 		Durable:       durableName,
+		FilterSubject: filter,
 	}
 
 	err = serv.memphisAddConsumer(streamName, &cc)
@@ -911,6 +1191,10 @@ cleanup:
 		if err != nil {
 			return 0, err
 		}
+		// a central DLS station holds the dead letters of the stations routed to it as well
+		if dlsMsg.StationName != "" && dlsMsg.StationName != station.Name {
+			continue
+		}
 		msgId := dlsMsg.ID
 		if msgType == "schema" {
 			if _, value := idCheck[msgId]; !value {
@@ -926,10 +1210,17 @@ cleanup:
 func GetPoisonedCgsByMessage(stationNameInter string, message models.MessageDetails) ([]models.PoisonedCg, error) {
 	timeout := 1 * time.Second
 	poisonedCgs := []models.PoisonedCg{}
-	streamName := fmt.Sprintf(dlsStreamName, stationNameInter)
+	station, err := getDlsStation(StationNameFromStreamName(stationNameInter))
+	if err != nil {
+		return []models.PoisonedCg{}, err
+	}
+	streamName, _, err := getStationDlsLocation(station)
+	if err != nil {
+		return []models.PoisonedCg{}, err
+	}
 	cgCheck := make(map[string]bool)
 	uid := serv.memphis.nuid.Next()
-	durableName := "$memphis_fetch_pcg_consumer_" + uid
+	durableName := pcgFetchConsumerPrefix + uid
 	var msgs []StoredMsg
 
 	streamInfo, err := serv.memphisStreamInfo(streamName)
@@ -943,7 +1234,10 @@ func GetPoisonedCgsByMessage(stationNameInter string, message models.MessageDeta
 		startSeq = streamInfo.State.FirstSeq
 	}
 	msgId := GetDlsMsgId(stationNameInter, message.MessageSeq, message.ProducedBy, message.TimeSent)
-	filter := GetDlsSubject("poison", stationNameInter, msgId)
+	filter, err := getStationDlsSubject(station, "poison", msgId)
+	if err != nil {
+		return []models.PoisonedCg{}, err
+	}
 	cc := ConsumerConfig{
 		OptStartSeq:   startSeq,
 		DeliverPolicy: DeliverByStartSequence,
@@ -1033,12 +1327,324 @@ cleanup:
 	return poisonedCgs, nil
 }
 
+// resolveDlsFetchBatchSize picks the requested batch size, falling back to the server default
+func resolveDlsFetchBatchSize(requested int) (uint64, error) {
+	if requested < 0 || requested > maxDlsFetchBatchSize {
+		return 0, fmt.Errorf("fetch batch size has to be between 1 and %v", maxDlsFetchBatchSize)
+	}
+	if requested > 0 {
+		return uint64(requested), nil
+	}
+	if configuration.DLS_FETCH_BATCH_SIZE > 0 && configuration.DLS_FETCH_BATCH_SIZE <= maxDlsFetchBatchSize {
+		return uint64(configuration.DLS_FETCH_BATCH_SIZE), nil
+	}
+	return defaultDlsFetchBatchSize, nil
+}
+
+// fetchDlsMsgs reads the DLS messages matching the filter through an ephemeral consumer, batchSize messages per pull,
+// and hands every batch to handle. It stops once a pull returns less than a full batch within the timeout.
+// The consumer is removed on every return path so a failed read does not leave it behind
+func (s *Server) fetchDlsMsgs(streamName, filter string, batchSize uint64, timeout time.Duration, handle func(msgs []StoredMsg) error) (err error) {
+	durableName := dlsFetchConsumerPrefix + s.memphis.nuid.Next()
+	cc := ConsumerConfig{
+		DeliverPolicy: DeliverAll,
+		AckPolicy:     AckExplicit,
+		Durable:       durableName,
+		FilterSubject: filter,
+	}
+	err = s.memphisAddConsumer(streamName, &cc)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		removeErr := s.memphisRemoveConsumer(streamName, durableName)
+		if removeErr != nil && err == nil {
+			err = removeErr
+		}
+	}()
+
+	responseChan := make(chan StoredMsg)
+	subject := fmt.Sprintf(JSApiRequestNextT, streamName, durableName)
+	reply := durableName + "_reply"
+	req := []byte(strconv.FormatUint(batchSize, 10))
+
+	sub, err := s.subscribeOnGlobalAcc(reply, reply+"_sid", func(_ *client, subject, reply string, msg []byte) {
+		go func(respCh chan StoredMsg, subject, reply string, msg []byte) {
+			// ack
+			s.sendInternalAccountMsg(s.GlobalAccount(), reply, []byte(_EMPTY_))
+			rawTs := tokenAt(reply, 8)
+			seq, _, _ := ackReplyInfo(reply)
+
+			intTs, err := strconv.Atoi(rawTs)
+			if err != nil {
+				s.Errorf("fetchDlsMsgs: " + err.Error())
+			}
+
+			respCh <- StoredMsg{
+				Subject:  subject,
+				Sequence: uint64(seq),
+				Data:     msg,
+				Time:     time.Unix(0, int64(intTs)),
+			}
+		}(responseChan, subject, reply, copyBytes(msg))
+	})
+	if err != nil {
+		return err
+	}
+	defer s.unsubscribeOnGlobalAcc(sub)
+
+	for {
+		s.sendInternalAccountMsgWithReply(s.GlobalAccount(), subject, reply, nil, req, true)
+
+		msgs := make([]StoredMsg, 0, batchSize)
+		timer := time.NewTimer(timeout)
+	batch:
+		for uint64(len(msgs)) < batchSize {
+			select {
+			case <-timer.C:
+				break batch
+			case msg := <-responseChan:
+				msgs = append(msgs, msg)
+			}
+		}
+		timer.Stop()
+
+		if len(msgs) > 0 {
+			err = handle(msgs)
+			if err != nil {
+				return err
+			}
+		}
+		if uint64(len(msgs)) < batchSize {
+			return nil
+		}
+	}
+}
+
+// getDlsMsgReason returns the stamped reason category of a DLS message, schema-failed messages the SDK did not stamp
+// are classified with schemaFailureReason and poison messages stored before reasons were stamped got their max deliveries
+func getDlsMsgReason(dlsMsg models.DlsMessage, msgType string, schemaFailureReason func(hexData string) string) string {
+	if dlsMsg.Reason != "" {
+		return dlsMsg.Reason
+	}
+	if reason := dlsMsg.Message.Headers[dlsReasonHeader]; reason != "" {
+		return reason
+	}
+	if msgType == "schema" {
+		return schemaFailureReason(dlsMsg.Message.Data)
+	}
+	return DlsReasonMaxDeliveries
+}
+
+// stationSchemaFailureReasons classifies the schema-failed messages of a station by the schema version attached to it,
+// the schema is loaded on the first message only. a message is a validation error when the schema can not be loaded
+func stationSchemaFailureReasons(station models.Station) func(hexData string) string {
+	loaded := false
+	var schema models.Schema
+	var schemaVersion models.SchemaVersion
+	return func(hexData string) string {
+		if !loaded {
+			loaded = true
+			if station.Schema.SchemaName == "" {
+				return DlsReasonSchemaValidationErr
+			}
+			exist, s, err := IsSchemaExist(station.Schema.SchemaName)
+			if err != nil || !exist {
+				return DlsReasonSchemaValidationErr
+			}
+			version, err := getStationSchemaVersion(s, station.Schema.VersionNumber)
+			if err != nil {
+				return DlsReasonSchemaValidationErr
+			}
+			schema, schemaVersion = s, version
+		}
+		if schema.Name == "" {
+			return DlsReasonSchemaValidationErr
+		}
+		payload, err := hex.DecodeString(hexData)
+		if err != nil {
+			return DlsReasonSchemaValidationErr
+		}
+		return schemaFailureReason(schema.Type, schemaVersion, payload)
+	}
+}
+
 func GetDlsSubject(subjType string, stationName string, id string) string {
 	return fmt.Sprintf(dlsStreamName, stationName) + "." + subjType + "." + id
 }
 
+// getStationDlsLocation returns the stream holding the DLS messages of a station and the filter subject selecting them,
+// stations routed to a central DLS station share its stream and are told apart by their origin station token
+func getStationDlsLocation(station models.Station) (string, string, error) {
+	sn, err := StationNameFromStr(station.Name)
+	if err != nil {
+		return "", "", err
+	}
+	if station.CentralDlsStation == "" {
+		return fmt.Sprintf(dlsStreamName, sn.Intern()), "", nil
+	}
+	centralSn, err := StationNameFromStr(station.CentralDlsStation)
+	if err != nil {
+		return "", "", err
+	}
+	streamName := fmt.Sprintf(dlsStreamName, centralSn.Intern())
+	return streamName, streamName + ".*." + sn.Intern() + ".>", nil
+}
+
+// getStationDlsSubject is the station aware version of GetDlsSubject
+func getStationDlsSubject(station models.Station, subjType string, id string) (string, error) {
+	sn, err := StationNameFromStr(station.Name)
+	if err != nil {
+		return "", err
+	}
+	if station.CentralDlsStation == "" {
+		return GetDlsSubject(subjType, sn.Intern(), id), nil
+	}
+	centralSn, err := StationNameFromStr(station.CentralDlsStation)
+	if err != nil {
+		return "", err
+	}
+	return GetDlsSubject(subjType, centralSn.Intern(), sn.Intern()+tsep+id), nil
+}
+
+// getDlsStation returns the station document used to locate the DLS messages of a station,
+// stations which no longer exist are looked up in their own DLS stream
+func getDlsStation(sn StationName) (models.Station, error) {
+	exist, station, err := IsStationExist(sn)
+	if err != nil {
+		return models.Station{}, err
+	}
+	if !exist {
+		return models.Station{Name: sn.Ext()}, nil
+	}
+	return station, nil
+}
+
 func GetDlsMsgId(stationName string, messageSeq int, producerName string, timeSent time.Time) string {
 	msgId := strings.ReplaceAll(stationName+dlsMsgSep+producerName+dlsMsgSep+strconv.Itoa(messageSeq)+dlsMsgSep+timeSent.String(), " ", "")
 	msgId = strings.ReplaceAll(msgId, ".", "-")
 	return msgId
 }
+
+// validateDlsWebhook makes sure a configured webhook can be called, nil or an empty url means no webhook
+func validateDlsWebhook(webhook *models.DlsWebhook) error {
+	if webhook == nil || webhook.Url == "" {
+		return nil
+	}
+	u, err := url.Parse(webhook.Url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("webhook url has to be a valid http/https url")
+	}
+	for name, value := range webhook.Headers {
+		if strings.TrimSpace(name) == "" {
+			return errors.New("webhook header names can not be empty")
+		}
+		if value == dlsWebhookRedactedValue {
+			return fmt.Errorf("webhook header %v has a redacted value, it has to be set again", name)
+		}
+	}
+	if webhook.TlsCaCert == dlsWebhookRedactedValue {
+		return errors.New("webhook tls_ca_cert is redacted, it has to be set again")
+	}
+	if webhook.TlsCaCert != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(webhook.TlsCaCert)) {
+		return errors.New("webhook tls_ca_cert has to be a PEM encoded certificate")
+	}
+	return nil
+}
+
+// redactDlsConfiguration returns the DLS configuration as shown to users, with the webhook header values and CA cert replaced
+func redactDlsConfiguration(dlsConfiguration models.DlsConfiguration) models.DlsConfiguration {
+	if dlsConfiguration.Webhook == nil {
+		return dlsConfiguration
+	}
+	webhook := *dlsConfiguration.Webhook
+	if len(webhook.Headers) > 0 {
+		webhook.Headers = make(map[string]string, len(dlsConfiguration.Webhook.Headers))
+		for name := range dlsConfiguration.Webhook.Headers {
+			webhook.Headers[name] = dlsWebhookRedactedValue
+		}
+	}
+	if webhook.TlsCaCert != "" {
+		webhook.TlsCaCert = dlsWebhookRedactedValue
+	}
+	dlsConfiguration.Webhook = &webhook
+	return dlsConfiguration
+}
+
+// exportedDlsConfiguration returns the DLS configuration as written to a station definition, the webhook header
+// values and CA cert are secrets which can not be restored on import so they are left out and have to be set again
+func exportedDlsConfiguration(dlsConfiguration models.DlsConfiguration) models.DlsConfiguration {
+	if dlsConfiguration.Webhook == nil {
+		return dlsConfiguration
+	}
+	webhook := *dlsConfiguration.Webhook
+	webhook.Headers = nil
+	webhook.TlsCaCert = ""
+	dlsConfiguration.Webhook = &webhook
+	return dlsConfiguration
+}
+
+// restoreRedactedDlsWebhook puts back the stored values of the webhook fields a client sent back redacted
+func restoreRedactedDlsWebhook(webhook *models.DlsWebhook, current *models.DlsWebhook) {
+	if webhook == nil || current == nil {
+		return
+	}
+	for name, value := range webhook.Headers {
+		if currentValue, ok := current.Headers[name]; ok && value == dlsWebhookRedactedValue {
+			webhook.Headers[name] = currentValue
+		}
+	}
+	if webhook.TlsCaCert == dlsWebhookRedactedValue {
+		webhook.TlsCaCert = current.TlsCaCert
+	}
+}
+
+func dlsWebhookClient(webhook models.DlsWebhook) (*http.Client, error) {
+	key := strconv.FormatBool(webhook.TlsSkipVerify) + "|" + webhook.TlsCaCert
+	dlsWebhookClientsMu.Lock()
+	defer dlsWebhookClientsMu.Unlock()
+	if client, ok := dlsWebhookClients[key]; ok {
+		return client, nil
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: webhook.TlsSkipVerify}
+	if webhook.TlsCaCert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(webhook.TlsCaCert)) {
+			return nil, errors.New("invalid tls_ca_cert")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	client := &http.Client{
+		Timeout:   dlsWebhookTimeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}
+	dlsWebhookClients[key] = client
+	return client, nil
+}
+
+func sendDlsWebhook(webhook models.DlsWebhook, payload []byte) error {
+	client, err := dlsWebhookClient(webhook)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", webhook.Url, bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range webhook.Headers {
+		req.Header.Set(name, value)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+	return nil
+}
@@ -49,53 +49,53 @@ func validateProducerType(producerType string) error {
 	return nil
 }
 
-func (s *Server) createProducerDirectCommon(c *client, pName, pType, pConnectionId string, pStationName StationName) error {
+func (s *Server) createProducerDirectCommon(c *client, pName, pType, pConnectionId string, pStationName StationName) (models.Station, error) {
 	name := strings.ToLower(pName)
 	err := validateProducerName(name)
 	if err != nil {
 		serv.Warnf("createProducerDirectCommon: Producer " + pName + " at station " + pStationName.external + ": " + err.Error())
-		return err
+		return models.Station{}, err
 	}
 
 	producerType := strings.ToLower(pType)
 	err = validateProducerType(producerType)
 	if err != nil {
 		serv.Warnf("createProducerDirectCommon: Producer " + pName + " at station " + pStationName.external + ": " + err.Error())
-		return err
+		return models.Station{}, err
 	}
 
 	connectionIdObj, err := primitive.ObjectIDFromHex(pConnectionId)
 	if err != nil {
 		serv.Warnf("createProducerDirectCommon: Producer " + pName + " at station " + pStationName.external + ": Connection ID " + pConnectionId + " is not valid")
-		return err
+		return models.Station{}, err
 	}
 	exist, connection, err := IsConnectionExist(connectionIdObj)
 	if err != nil {
 		serv.Errorf("createProducerDirectCommon: Producer " + pName + " at station " + pStationName.external + ": " + err.Error())
-		return err
+		return models.Station{}, err
 	}
 	if !exist {
 		errMsg := "Connection ID " + pConnectionId + " was not found"
 		serv.Warnf("createProducerDirectCommon: Producer " + pName + " at station " + pStationName.external + ": " + errMsg)
-		return errors.New("memphis: " + errMsg)
+		return models.Station{}, errors.New("memphis: " + errMsg)
 	}
 	if !connection.IsActive {
 		errMsg := "Connection with ID " + pConnectionId + " is not active"
 		serv.Warnf("createProducerDirectCommon: Producer " + pName + " at station " + pStationName.external + ": " + errMsg)
-		return errors.New("memphis: " + errMsg)
+		return models.Station{}, errors.New("memphis: " + errMsg)
 	}
 
 	exist, station, err := IsStationExist(pStationName)
 	if err != nil {
 		serv.Errorf("createProducerDirectCommon: Producer " + pName + " at station " + pStationName.external + ": " + err.Error())
-		return err
+		return models.Station{}, err
 	}
 	if !exist {
 		var created bool
 		station, created, err = CreateDefaultStation(s, pStationName, connection.CreatedByUser)
 		if err != nil {
 			serv.Errorf("createProducerDirectCommon: creating default station error - producer " + pName + " at station " + pStationName.external + ": " + err.Error())
-			return err
+			return models.Station{}, err
 		}
 
 		if created {
@@ -116,7 +116,7 @@ func (s *Server) createProducerDirectCommon(c *client, pName, pType, pConnection
 				serv.Errorf("createProducerDirectCommon: Producer " + pName + " at station " + pStationName.external + ": " + err.Error())
 			}
 
-			shouldSendAnalytics, _ := shouldSendAnalytics()
+			shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategoryStation)
 			if shouldSendAnalytics {
 				param := analytics.EventParam{
 					Name:  "station-name",
@@ -128,15 +128,47 @@ func (s *Server) createProducerDirectCommon(c *client, pName, pType, pConnection
 		}
 	}
 
+	if station.ReadOnly {
+		errMsg := "Producer " + pName + " at station " + pStationName.Ext() + ": " + ErrStationReadOnly.Error()
+		serv.Warnf("createProducerDirectCommon: " + errMsg)
+		return models.Station{}, errors.New("memphis: " + errMsg)
+	}
+
+	if station.Mirror != "" {
+		errMsg := "Producer " + pName + " at station " + pStationName.Ext() + ": " + ErrMirrorStationProduce.Error()
+		serv.Warnf("createProducerDirectCommon: " + errMsg)
+		return models.Station{}, errors.New("memphis: " + errMsg)
+	}
+
+	if !isClientAllowed(station.AllowedProducers, name) {
+		errMsg := "Producer " + name + " is not allowed to produce to station " + pStationName.Ext()
+		serv.Warnf("createProducerDirectCommon: " + errMsg)
+		var auditLogs []interface{}
+		newAuditLog := models.AuditLog{
+			ID:            primitive.NewObjectID(),
+			StationName:   pStationName.Ext(),
+			Message:       errMsg + ", connection was rejected",
+			CreatedByUser: c.memphisInfo.username,
+			CreationDate:  time.Now(),
+			UserType:      "application",
+		}
+		auditLogs = append(auditLogs, newAuditLog)
+		err = CreateAuditLogs(auditLogs)
+		if err != nil {
+			serv.Errorf("createProducerDirectCommon: Producer " + pName + " at station " + pStationName.external + ": " + err.Error())
+		}
+		return models.Station{}, errors.New("memphis: " + errMsg)
+	}
+
 	exist, _, err = IsProducerExist(name, station.ID)
 	if err != nil {
 		serv.Errorf("createProducerDirectCommon: Producer " + pName + " at station " + pStationName.external + ": " + err.Error())
-		return err
+		return models.Station{}, err
 	}
 	if exist {
 		errMsg := "Producer name (" + pName + ") has to be unique per station (" + pStationName.external + ")"
 		serv.Warnf("createProducerDirectCommon: " + errMsg)
-		return errors.New("memphis: " + errMsg)
+		return models.Station{}, errors.New("memphis: " + errMsg)
 	}
 
 	newProducer := models.Producer{
@@ -165,7 +197,7 @@ func (s *Server) createProducerDirectCommon(c *client, pName, pType, pConnection
 	updateResults, err := producersCollection.UpdateOne(context.TODO(), filter, update, opts)
 	if err != nil {
 		serv.Errorf("createProducerDirectCommon: Producer " + pName + " at station " + pStationName.external + ": " + err.Error())
-		return err
+		return models.Station{}, err
 	}
 
 	if updateResults.MatchedCount == 0 {
@@ -186,7 +218,7 @@ func (s *Server) createProducerDirectCommon(c *client, pName, pType, pConnection
 			serv.Errorf("createProducerDirectCommon: Producer " + pName + " at station " + pStationName.external + ": " + err.Error())
 		}
 
-		shouldSendAnalytics, _ := shouldSendAnalytics()
+		shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategoryStation)
 		if shouldSendAnalytics {
 			param := analytics.EventParam{
 				Name:  "producer-name",
@@ -197,7 +229,7 @@ func (s *Server) createProducerDirectCommon(c *client, pName, pType, pConnection
 		}
 	}
 
-	return nil
+	return station, nil
 }
 
 func (s *Server) createProducerDirectV0(c *client, reply string, cpr createProducerRequestV0) {
@@ -206,7 +238,7 @@ func (s *Server) createProducerDirectV0(c *client, reply string, cpr createProdu
 		respondWithErr(s, reply, err)
 		return
 	}
-	err = s.createProducerDirectCommon(c, cpr.Name,
+	_, err = s.createProducerDirectCommon(c, cpr.Name,
 		cpr.ProducerType, cpr.ConnectionId, sn)
 	respondWithErr(s, reply, err)
 }
@@ -233,11 +265,15 @@ func (s *Server) createProducerDirect(c *client, reply string, msg []byte) {
 		return
 	}
 
-	err = s.createProducerDirectCommon(c, cpr.Name, cpr.ProducerType, cpr.ConnectionId, sn)
+	station, err := s.createProducerDirectCommon(c, cpr.Name, cpr.ProducerType, cpr.ConnectionId, sn)
 	if err != nil {
 		respondWithRespErr(s, reply, err, &resp)
 		return
 	}
+	resp.PartitionKeyHeader = station.PartitionKeyHeader
+	// producers of keyed stations publish each message on the final subject suffixed with the value of this header
+	resp.CompactionKey = station.CompactionKey
+	resp.SchemaEnforcement = getStationSchemaEnforcement(station)
 
 	schemaUpdate, err := getSchemaUpdateInitFromStation(sn)
 	if err == ErrNoSchema {
@@ -251,13 +287,17 @@ func (s *Server) createProducerDirect(c *client, reply string, msg []byte) {
 	}
 
 	resp.SchemaUpdate = *schemaUpdate
+	resp.SchemaUpdate.Enforcement = resp.SchemaEnforcement
 
 	respondWithResp(s, reply, &resp)
 }
 
 func (ph ProducersHandler) GetAllProducers(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
 	var producers []models.ExtendedProducer
-	cursor, err := producersCollection.Aggregate(context.TODO(), mongo.Pipeline{
+	cursor, err := producersCollection.Aggregate(ctx, mongo.Pipeline{
 		bson.D{{"$match", bson.D{}}},
 		bson.D{{"$lookup", bson.D{{"from", "stations"}, {"localField", "station_id"}, {"foreignField", "_id"}, {"as", "station"}}}},
 		bson.D{{"$unwind", bson.D{{"path", "$station"}, {"preserveNullAndEmptyArrays", true}}}},
@@ -272,7 +312,7 @@ func (ph ProducersHandler) GetAllProducers(c *gin.Context) {
 		return
 	}
 
-	if err = cursor.All(context.TODO(), &producers); err != nil {
+	if err = cursor.All(ctx, &producers); err != nil {
 		serv.Errorf("GetAllProducers: " + err.Error())
 		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
 		return
@@ -285,10 +325,10 @@ func (ph ProducersHandler) GetAllProducers(c *gin.Context) {
 	}
 }
 
-func (ph ProducersHandler) GetProducersByStation(station models.Station) ([]models.ExtendedProducer, []models.ExtendedProducer, []models.ExtendedProducer, error) { // for socket io endpoint
+func (ph ProducersHandler) GetProducersByStation(ctx context.Context, station models.Station) ([]models.ExtendedProducer, []models.ExtendedProducer, []models.ExtendedProducer, error) { // for socket io endpoint
 	var producers []models.ExtendedProducer
 
-	cursor, err := producersCollection.Aggregate(context.TODO(), mongo.Pipeline{
+	cursor, err := producersCollection.Aggregate(ctx, mongo.Pipeline{
 		bson.D{{"$match", bson.D{{"station_id", station.ID}}}},
 		bson.D{{"$sort", bson.D{{"creation_date", -1}}}},
 		bson.D{{"$lookup", bson.D{{"from", "stations"}, {"localField", "station_id"}, {"foreignField", "_id"}, {"as", "station"}}}},
@@ -302,7 +342,7 @@ func (ph ProducersHandler) GetProducersByStation(station models.Station) ([]mode
 		return producers, producers, producers, err
 	}
 
-	if err = cursor.All(context.TODO(), &producers); err != nil {
+	if err = cursor.All(ctx, &producers); err != nil {
 		return producers, producers, producers, err
 	}
 
@@ -351,6 +391,9 @@ func (ph ProducersHandler) GetProducersByStation(station models.Station) ([]mode
 }
 
 func (ph ProducersHandler) GetAllProducersByStation(c *gin.Context) { // for the REST endpoint
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
 	var body models.GetAllProducersByStationSchema
 	ok := utils.Validate(c, &body, false, nil)
 	if !ok {
@@ -358,7 +401,7 @@ func (ph ProducersHandler) GetAllProducersByStation(c *gin.Context) { // for the
 	}
 
 	stationName, err := StationNameFromStr(body.StationName)
-	exist, station, err := IsStationExist(stationName)
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
 	if err != nil {
 		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
 		return
@@ -370,7 +413,7 @@ func (ph ProducersHandler) GetAllProducersByStation(c *gin.Context) { // for the
 	}
 
 	var producers []models.ExtendedProducer
-	cursor, err := producersCollection.Aggregate(context.TODO(), mongo.Pipeline{
+	cursor, err := producersCollection.Aggregate(ctx, mongo.Pipeline{
 		bson.D{{"$match", bson.D{{"station_id", station.ID}}}},
 		bson.D{{"$lookup", bson.D{{"from", "stations"}, {"localField", "station_id"}, {"foreignField", "_id"}, {"as", "station"}}}},
 		bson.D{{"$unwind", bson.D{{"path", "$station"}, {"preserveNullAndEmptyArrays", true}}}},
@@ -385,7 +428,7 @@ func (ph ProducersHandler) GetAllProducersByStation(c *gin.Context) { // for the
 		return
 	}
 
-	if err = cursor.All(context.TODO(), &producers); err != nil {
+	if err = cursor.All(ctx, &producers); err != nil {
 		serv.Errorf("GetAllProducersByStation: Station " + body.StationName + ": " + err.Error())
 		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
 		return
@@ -456,7 +499,7 @@ func (s *Server) destroyProducerDirect(c *client, reply string, msg []byte) {
 		serv.Errorf("destroyProducerDirect: Producer " + name + "at station " + dpr.StationName + ": " + err.Error())
 	}
 
-	shouldSendAnalytics, _ := shouldSendAnalytics()
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategoryStation)
 	if shouldSendAnalytics {
 		analytics.SendEvent(c.memphisInfo.username, "user-remove-producer")
 	}
@@ -18,7 +18,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/graph-gophers/graphql-go"
+	"github.com/jhump/protoreflect/desc"
 	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/jhump/protoreflect/dynamic"
 	"github.com/santhosh-tekuri/jsonschema/v5"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -163,6 +165,133 @@ func generateSchemaDescriptor(schemaName string, schemaVersionNum int, schemaCon
 	return string(descriptor), nil
 }
 
+// validateMessageAgainstSchema checks a payload the way producers of a station using the schema version would,
+// the returned list holds the validation errors and is empty when the payload is valid
+func validateMessageAgainstSchema(schemaType string, schemaVersion models.SchemaVersion, payload []byte) ([]string, error) {
+	switch schemaType {
+	case "json":
+		schema, err := jsonschema.CompileString(schemaVersion.SchemaId.Hex(), schemaVersion.SchemaContent)
+		if err != nil {
+			return nil, err
+		}
+		var msg interface{}
+		if err = json.Unmarshal(payload, &msg); err != nil {
+			return []string{"payload is not a valid json: " + err.Error()}, nil
+		}
+		err = schema.Validate(msg)
+		if err == nil {
+			return []string{}, nil
+		}
+		verr, ok := err.(*jsonschema.ValidationError)
+		if !ok {
+			return nil, err
+		}
+		return jsonSchemaValidationErrors(verr), nil
+	case "protobuf":
+		md, err := protobufMessageDescriptor(schemaVersion)
+		if err != nil {
+			return nil, err
+		}
+		msg := dynamic.NewMessage(md)
+		if err = msg.Unmarshal(payload); err != nil {
+			return []string{err.Error()}, nil
+		}
+		if err = msg.ValidateRecursive(); err != nil {
+			return []string{err.Error()}, nil
+		}
+		return []string{}, nil
+	case "graphql":
+		schema, err := graphql.ParseSchema(schemaVersion.SchemaContent, nil)
+		if err != nil {
+			return nil, err
+		}
+		validationErrs := []string{}
+		for _, qerr := range schema.Validate(string(payload)) {
+			validationErrs = append(validationErrs, qerr.Error())
+		}
+		return validationErrs, nil
+	default:
+		return nil, errors.New("validating messages of schema type " + schemaType + " is not supported")
+	}
+}
+
+func protobufMessageDescriptor(schemaVersion models.SchemaVersion) (*desc.MessageDescriptor, error) {
+	parser := protoparse.Parser{
+		Accessor: func(filename string) (io.ReadCloser, error) {
+			return ioutil.NopCloser(strings.NewReader(schemaVersion.SchemaContent)), nil
+		},
+	}
+	fds, err := parser.ParseFiles("")
+	if err != nil {
+		return nil, err
+	}
+	md := fds[0].FindMessage(schemaVersion.MessageStructName)
+	if md == nil && fds[0].GetPackage() != "" {
+		md = fds[0].FindMessage(fds[0].GetPackage() + "." + schemaVersion.MessageStructName)
+	}
+	if md == nil {
+		return nil, errors.New("message struct " + schemaVersion.MessageStructName + " does not exist in the schema")
+	}
+	return md, nil
+}
+
+// decodeMessageWithSchema returns the JSON representation of a payload produced with the schema version, for display only
+func decodeMessageWithSchema(schemaType string, schemaVersion models.SchemaVersion, payload []byte) (json.RawMessage, error) {
+	switch schemaType {
+	case "protobuf":
+		md, err := protobufMessageDescriptor(schemaVersion)
+		if err != nil {
+			return nil, err
+		}
+		msg := dynamic.NewMessage(md)
+		if err = msg.Unmarshal(payload); err != nil {
+			return nil, err
+		}
+		return msg.MarshalJSON()
+	case "json":
+		if !json.Valid(payload) {
+			return nil, errors.New("payload is not a valid json")
+		}
+		return payload, nil
+	case "graphql":
+		return json.Marshal(string(payload))
+	default:
+		return nil, errors.New("decoding messages of schema type " + schemaType + " is not supported")
+	}
+}
+
+// schemaFailureReason tells a payload which is not of the schema's type, e.g. not a json at all for a json schema,
+// from a payload of the right type which fails the schema's rules
+func schemaFailureReason(schemaType string, schemaVersion models.SchemaVersion, payload []byte) string {
+	switch schemaType {
+	case "json":
+		if !json.Valid(payload) {
+			return DlsReasonSchemaTypeMismatch
+		}
+	case "protobuf":
+		md, err := protobufMessageDescriptor(schemaVersion)
+		if err == nil && dynamic.NewMessage(md).Unmarshal(payload) != nil {
+			return DlsReasonSchemaTypeMismatch
+		}
+	}
+	return DlsReasonSchemaValidationErr
+}
+
+func jsonSchemaValidationErrors(verr *jsonschema.ValidationError) []string {
+	if len(verr.Causes) == 0 {
+		location := verr.InstanceLocation
+		if location == "" {
+			location = "/"
+		}
+		return []string{location + ": " + verr.Message}
+	}
+	validationErrs := []string{}
+	for _, cause := range verr.Causes {
+		validationErrs = append(validationErrs, jsonSchemaValidationErrors(cause)...)
+	}
+	return validationErrs
+}
+
 func validateMessageStructName(messageStructName string) error {
 	if messageStructName == "" {
 		return errors.New("Message struct name is required when schema type is Protobuf")
@@ -176,16 +305,21 @@ func generateSchemaUpdateInit(schema models.Schema) (*models.ProducerSchemaUpdat
 		return nil, err
 	}
 
+	return generateSchemaVersionUpdateInit(schema, activeVersion), nil
+}
+
+// generateSchemaVersionUpdateInit builds the producers' init of a specific version of the schema
+func generateSchemaVersionUpdateInit(schema models.Schema, version models.SchemaVersion) *models.ProducerSchemaUpdateInit {
 	return &models.ProducerSchemaUpdateInit{
 		SchemaName: schema.Name,
 		ActiveVersion: models.ProducerSchemaUpdateVersion{
-			VersionNumber:     activeVersion.VersionNumber,
-			Descriptor:        activeVersion.Descriptor,
-			Content:           activeVersion.SchemaContent,
-			MessageStructName: activeVersion.MessageStructName,
+			VersionNumber:     version.VersionNumber,
+			Descriptor:        version.Descriptor,
+			Content:           version.SchemaContent,
+			MessageStructName: version.MessageStructName,
 		},
 		SchemaType: schema.Type,
-	}, nil
+	}
 }
 
 func getSchemaUpdateInitFromStation(sn StationName) (*models.ProducerSchemaUpdateInit, error) {
@@ -630,7 +764,7 @@ func (sh SchemasHandler) CreateNewSchema(c *gin.Context) {
 		}
 	}
 
-	shouldSendAnalytics, _ := shouldSendAnalytics()
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategorySchema)
 	if shouldSendAnalytics {
 		user, _ := getUserDetailsFromMiddleware(c)
 		analytics.SendEvent(user.Username, "user-create-schema")
@@ -647,7 +781,7 @@ func (sh SchemasHandler) GetAllSchemas(c *gin.Context) {
 		return
 	}
 
-	shouldSendAnalytics, _ := shouldSendAnalytics()
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategoryBrowse)
 	if shouldSendAnalytics {
 		user, _ := getUserDetailsFromMiddleware(c)
 		analytics.SendEvent(user.Username, "user-enter-schema-page")
@@ -683,7 +817,7 @@ func (sh SchemasHandler) GetSchemaDetails(c *gin.Context) {
 		return
 	}
 
-	shouldSendAnalytics, _ := shouldSendAnalytics()
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategoryBrowse)
 	if shouldSendAnalytics {
 		user, _ := getUserDetailsFromMiddleware(c)
 		analytics.SendEvent(user.Username, "user-enter-schema-details")
@@ -692,7 +826,35 @@ func (sh SchemasHandler) GetSchemaDetails(c *gin.Context) {
 	c.IndentedJSON(200, schemaDetails)
 }
 
+// findStationRequiringSchema returns the name of a station which requires a schema and uses the given one,
+// such a schema can not be deleted since the station would be left without it. an empty result means there is none
+func findStationRequiringSchema(schemaName string) (string, error) {
+	var station models.Station
+	err := stationsCollection.FindOne(context.TODO(), bson.M{
+		"schema.name":     schemaName,
+		"schema_required": true,
+		"$or": []interface{}{
+			bson.M{"is_deleted": false},
+			bson.M{"is_deleted": bson.M{"$exists": false}},
+		},
+	}).Decode(&station)
+	if err == mongo.ErrNoDocuments {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return station.Name, nil
+}
+
 func deleteSchemaFromStations(s *Server, schemaName string) error {
+	stationName, err := findStationRequiringSchema(schemaName)
+	if err != nil {
+		return err
+	}
+	if stationName != "" {
+		return errors.New("Schema " + schemaName + " is used by station " + stationName + " which requires a schema")
+	}
+
 	var stations []models.Station
 	cursor, err := stationsCollection.Find(nil, bson.M{"schema.name": schemaName})
 	if err != nil {
@@ -718,7 +880,7 @@ func deleteSchemaFromStations(s *Server, schemaName string) error {
 			continue
 		}
 
-		removeSchemaFromStation(s, sn, false)
+		removeSchemaFromStation(context.TODO(), s, sn, false)
 	}
 
 	_, err = stationsCollection.UpdateMany(context.TODO(),
@@ -732,6 +894,17 @@ func deleteSchemaFromStations(s *Server, schemaName string) error {
 		return err
 	}
 
+	_, err = stationsCollection.UpdateMany(context.TODO(),
+		bson.M{
+			"pending_schema.name": schemaName,
+		},
+		bson.M{"$unset": bson.M{"pending_schema": ""}},
+	)
+	if err != nil {
+		s.Errorf("deleteSchemaFromStations: Schema " + schemaName + ": " + err.Error())
+		return err
+	}
+
 	return nil
 }
 
@@ -747,6 +920,23 @@ func (sh SchemasHandler) RemoveSchema(c *gin.Context) {
 	}
 	var schemaIds []primitive.ObjectID
 
+	// nothing is deleted while one of the schemas is still required by a station
+	for _, name := range body.SchemaNames {
+		schemaName := strings.ToLower(name)
+		stationName, err := findStationRequiringSchema(schemaName)
+		if err != nil {
+			serv.Errorf("RemoveSchema: Schema " + schemaName + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+			return
+		}
+		if stationName != "" {
+			errMsg := "Schema " + schemaName + " is used by station " + stationName + " which requires a schema, disable the requirement before deleting it"
+			serv.Warnf("RemoveSchema: " + errMsg)
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg})
+			return
+		}
+	}
+
 	for _, name := range body.SchemaNames {
 		schemaName := strings.ToLower(name)
 		exist, schema, err := IsSchemaExist(schemaName)
@@ -780,7 +970,7 @@ func (sh SchemasHandler) RemoveSchema(c *gin.Context) {
 		}
 	}
 
-	shouldSendAnalytics, _ := shouldSendAnalytics()
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategorySchema)
 	if shouldSendAnalytics {
 		user, _ := getUserDetailsFromMiddleware(c)
 		analytics.SendEvent(user.Username, "user-remove-schema")
@@ -898,7 +1088,7 @@ func (sh SchemasHandler) CreateNewVersion(c *gin.Context) {
 		return
 	}
 
-	shouldSendAnalytics, _ := shouldSendAnalytics()
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategorySchema)
 	if shouldSendAnalytics {
 		user, _ := getUserDetailsFromMiddleware(c)
 		analytics.SendEvent(user.Username, "user-create-new-schema-version")
@@ -967,7 +1157,7 @@ func (sh SchemasHandler) RollBackVersion(c *gin.Context) {
 		return
 	}
 
-	shouldSendAnalytics, _ := shouldSendAnalytics()
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategorySchema)
 	if shouldSendAnalytics {
 		user, _ := getUserDetailsFromMiddleware(c)
 		analytics.SendEvent(user.Username, "user-rollback-schema-version")
@@ -999,7 +1189,7 @@ func (sh SchemasHandler) ValidateSchema(c *gin.Context) {
 		return
 	}
 
-	shouldSendAnalytics, _ := shouldSendAnalytics()
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategorySchema)
 	if shouldSendAnalytics {
 		user, _ := getUserDetailsFromMiddleware(c)
 		analytics.SendEvent(user.Username, "user-validate-schema")
@@ -0,0 +1,81 @@
+// Credit for The NATS.IO Authors
+// Copyright 2021-2022 The Memphis Authors
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"memphis-broker/models"
+	"strings"
+	"testing"
+)
+
+func TestValidateMessageAgainstJsonSchema(t *testing.T) {
+	schemaVersion := models.SchemaVersion{
+		SchemaContent: `{"type": "object", "properties": {"id": {"type": "integer"}}, "required": ["id"]}`,
+	}
+
+	validationErrs, err := validateMessageAgainstSchema("json", schemaVersion, []byte(`{"id": 1}`))
+	if err != nil || len(validationErrs) != 0 {
+		t.Fatalf("expected a valid payload, got %v %v", validationErrs, err)
+	}
+
+	for _, payload := range []string{`{"id": "1"}`, `{}`, `not json`} {
+		validationErrs, err = validateMessageAgainstSchema("json", schemaVersion, []byte(payload))
+		if err != nil {
+			t.Fatalf("%v: unexpected error %v", payload, err)
+		}
+		if len(validationErrs) == 0 {
+			t.Fatalf("%v: expected validation errors", payload)
+		}
+	}
+}
+
+func TestDecodeMessageWithSchema(t *testing.T) {
+	schemaVersion := models.SchemaVersion{
+		SchemaContent:     "syntax = \"proto3\";\nmessage Order {\n  string id = 1;\n  int32 amount = 2;\n}\n",
+		MessageStructName: "Order",
+	}
+	// id: "a1", amount: 5
+	payload := []byte{0x0a, 0x02, 'a', '1', 0x10, 0x05}
+	decoded, err := decodeMessageWithSchema("protobuf", schemaVersion, payload)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !strings.Contains(string(decoded), "\"a1\"") || !strings.Contains(string(decoded), "5") {
+		t.Fatalf("unexpected decoded message %s", decoded)
+	}
+
+	if _, err = decodeMessageWithSchema("json", models.SchemaVersion{}, []byte("{")); err == nil {
+		t.Fatalf("expected an invalid json payload to fail decoding")
+	}
+	if _, err = decodeMessageWithSchema("avro", models.SchemaVersion{}, payload); err == nil {
+		t.Fatalf("expected an unsupported schema type to fail decoding")
+	}
+}
+
+func TestSchemaFailureReason(t *testing.T) {
+	if reason := schemaFailureReason("json", models.SchemaVersion{}, []byte("not a json")); reason != DlsReasonSchemaTypeMismatch {
+		t.Fatalf("expected a type mismatch, got %v", reason)
+	}
+	if reason := schemaFailureReason("json", models.SchemaVersion{}, []byte(`{"id": 1}`)); reason != DlsReasonSchemaValidationErr {
+		t.Fatalf("expected a validation error, got %v", reason)
+	}
+	schemaVersion := models.SchemaVersion{
+		SchemaContent:     "syntax = \"proto3\";\nmessage Order {\n  string id = 1;\n}\n",
+		MessageStructName: "Order",
+	}
+	if reason := schemaFailureReason("protobuf", schemaVersion, []byte{0xff, 0xff, 0xff}); reason != DlsReasonSchemaTypeMismatch {
+		t.Fatalf("expected a type mismatch, got %v", reason)
+	}
+}
@@ -14,17 +14,22 @@
 package server
 
 import (
+	"compress/gzip"
 	"context"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"memphis-broker/analytics"
 	"memphis-broker/models"
 	"memphis-broker/utils"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -38,8 +43,55 @@ type StationsHandler struct{ S *Server }
 
 const (
 	stationObjectName = "Station"
+	// above this amount of stations GetAllStations responds with compact, streamed JSON
+	allStationsCompactThreshold = 100
+	stationReadyTimeout         = 10 * time.Second
+	schemaVersionHeader         = "$memphis_schema_version"
+	unknownSchemaVersion        = "unknown"
+	maxMessagesDetailsBatch     = 100
+	defaultTimeRangePageSize    = 100
+	maxMessagesDeleteBatch      = 1000
+	schemaFailureRateWindow     = time.Hour
+	maxRecentMessagesInStation  = 100
+	unlimitedRetentionType      = "none"
+	keyedRetentionType          = "keyed"
+	maxPoisonTrendBuckets       = 1000
+	defaultAuditLogsPageSize    = 50
+	maxAuditLogsPageSize        = 500
+	maxRunningResendJobs        = 4
+	resendJobRetention          = time.Hour
+	maxStationsByNames          = 100
+	maxStationDescriptionLength = 1024
+	maxStationMetadataEntries   = 50
+	maxStationMetadataKeyLength = 64
+	messagesOrderOldest         = "oldest"
+	messagesOrderNewest         = "newest"
+	maxRetentionPinDuration     = 7 * 24 * time.Hour
+	defaultPoisonMessagesPage   = 50
+	maxPoisonMessagesPage       = 500
+	poisonMessagesFetchWorkers  = 8
+	removeStationsWorkers       = 8
+	defaultSchemaWatchTimeout   = 30 * time.Second
+	maxSchemaWatchTimeout       = 60 * time.Second
 )
 
+var (
+	ErrMissingMsgHeaders         = errors.New("Error while getting notified about a poison message: Missing mandatory message headers, please upgrade the SDK version you are using")
+	ErrStationDeletionInProgress = errors.New("a station with the same name is being deleted, please retry in a few seconds")
+	ErrStationCreationInProgress = errors.New("a station with the same name is being created, please retry in a few seconds")
+	ErrStationSchemaChanged      = errors.New("station schema changed concurrently, please retry")
+	ErrNonNativeStationSchema    = errors.New("schemas can not be attached to non native stations, schema enforcement applies to Memphis producers only")
+	ErrTooManyResendJobs         = errors.New("too many resend jobs are running, please retry once one of them is done")
+	ErrMissingMemphisHeaders     = errors.New("missing mandatory message headers, the station accepts messages produced by Memphis SDKs only")
+	ErrStationReadOnly           = errors.New("station is read only, new messages are rejected until it is writable again")
+	ErrMirrorStationProduce      = errors.New("station mirrors another station, messages have to be produced to the source station")
+)
+
+// schemaNotFoundError is returned when a station is given a schema that does not exist
+func schemaNotFoundError(schemaName string) error {
+	return withErrorCode(ErrCodeSchemaMissing, errors.New("Schema "+schemaName+" does not exist"))
+}
+
 type StationName struct {
 	internal string
 	external string
@@ -54,11 +106,15 @@ func (sn StationName) Intern() string {
 }
 
 func StationNameFromStr(name string) (StationName, error) {
-	extern := strings.ToLower(name)
+	err := validateNameCharacters(name, stationObjectName)
+	if err != nil {
+		return StationName{}, withErrorCode(ErrCodeStationNameInvalid, err)
+	}
 
-	err := validateName(extern, stationObjectName)
+	extern := strings.ToLower(name)
+	err = validateName(extern, stationObjectName)
 	if err != nil {
-		return StationName{}, err
+		return StationName{}, withErrorCode(ErrCodeStationNameInvalid, err)
 	}
 
 	intern := replaceDelimiters(name)
@@ -75,1782 +131,7142 @@ func StationNameFromStreamName(streamName string) StationName {
 }
 
 func validateRetentionType(retentionType string) error {
-	if retentionType != "message_age_sec" && retentionType != "messages" && retentionType != "bytes" {
-		return errors.New("retention type can be one of the following message_age_sec/messages/bytes")
+	if retentionType != "message_age_sec" && retentionType != "messages" && retentionType != "bytes" && retentionType != unlimitedRetentionType && retentionType != keyedRetentionType {
+		return withErrorCode(ErrCodeRetentionInvalid, errors.New("retention type can be one of the following message_age_sec/messages/bytes/none/keyed"))
+	}
+
+	return nil
+}
+
+// isUnlimitedRetention tells whether the station never deletes messages, a retention that must be asked for explicitly
+func isUnlimitedRetention(retentionType string) bool {
+	return strings.ToLower(retentionType) == unlimitedRetentionType
+}
+
+// isKeyedRetention tells whether the station keeps only the latest message of each compaction key
+func isKeyedRetention(retentionType string) bool {
+	return strings.ToLower(retentionType) == keyedRetentionType
+}
+
+// retentionIgnoresValue tells whether the retention type is used without a retention value
+func retentionIgnoresValue(retentionType string) bool {
+	return isUnlimitedRetention(retentionType) || isKeyedRetention(retentionType)
+}
+
+// validateRetentionValue rejects an explicitly set retention type without a positive value,
+// falling back to the default retention would silently create a station retaining messages other than requested
+func validateRetentionValue(retentionType string, retentionValue int) error {
+	if retentionIgnoresValue(retentionType) || retentionValue > 0 {
+		return nil
+	}
+	return withErrorCode(ErrCodeRetentionInvalid, errors.New("retention value has to be a positive number for the "+retentionType+" retention type"))
+}
+
+// normalizeStationMetadata checks the free text description and metadata of a station, a missing metadata is stored empty
+func normalizeStationMetadata(description string, metadata map[string]string) (map[string]string, error) {
+	if len(description) > maxStationDescriptionLength {
+		return map[string]string{}, errors.New("description can not exceed " + strconv.Itoa(maxStationDescriptionLength) + " characters")
+	}
+	if len(metadata) > maxStationMetadataEntries {
+		return map[string]string{}, errors.New("metadata can not exceed " + strconv.Itoa(maxStationMetadataEntries) + " entries")
+	}
+	normalized := make(map[string]string)
+	for key, value := range metadata {
+		if strings.TrimSpace(key) == "" {
+			return map[string]string{}, errors.New("metadata keys can not be empty")
+		}
+		if len(key) > maxStationMetadataKeyLength {
+			return map[string]string{}, errors.New("metadata key " + key + " can not exceed " + strconv.Itoa(maxStationMetadataKeyLength) + " characters")
+		}
+		if len(value) > maxStationDescriptionLength {
+			return map[string]string{}, errors.New("the value of metadata key " + key + " can not exceed " + strconv.Itoa(maxStationDescriptionLength) + " characters")
+		}
+		normalized[key] = value
+	}
+
+	return normalized, nil
+}
+
+// validateCompactionKey checks the header keyed stations are compacted by, extra subjects are not allowed
+// since each of their subjects would be compacted on its own
+func validateCompactionKey(retentionType, compactionKey string, subjects []string) error {
+	if !isKeyedRetention(retentionType) {
+		if compactionKey != "" {
+			return errors.New("compaction_key_header can only be set for the keyed retention")
+		}
+		return nil
+	}
+	if compactionKey == "" {
+		return errors.New("compaction_key_header is required for the keyed retention")
+	}
+	if strings.HasPrefix(compactionKey, "$memphis") {
+		return errors.New("memphis headers can not be used as the compaction key")
+	}
+	if len(subjects) > 0 {
+		return errors.New("the keyed retention can not be combined with extra subjects")
 	}
 
 	return nil
 }
 
+// normalizePartitionKeyHeader checks the header the producers of a station partition their messages by,
+// the SDKs read it as is from the messages so it has to be a valid header name. an empty header disables partitioning
+func normalizePartitionKeyHeader(header string) (string, error) {
+	if header == "" {
+		return "", nil
+	}
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return "", errors.New("partition_key_header can not be empty")
+	}
+	if strings.HasPrefix(header, "$memphis") {
+		return "", errors.New("memphis headers can not be used as the partition key")
+	}
+	for _, r := range header {
+		if r <= ' ' || r >= 0x7f || r == ':' {
+			return "", errors.New("partition_key_header " + header + " is not a valid header name")
+		}
+	}
+
+	return header, nil
+}
+
 func validateStorageType(storageType string) error {
 	if storageType != "file" && storageType != "memory" {
-		return errors.New("storage type can be one of the following file/memory")
+		return withErrorCode(ErrCodeStorageTypeInvalid, errors.New("storage type can be one of the following file/memory"))
 	}
 
 	return nil
 }
 
+// normalizeStorageTypeFilter accepts both the internal (file) and the display (disk) storage type
+func normalizeStorageTypeFilter(storageType string) (string, error) {
+	storageType = strings.ToLower(strings.TrimSpace(storageType))
+	if storageType == "disk" {
+		storageType = "file"
+	}
+	if err := validateStorageType(storageType); err != nil {
+		return "", withErrorCode(ErrCodeStorageTypeInvalid, errors.New("storage type can be one of the following file/disk/memory"))
+	}
+	return storageType, nil
+}
+
 func validateReplicas(replicas int) error {
 	if replicas > 5 {
-		return errors.New("max replicas in a cluster is 5")
+		return withErrorCode(ErrCodeReplicasExceeded, errors.New("max replicas in a cluster is 5"))
 	}
 
 	return nil
 }
 
-// TODO remove the station resources - functions, connectors
-func removeStationResources(s *Server, station models.Station, nonNativeRemoveStreamFunc func() error) error {
-	stationName, err := StationNameFromStr(station.Name)
-	if err != nil {
-		return err
+// validateMaxMsgDeliveries checks the station default of its consumers, 0 leaves it to the server default
+func validateMaxMsgDeliveries(maxMsgDeliveries int) error {
+	if maxMsgDeliveries < 0 || maxMsgDeliveries > maxConsumerMsgDeliveries {
+		return errors.New("max_msg_deliveries has to be between 1 and " + strconv.Itoa(maxConsumerMsgDeliveries) + ", or 0 to use the default")
 	}
 
-	removeFunc := nonNativeRemoveStreamFunc
-	if removeFunc == nil {
-		removeFunc = func() error {
-			return s.RemoveStream(stationName.Intern())
+	return nil
+}
+
+// validateStationSubjects checks the extra subjects a station's stream captures on top of its own subject,
+// subjects that could capture memphis or other internal subjects are rejected
+func validateStationSubjects(sn StationName, subjects []string) ([]string, error) {
+	var validSubjects []string
+	for _, subject := range subjects {
+		if !IsValidSubject(subject) {
+			return nil, errors.New("subject " + subject + " is not a valid subject")
+		}
+		firstToken := strings.Split(subject, tsep)[0]
+		if strings.HasPrefix(firstToken, "$") || firstToken == pwcs || firstToken == fwcs {
+			return nil, errors.New("subject " + subject + " overlaps internal subjects")
+		}
+		if SubjectsCollide(subject, sn.Intern()+".>") {
+			return nil, errors.New("subject " + subject + " overlaps the station subject")
+		}
+		for _, validSubject := range validSubjects {
+			if SubjectsCollide(subject, validSubject) {
+				return nil, errors.New("subjects " + subject + " and " + validSubject + " overlap")
+			}
 		}
+		validSubjects = append(validSubjects, subject)
 	}
 
-	err = removeFunc()
+	return validSubjects, nil
+}
+
+// findStationSubjectsOverlap returns why the subjects a new station listens on overlap the ones of another station's stream,
+// an empty result means there is no overlap. removed stations whose streams are still retained count as well
+func findStationSubjectsOverlap(ctx context.Context, sn StationName, subjects []string) (string, error) {
+	var stations []models.Station
+	filter := bson.M{"resources_removed": bson.M{"$ne": true}}
+	cursor, err := stationsCollection.Find(ctx, filter, options.Find().SetProjection(bson.M{"name": 1, "subjects": 1}))
 	if err != nil {
-		return err
+		return "", err
+	}
+	if err = cursor.All(ctx, &stations); err != nil {
+		return "", err
 	}
 
-	err = s.RemoveStream(fmt.Sprintf(dlsStreamName, stationName.Intern()))
-	if err != nil {
-		return err
+	newSubjects := stationSubjects(sn, models.Station{Subjects: subjects})
+	for _, station := range stations {
+		otherSn, err := StationNameFromStr(station.Name)
+		if err != nil || otherSn.Ext() == sn.Ext() {
+			continue
+		}
+		for _, otherSubject := range stationSubjects(otherSn, station) {
+			for _, subject := range newSubjects {
+				if SubjectsCollide(subject, otherSubject) {
+					return "subject " + subject + " overlaps the subject " + otherSubject + " of station " + otherSn.Ext(), nil
+				}
+			}
+		}
 	}
+	return "", nil
+}
 
-	DeleteTagsFromStation(station.ID)
+// validateMaxConsumerGroups checks the station's cap of consumer groups, 0 leaves it to the server default
+func validateMaxConsumerGroups(maxConsumerGroups int) error {
+	if maxConsumerGroups < 0 {
+		return errors.New("max_consumer_groups can not be negative")
+	}
 
-	_, err = producersCollection.UpdateMany(context.TODO(),
-		bson.M{"station_id": station.ID},
-		bson.M{"$set": bson.M{"is_active": false, "is_deleted": true}},
-	)
-	if err != nil {
-		return err
+	return nil
+}
+
+// validateIdempotencyWindow rejects an idempotency window longer than an age based retention,
+// message ids are tracked only as long as their messages are kept so the dedup would silently cover less than asked
+func validateIdempotencyWindow(retentionType string, retentionValue int, idempotencyWindowMs int) error {
+	if retentionType != "message_age_sec" || retentionValue <= 0 {
+		return nil
 	}
+	if int64(idempotencyWindowMs) > int64(retentionValue)*1000 {
+		return withErrorCode(ErrCodeIdempotencyWindowInvalid, fmt.Errorf("idempotency window of %vms exceeds the message_age_sec retention of %vs, duplicates are detected only while the original message is retained, shorten the idempotency window or extend the retention", idempotencyWindowMs, retentionValue))
+	}
+	return nil
+}
 
-	_, err = consumersCollection.UpdateMany(context.TODO(),
-		bson.M{"station_id": station.ID},
-		bson.M{"$set": bson.M{"is_active": false, "is_deleted": true}},
-	)
-	if err != nil {
-		return err
+// defaultIdempotencyWindow caps the default idempotency window to an age based retention, as JetStream does with its own default
+func defaultIdempotencyWindow(retentionType string, retentionValue int, defaultWindowMs int) int {
+	if retentionType == "message_age_sec" && retentionValue > 0 && int64(defaultWindowMs) > int64(retentionValue)*1000 {
+		return retentionValue * 1000
 	}
+	return defaultWindowMs
+}
 
-	err = RemoveAllAuditLogsByStation(station.Name)
-	if err != nil {
-		serv.Errorf("removeStationResources: Station " + station.Name + ": " + err.Error())
+func validateMaxMsgSize(maxMsgSizeBytes int) error {
+	serverMax := configuration.MAX_MESSAGE_SIZE_MB * 1024 * 1024
+	if maxMsgSizeBytes <= 0 {
+		return errors.New("max message size has to be a positive number")
+	}
+	if maxMsgSizeBytes > serverMax {
+		return errors.New("max message size can not exceed the server's max message size (" + strconv.Itoa(serverMax) + " bytes)")
 	}
 
 	return nil
 }
 
-func (s *Server) createStationDirect(c *client, reply string, msg []byte) {
-	var csr createStationRequest
-	if err := json.Unmarshal(msg, &csr); err != nil {
-		s.Errorf("createStationDirect: failed creating station: %v", err.Error())
-		respondWithErr(s, reply, err)
-		return
+// validateAdoptedStreamConfig checks that a stream created outside of Memphis can be managed as a station
+func validateAdoptedStreamConfig(sn StationName, streamConfig StreamConfig) error {
+	if streamConfig.Retention != LimitsPolicy {
+		return errors.New("stream " + streamConfig.Name + " can not be adopted, only limits based retention is supported")
 	}
-	s.createStationDirectIntern(c, reply, &csr, nil)
-}
 
-func (s *Server) createStationDirectIntern(c *client,
-	reply string,
-	csr *createStationRequest,
-	nonNativeCreateStreamFunc func() error) {
-	isNative := nonNativeCreateStreamFunc == nil
-	jsApiResp := JSApiStreamCreateResponse{ApiResponse: ApiResponse{Type: JSApiStreamCreateResponseType}}
+	stationSubject := sn.Intern() + ".>"
+	for _, subject := range streamConfig.Subjects {
+		if subject == stationSubject {
+			return nil
+		}
+	}
+	return errors.New("stream " + streamConfig.Name + " can not be adopted, its subjects have to include " + stationSubject)
+}
 
-	stationName, err := StationNameFromStr(csr.StationName)
-	if err != nil {
-		serv.Warnf("createStationDirect: Station " + csr.StationName + ": " + err.Error())
-		jsApiResp.Error = NewJSStreamCreateError(err)
-		respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
-		return
+// applyAdoptedStreamConfig stores the settings of an adopted stream on the station, so updating the station later
+// does not reset the stream to the creation request's values. a stream with several limits keeps the age, messages or bytes one in that order
+func applyAdoptedStreamConfig(station *models.Station, streamConfig StreamConfig) {
+	switch {
+	case streamConfig.MaxAge > 0:
+		station.RetentionType = "message_age_sec"
+		station.RetentionValue = int(streamConfig.MaxAge.Seconds())
+	case streamConfig.MaxMsgs > 0:
+		station.RetentionType = "messages"
+		station.RetentionValue = int(streamConfig.MaxMsgs)
+	case streamConfig.MaxBytes > 0:
+		station.RetentionType = "bytes"
+		station.RetentionValue = int(streamConfig.MaxBytes)
+	default:
+		station.RetentionType = unlimitedRetentionType
+		station.RetentionValue = 0
+	}
+	if streamConfig.Storage == MemoryStorage {
+		station.StorageType = "memory"
+	} else {
+		station.StorageType = "file"
+	}
+	station.Replicas = streamConfig.Replicas
+	station.IdempotencyWindow = int(streamConfig.Duplicates.Milliseconds())
+	if streamConfig.MaxMsgSize > 0 {
+		station.MaxMsgSizeBytes = int(streamConfig.MaxMsgSize)
 	}
+}
 
-	exist, _, err := IsStationExist(stationName)
-	if err != nil {
-		serv.Errorf("createStationDirect: Station " + csr.StationName + ": " + err.Error())
-		jsApiResp.Error = NewJSStreamCreateError(err)
-		respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
-		return
+func validateSchemaEnforcement(enforcement string) error {
+	if enforcement != "reject" && enforcement != "dls" && enforcement != "off" {
+		return errors.New("schema enforcement can be one of the following reject/dls/off")
 	}
 
-	if exist {
-		errMsg := "Station " + stationName.Ext() + " already exists"
-		serv.Warnf("createStationDirect: " + errMsg)
-		jsApiResp.Error = NewJSStreamNameExistError()
-		respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
-		return
+	return nil
+}
+
+// getStationSchemaEnforcement returns the station's schema enforcement mode, stations created before the field existed capture schema failures in the DLS
+func getStationSchemaEnforcement(station models.Station) string {
+	if station.SchemaEnforcement == "" {
+		return "dls"
 	}
+	return station.SchemaEnforcement
+}
 
-	schemaName := csr.SchemaName
-	var schemaDetails models.SchemaDetails
-	if schemaName != "" {
-		schemaName = strings.ToLower(csr.SchemaName)
-		exist, schema, err := IsSchemaExist(schemaName)
-		if err != nil {
-			serv.Errorf("createStationDirect: Station " + csr.StationName + ": " + err.Error())
-			jsApiResp.Error = NewJSStreamCreateError(err)
-			respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
-			return
+// stationAllowsNonNative reports whether the station accepts messages lacking the Memphis SDK headers, e.g. raw NATS publishes,
+// stations created before the flag existed accept them
+func stationAllowsNonNative(station models.Station) bool {
+	return station.AllowNonNative == nil || *station.AllowNonNative
+}
+
+// getRetentionDescriptor returns a human readable form of the retention, e.g. "7 days", "10 GB", "1,000,000 messages"
+func getRetentionDescriptor(retentionType string, retentionValue int) string {
+	switch retentionType {
+	case "message_age_sec":
+		units := []struct {
+			name    string
+			seconds int
+		}{{"day", 86400}, {"hour", 3600}, {"minute", 60}}
+		for _, unit := range units {
+			if retentionValue >= unit.seconds && retentionValue%unit.seconds == 0 {
+				return pluralize(retentionValue/unit.seconds, unit.name)
+			}
 		}
-		if !exist {
-			errMsg := "Schema " + csr.SchemaName + " does not exist"
-			serv.Warnf("createStationDirect: " + errMsg)
-			jsApiResp.Error = NewJSStreamCreateError(err)
-			respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
-			return
+		return pluralize(retentionValue, "second")
+	case "bytes":
+		units := []string{"TB", "GB", "MB", "KB"}
+		for i, unit := range units {
+			size := 1 << (10 * (len(units) - i))
+			if retentionValue >= size {
+				value := strconv.FormatFloat(float64(retentionValue)/float64(size), 'f', 2, 64)
+				value = strings.TrimSuffix(strings.TrimRight(value, "0"), ".")
+				return value + " " + unit
+			}
 		}
+		return pluralize(retentionValue, "byte")
+	case "messages":
+		return pluralize(retentionValue, "message")
+	case unlimitedRetentionType:
+		return "unlimited, messages are never deleted"
+	case keyedRetentionType:
+		return "the latest message of each key"
+	default:
+		return ""
+	}
+}
 
-		schemaVersion, err := getActiveVersionBySchemaId(schema.ID)
-		if err != nil {
-			serv.Errorf("createStationDirect: Station " + csr.StationName + ": " + err.Error())
-			jsApiResp.Error = NewJSStreamCreateError(err)
-			respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
-			return
-		}
-		schemaDetails = models.SchemaDetails{SchemaName: schemaName, VersionNumber: schemaVersion.VersionNumber}
+func pluralize(amount int, unit string) string {
+	if amount == 1 {
+		return "1 " + unit
 	}
+	return formatWithThousandsSep(amount) + " " + unit + "s"
+}
 
-	var retentionType string
-	var retentionValue int
-	if csr.RetentionType != "" {
-		retentionType = strings.ToLower(csr.RetentionType)
-		err = validateRetentionType(retentionType)
-		if err != nil {
-			serv.Warnf("createStationDirect: " + err.Error())
-			jsApiResp.Error = NewJSStreamCreateError(err)
-			respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
-			return
-		}
-		retentionValue = csr.RetentionValue
-	} else {
-		retentionType = "message_age_sec"
-		retentionValue = 604800 // 1 week
+func formatWithThousandsSep(n int) string {
+	if n < 0 {
+		return "-" + formatWithThousandsSep(-n)
+	}
+	str := strconv.Itoa(n)
+	for i := len(str) - 3; i > 0; i -= 3 {
+		str = str[:i] + "," + str[i:]
 	}
+	return str
+}
 
-	var storageType string
-	if csr.StorageType != "" {
-		storageType = strings.ToLower(csr.StorageType)
-		err = validateStorageType(storageType)
-		if err != nil {
-			serv.Warnf("createStationDirect: " + err.Error())
-			jsApiResp.Error = NewJSStreamCreateError(err)
-			respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
-			return
+// stationDefaults holds the settings a station is created with when the request leaves them empty
+type stationDefaults struct {
+	RetentionType     string
+	RetentionValue    int
+	StorageType       string
+	Replicas          int
+	IdempotencyWindow int
+	DlsConfiguration  models.DlsConfiguration
+}
+
+// getStationDefaults returns the station creation defaults, each of them can be overridden in the server configuration
+func getStationDefaults() stationDefaults {
+	defaults := stationDefaults{
+		RetentionType:     "message_age_sec",
+		RetentionValue:    604800, // 1 week
+		StorageType:       "file",
+		Replicas:          1,
+		IdempotencyWindow: 120000,
+		DlsConfiguration:  models.DlsConfiguration{Poison: true, Schemaverse: true},
+	}
+
+	retentionType := strings.ToLower(configuration.STATION_DEFAULT_RETENTION_TYPE)
+	if retentionType != "" && configuration.STATION_DEFAULT_RETENTION_VALUE > 0 {
+		if retentionIgnoresValue(retentionType) {
+			serv.Warnf("getStationDefaults: ignoring the configured default retention: unlimited and keyed retentions have to be set per station")
+		} else if err := validateRetentionType(retentionType); err != nil {
+			serv.Warnf("getStationDefaults: ignoring the configured default retention: " + err.Error())
+		} else {
+			defaults.RetentionType = retentionType
+			defaults.RetentionValue = configuration.STATION_DEFAULT_RETENTION_VALUE
 		}
-	} else {
-		storageType = "file"
 	}
-
-	replicas := csr.Replicas
-	if replicas > 0 {
-		err = validateReplicas(replicas)
-		if err != nil {
-			serv.Warnf("createStationDirect: " + err.Error())
-			jsApiResp.Error = NewJSStreamCreateError(err)
-			respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
-			return
+	storageType := strings.ToLower(configuration.STATION_DEFAULT_STORAGE_TYPE)
+	if storageType != "" {
+		if err := validateStorageType(storageType); err != nil {
+			serv.Warnf("getStationDefaults: ignoring the configured default storage type: " + err.Error())
+		} else {
+			defaults.StorageType = storageType
 		}
-	} else {
-		replicas = 1
 	}
-
-	if csr.IdempotencyWindow <= 0 {
-		csr.IdempotencyWindow = 120000 // default
-	} else if csr.IdempotencyWindow < 100 {
-		csr.IdempotencyWindow = 100 // minimum is 100 millis
+	if configuration.STATION_DEFAULT_REPLICAS > 0 {
+		if err := validateReplicas(configuration.STATION_DEFAULT_REPLICAS); err != nil {
+			serv.Warnf("getStationDefaults: ignoring the configured default replicas: " + err.Error())
+		} else {
+			defaults.Replicas = configuration.STATION_DEFAULT_REPLICAS
+		}
+	}
+	if configuration.STATION_DEFAULT_IDEMPOTENCY_WINDOW_MS >= 100 { // minimum is 100 millis
+		defaults.IdempotencyWindow = configuration.STATION_DEFAULT_IDEMPOTENCY_WINDOW_MS
+	}
+	if configuration.STATION_DEFAULT_DLS_POISON != "" {
+		if poison, err := strconv.ParseBool(configuration.STATION_DEFAULT_DLS_POISON); err != nil {
+			serv.Warnf("getStationDefaults: ignoring the configured default DLS poison capture: " + err.Error())
+		} else {
+			defaults.DlsConfiguration.Poison = poison
+		}
 	}
+	if configuration.STATION_DEFAULT_DLS_SCHEMAVERSE != "" {
+		if schemaverse, err := strconv.ParseBool(configuration.STATION_DEFAULT_DLS_SCHEMAVERSE); err != nil {
+			serv.Warnf("getStationDefaults: ignoring the configured default DLS schemaverse capture: " + err.Error())
+		} else {
+			defaults.DlsConfiguration.Schemaverse = schemaverse
+		}
+	}
+	return defaults
+}
 
-	newStation := models.Station{
-		ID:                primitive.NewObjectID(),
-		Name:              stationName.Ext(),
-		CreatedByUser:     c.memphisInfo.username,
-		CreationDate:      time.Now(),
-		IsDeleted:         false,
-		RetentionType:     retentionType,
-		RetentionValue:    retentionValue,
-		StorageType:       storageType,
-		Replicas:          replicas,
-		DedupEnabled:      csr.DedupEnabled,      // TODO deprecated
-		DedupWindowInMs:   csr.DedupWindowMillis, // TODO deprecated
-		LastUpdate:        time.Now(),
-		Schema:            schemaDetails,
-		Functions:         []models.Function{},
-		IdempotencyWindow: csr.IdempotencyWindow,
-		IsNative:          isNative,
-		DlsConfiguration:  csr.DlsConfiguration,
+// resolveDlsConfiguration returns the requested DLS configuration, or the default one when the request omits it
+func resolveDlsConfiguration(requested *models.DlsConfiguration, defaults stationDefaults) models.DlsConfiguration {
+	if requested == nil {
+		return defaults.DlsConfiguration
 	}
+	return *requested
+}
 
-	createStreamFunc := nonNativeCreateStreamFunc
+// normalizeClientAllowlist lowercases and validates the client names of a station allowlist, dropping duplicates
+func normalizeClientAllowlist(names []string, validateFunc func(string) error) ([]string, error) {
+	allowlist := []string{}
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.ToLower(name)
+		if err := validateFunc(name); err != nil {
+			return []string{}, err
+		}
+		if !seen[name] {
+			seen[name] = true
+			allowlist = append(allowlist, name)
+		}
+	}
+	return allowlist, nil
+}
 
-	if createStreamFunc == nil {
-		createStreamFunc = func() error {
-			return s.CreateStream(stationName, newStation)
+// isClientAllowed checks a client name against a station allowlist, an empty allowlist allows everyone
+func isClientAllowed(allowlist []string, name string) bool {
+	if len(allowlist) == 0 {
+		return true
+	}
+	for _, allowed := range allowlist {
+		if allowed == name {
+			return true
 		}
 	}
+	return false
+}
 
-	err = createStreamFunc()
+// normalizeCentralDlsStation validates the station whose DLS stream should hold the dead letters of stationName,
+// an empty name keeps the station on its own DLS
+func normalizeCentralDlsStation(stationName StationName, centralDlsStation string) (string, error) {
+	if centralDlsStation == "" {
+		return "", nil
+	}
+	centralSn, err := StationNameFromStr(centralDlsStation)
 	if err != nil {
-		serv.Errorf("createStationDirect: Station " + csr.StationName + ": " + err.Error())
-		respondWithErr(s, reply, err)
-		return
+		return "", err
 	}
-
-	err = s.CreateDlsStream(stationName, newStation)
+	if centralSn.Ext() == stationName.Ext() {
+		return "", errors.New("a station can not be its own central DLS station")
+	}
+	exist, _, err := IsStationExist(centralSn)
 	if err != nil {
-		serv.Errorf("createStationDirect: Create DLS at station " + csr.StationName + ": " + err.Error())
-		respondWithErr(s, reply, err)
-		return
+		return "", err
+	}
+	if !exist {
+		return "", errors.New("central DLS station " + centralSn.Ext() + " does not exist")
 	}
+	return centralSn.Ext(), nil
+}
 
-	_, err = stationsCollection.InsertOne(context.TODO(), newStation)
+// normalizeStationMirror validates the source station whose stream the stream of stationName should mirror,
+// a mirror is fed by its source only so it can not capture subjects of its own, adopt an existing stream or restrict what it stores.
+// its messages are read on the final subject of the source, so the source can not be keyed, capture subjects or be a mirror itself
+func normalizeStationMirror(stationName StationName, station models.Station, adoptExisting bool) (string, error) {
+	if station.Mirror == "" {
+		return "", nil
+	}
+	sourceSn, err := StationNameFromStr(station.Mirror)
 	if err != nil {
-		serv.Errorf("createStationDirect: Station " + csr.StationName + ": " + err.Error())
-		respondWithErr(s, reply, err)
-		return
+		return "", err
 	}
-	message := "Station " + stationName.Ext() + " has been created by user " + c.memphisInfo.username
-	serv.Noticef(message)
+	if sourceSn.Ext() == stationName.Ext() {
+		return "", errors.New("a station can not mirror itself")
+	}
+	if len(station.Subjects) > 0 {
+		return "", errors.New("a mirror station can not capture subjects, it holds the messages of station " + sourceSn.Ext() + " only")
+	}
+	if adoptExisting {
+		return "", errors.New("a mirror station can not adopt an existing stream")
+	}
+	if !stationAllowsNonNative(station) {
+		return "", errors.New("a mirror station has to allow non native messages, its messages are copied from station " + sourceSn.Ext() + " and not produced to it")
+	}
+	if station.SchemaRequired {
+		return "", errors.New("a mirror station can not require a schema, its messages are copied from station " + sourceSn.Ext() + " and not produced to it")
+	}
+	if isKeyedRetention(station.RetentionType) {
+		return "", errors.New("a mirror station can not have a keyed retention, it holds every message of station " + sourceSn.Ext())
+	}
+	exist, source, err := IsStationExist(sourceSn)
+	if err != nil {
+		return "", withErrorCode(ErrCodeServerError, err)
+	}
+	if !exist {
+		return "", errors.New("mirrored station " + sourceSn.Ext() + " does not exist")
+	}
+	if source.Mirror != "" {
+		return "", errors.New("station " + sourceSn.Ext() + " is a mirror itself and can not be mirrored")
+	}
+	if isKeyedRetention(source.RetentionType) || len(source.Subjects) > 0 {
+		return "", errors.New("station " + sourceSn.Ext() + " has a keyed retention or captures subjects and can not be mirrored")
+	}
+	return sourceSn.Ext(), nil
+}
 
-	var auditLogs []interface{}
-	newAuditLog := models.AuditLog{
-		ID:            primitive.NewObjectID(),
-		StationName:   stationName.Ext(),
-		Message:       message,
-		CreatedByUser: c.memphisInfo.username,
-		CreationDate:  time.Now(),
-		UserType:      "application",
+// getCentralDlsDependents returns the stations, other than the excluded ones, whose dead letters are kept in the DLS of stationName
+func getCentralDlsDependents(ctx context.Context, stationName string, excluded map[string]bool) ([]string, error) {
+	var dependents []models.Station
+	filter := bson.M{
+		"central_dls_station": stationName,
+		"$or": []interface{}{
+			bson.M{"is_deleted": false},
+			bson.M{"is_deleted": bson.M{"$exists": false}},
+		},
 	}
-	auditLogs = append(auditLogs, newAuditLog)
-	err = CreateAuditLogs(auditLogs)
+	cursor, err := stationsCollection.Find(ctx, filter, options.Find().SetProjection(bson.M{"name": 1}))
 	if err != nil {
-		serv.Errorf("createStationDirect: Station " + csr.StationName + " - create audit logs error: " + err.Error())
+		return nil, err
+	}
+	if err = cursor.All(ctx, &dependents); err != nil {
+		return nil, err
 	}
 
-	shouldSendAnalytics, _ := shouldSendAnalytics()
-	if shouldSendAnalytics {
-		param := analytics.EventParam{
-			Name:  "station-name",
-			Value: stationName.Ext(),
+	names := []string{}
+	for _, dependent := range dependents {
+		if !excluded[dependent.Name] {
+			names = append(names, dependent.Name)
 		}
-		analyticsParams := []analytics.EventParam{param}
-		analytics.SendEventWithParams(c.memphisInfo.username, analyticsParams, "user-create-station")
 	}
+	sort.Strings(names)
+	return names, nil
+}
 
-	respondWithErr(s, reply, nil)
+// centralDlsInUseError is returned when removing a station would leave the stations using it as their central DLS station without a DLS
+func centralDlsInUseError(stationName string, dependents []string) error {
+	return withErrorCode(ErrCodeStationInUse, errors.New("Station "+stationName+" is the central DLS station of "+strings.Join(dependents, ", ")+", remove them along with it or change their central DLS station first"))
 }
 
-func (sh StationsHandler) GetStation(c *gin.Context) {
-	var body models.GetStationSchema
-	ok := utils.Validate(c, &body, false, nil)
-	if !ok {
-		return
+// getPoisonedCgDeliveryCount returns how many times a message has been delivered to a consumer group, read from the
+// consumer while it still tracks the message and otherwise the deliveries recorded when it was poisoned, 0 when unknown
+func (s *Server) getPoisonedCgDeliveryCount(sn StationName, cg models.PoisonedCg, messageSeq int) int {
+	if dc, ok := s.memphisMsgDeliveryCount(sn, cg.CgName, uint64(messageSeq)); ok {
+		return dc
 	}
-	tagsHandler := TagsHandler{S: sh.S}
+	return cg.DeliveriesCount
+}
 
-	var station models.GetStationResponseSchema
-	err := stationsCollection.FindOne(context.TODO(), bson.M{
-		"name": body.StationName,
-		"$or": []interface{}{
-			bson.M{"is_deleted": false},
-			bson.M{"is_deleted": bson.M{"$exists": false}},
-		},
-	}).Decode(&station)
-	if err == mongo.ErrNoDocuments {
-		errMsg := "Station " + body.StationName + " does not exist"
-		serv.Warnf("GetStation: " + errMsg)
-		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg})
-		return
-	} else if err != nil {
-		serv.Errorf("GetStation: Station " + body.StationName + ": " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-		return
+// getStationMaxMsgSize returns the station's max message size, stations created before the field existed get the server's max
+func getStationMaxMsgSize(station models.Station) int {
+	if station.MaxMsgSizeBytes > 0 {
+		return station.MaxMsgSizeBytes
 	}
-	tags, err := tagsHandler.GetTagsByStation(station.ID)
+	return configuration.MAX_MESSAGE_SIZE_MB * 1024 * 1024
+}
+
+// rollbackStationCreation removes everything a station creation has set up so far
+func rollbackStationCreation(s *Server, sn StationName, stationId primitive.ObjectID) error {
+	DeleteTagsFromStation(stationId)
+
+	err := rollbackStationStreams(s, sn)
 	if err != nil {
-		serv.Errorf("GetStation: Station " + body.StationName + ": " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-		return
-	}
-	station.Tags = tags
-	if station.StorageType == "file" {
-		station.StorageType = "disk"
+		return err
 	}
 
-	c.IndentedJSON(200, station)
+	_, err = stationsCollection.DeleteOne(context.TODO(), bson.M{"_id": stationId})
+	return err
 }
 
-func (sh StationsHandler) GetStationsDetails() ([]models.ExtendedStationDetails, error) {
-	var exStations []models.ExtendedStationDetails
-	var stations []models.Station
-
-	poisonMsgsHandler := PoisonMessagesHandler{S: sh.S}
-	filter := bson.M{"$or": []interface{}{
-		bson.M{"is_deleted": bson.M{"$exists": false}},
-		bson.M{"is_deleted": false},
-	}}
-	cursor, err := stationsCollection.Find(context.TODO(), filter)
-	if err != nil {
-		return []models.ExtendedStationDetails{}, err
+// rollbackStationStreams removes the station and DLS streams a failed creation has created before the station was stored,
+// so they are not left behind without a station
+func rollbackStationStreams(s *Server, sn StationName) error {
+	err := s.RemoveStream(sn.Intern())
+	if err != nil && !IsNatsErr(err, JSStreamNotFoundErr) {
+		return err
 	}
 
-	if err = cursor.All(context.TODO(), &stations); err != nil {
-		return []models.ExtendedStationDetails{}, err
+	return rollbackDlsStream(s, sn)
+}
+
+// rollbackDlsStream removes the DLS stream a failed creation has created, used when the station stream was adopted and has to be kept
+func rollbackDlsStream(s *Server, sn StationName) error {
+	err := s.RemoveStream(fmt.Sprintf(dlsStreamName, sn.Intern()))
+	if err != nil && !IsNatsErr(err, JSStreamNotFoundErr) {
+		return err
 	}
+	return nil
+}
 
-	if len(stations) == 0 {
-		return []models.ExtendedStationDetails{}, nil
-	} else {
-		tagsHandler := TagsHandler{S: sh.S}
-		for _, station := range stations {
-			totalMessages, err := sh.GetTotalMessages(station.Name)
-			if err != nil {
-				if IsNatsErr(err, JSStreamNotFoundErr) {
-					continue
-				} else {
-					return []models.ExtendedStationDetails{}, err
-				}
-			}
-			poisonMessages, err := poisonMsgsHandler.GetTotalPoisonMsgsByStation(station.Name)
-			if err != nil {
-				if IsNatsErr(err, JSStreamNotFoundErr) {
-					continue
-				} else {
-					return []models.ExtendedStationDetails{}, err
-				}
-			}
-			tags, err := tagsHandler.GetTagsByStation(station.ID)
-			if err != nil {
-				return []models.ExtendedStationDetails{}, err
-			}
-			if station.StorageType == "file" {
-				station.StorageType = "disk"
-			}
-			exStations = append(exStations, models.ExtendedStationDetails{Station: station, PoisonMessages: poisonMessages, TotalMessages: totalMessages, Tags: tags})
+// stationStreamsRetained reports whether the streams of a removed station are kept for the deletion grace period,
+// the streams of a non native deletion or of a broker without a grace period are destroyed right away
+func stationStreamsRetained(nonNative bool) bool {
+	return !nonNative && stationDeletionGracePeriod() > 0
+}
+
+// removeStationResources deactivates the producers and consumers of a removed station,
+// its streams are kept for the deletion grace period and destroyed by the sweeper unless stationStreamsRetained says otherwise
+func removeStationResources(s *Server, station models.Station, nonNativeRemoveStreamFunc func() error) error {
+	if stationStreamsRetained(nonNativeRemoveStreamFunc != nil) {
+		err := retainStationStream(s, station)
+		if err != nil {
+			return err
 		}
-		if exStations == nil {
-			return []models.ExtendedStationDetails{}, nil
+	} else {
+		err := destroyStationResources(s, station, nonNativeRemoveStreamFunc)
+		if err != nil {
+			return err
 		}
-		return exStations, nil
 	}
-}
 
-func (sh StationsHandler) GetAllStationsDetails() ([]models.ExtendedStation, error) {
-	var stations []models.ExtendedStation
-	cursor, err := stationsCollection.Aggregate(context.TODO(), mongo.Pipeline{
-		bson.D{{"$match", bson.D{{"$or", []interface{}{
-			bson.D{{"is_deleted", false}},
-			bson.D{{"is_deleted", bson.D{{"$exists", false}}}},
-		}}}}},
-		bson.D{{"$project", bson.D{{"_id", 1}, {"name", 1}, {"retention_type", 1}, {"retention_value", 1}, {"storage_type", 1}, {"replicas", 1}, {"idempotency_window_in_ms", 1}, {"created_by_user", 1}, {"creation_date", 1}, {"last_update", 1}, {"functions", 1}, {"dls_configuration", 1}}}},
-	})
+	_, err := producersCollection.UpdateMany(context.TODO(),
+		bson.M{"station_id": station.ID},
+		bson.M{"$set": bson.M{"is_active": false, "is_deleted": true}},
+	)
 	if err != nil {
-		return stations, err
+		return err
 	}
 
-	if err = cursor.All(context.TODO(), &stations); err != nil {
-		return stations, err
-	}
+	_, err = consumersCollection.UpdateMany(context.TODO(),
+		bson.M{"station_id": station.ID},
+		bson.M{"$set": bson.M{"is_active": false, "is_deleted": true}},
+	)
+	return err
+}
 
-	if len(stations) == 0 {
-		return []models.ExtendedStation{}, nil
-	} else {
-		poisonMsgsHandler := PoisonMessagesHandler{S: sh.S}
-		tagsHandler := TagsHandler{S: sh.S}
-		var extStations []models.ExtendedStation
-		for i := 0; i < len(stations); i++ {
-			totalMessages, err := sh.GetTotalMessages(stations[i].Name)
-			if err != nil {
-				if IsNatsErr(err, JSStreamNotFoundErr) {
-					continue
-				} else {
-					return []models.ExtendedStation{}, err
-				}
-			}
-			poisonMessages, err := poisonMsgsHandler.GetTotalPoisonMsgsByStation(stations[i].Name)
-			if err != nil {
-				if IsNatsErr(err, JSStreamNotFoundErr) {
-					continue
-				} else {
-					return []models.ExtendedStation{}, err
-				}
-			}
-			tags, err := tagsHandler.GetTagsByStation(stations[i].ID)
-			if err != nil {
-				return []models.ExtendedStation{}, err
-			}
+// retainStationStream keeps the stream of a removed station for the deletion grace period,
+// it is made read only and its consumer groups are removed so the station neither takes nor delivers messages anymore
+func retainStationStream(s *Server, station models.Station) error {
+	stationName, err := StationNameFromStr(station.Name)
+	if err != nil {
+		return err
+	}
 
-			stations[i].TotalMessages = totalMessages
-			stations[i].PoisonMessages = poisonMessages
-			stations[i].Tags = tags
-			extStations = append(extStations, stations[i])
+	station.ReadOnly = true
+	err = s.applyStationReadOnly(stationName, station)
+	if err != nil {
+		if IsNatsErr(err, JSStreamNotFoundErr) {
+			return nil
 		}
-		return extStations, nil
+		return err
 	}
-}
 
-func (sh StationsHandler) GetStations(c *gin.Context) {
-	stations, err := sh.GetStationsDetails()
+	consumers, err := s.memphisAllConsumersInfo(stationName.Intern())
 	if err != nil {
-		serv.Errorf("GetStations: " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-		return
+		return err
 	}
-	c.IndentedJSON(200, gin.H{
-		"stations": stations,
-	})
+	for _, consumer := range consumers {
+		err = s.memphisRemoveConsumer(stationName.Intern(), consumer.Name)
+		if err != nil && !IsNatsErr(err, JSConsumerNotFoundErr) {
+			return err
+		}
+	}
+	return nil
 }
 
-func (sh StationsHandler) GetAllStations(c *gin.Context) {
-	stations, err := sh.GetAllStationsDetails()
+// TODO remove the station resources - functions, connectors
+func destroyStationResources(s *Server, station models.Station, removeStreamFunc func() error) error {
+	stationName, err := StationNameFromStr(station.Name)
 	if err != nil {
-		serv.Errorf("GetAllStations: " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-		return
+		return err
 	}
 
-	c.IndentedJSON(200, stations)
-}
-
-func (sh StationsHandler) CreateStation(c *gin.Context) {
-	var body models.CreateStationSchema
-	ok := utils.Validate(c, &body, false, nil)
-	if !ok {
-		return
+	if removeStreamFunc == nil {
+		removeStreamFunc = func() error {
+			return s.RemoveStream(stationName.Intern())
+		}
 	}
 
-	stationName, err := StationNameFromStr(body.Name)
-	if err != nil {
-		serv.Warnf("CreateStation: Station " + body.Name + ": " + err.Error())
-		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error()})
-		return
+	err = removeStreamFunc()
+	if err != nil && !IsNatsErr(err, JSStreamNotFoundErr) {
+		return err
 	}
 
-	exist, _, err := IsStationExist(stationName)
-	if err != nil {
-		serv.Errorf("CreateStation: Station " + body.Name + ": " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-		return
-	}
-	if exist {
-		errMsg := "Station " + stationName.external + " already exists"
-		serv.Warnf("CreateStation: " + errMsg)
-		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg})
-		return
+	err = s.RemoveStream(fmt.Sprintf(dlsStreamName, stationName.Intern()))
+	if err != nil && !IsNatsErr(err, JSStreamNotFoundErr) {
+		return err
 	}
 
-	user, err := getUserDetailsFromMiddleware(c)
+	DeleteTagsFromStation(station.ID)
+
+	err = RemoveAllAuditLogsByStation(station.Name)
 	if err != nil {
-		serv.Errorf("CreateStation: Station " + body.Name + ": " + err.Error())
-		c.AbortWithStatusJSON(401, gin.H{"message": "Unauthorized"})
+		serv.Errorf("destroyStationResources: Station " + station.Name + ": " + err.Error())
 	}
 
-	schemaName := body.SchemaName
-	var schemaDetails models.SchemaDetails
-	var schemaDetailsResponse models.StationOverviewSchemaDetails
-	if schemaName != "" {
-		schemaName = strings.ToLower(body.SchemaName)
-		exist, schema, err := IsSchemaExist(schemaName)
-		if err != nil {
-			serv.Errorf("CreateStation: Station " + body.Name + ": " + err.Error())
-			c.AbortWithStatusJSON(500, gin.H{"message": "Server Error"})
-			return
-		}
-		if !exist {
-			errMsg := "Schema " + schemaName + " does not exist"
-			serv.Warnf("CreateStation: Station " + body.Name + ": " + errMsg)
-			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg})
-			return
-		}
+	return nil
+}
 
-		schemaVersion, err := getActiveVersionBySchemaId(schema.ID)
-		if err != nil {
-			serv.Errorf("CreateStation: Station " + body.Name + ": " + err.Error())
-			c.AbortWithStatusJSON(500, gin.H{"message": err.Error()})
-			return
-		}
+func stationDeletionGracePeriod() time.Duration {
+	return time.Duration(configuration.STATION_DELETION_GRACE_PERIOD_HOURS) * time.Hour
+}
 
-		schemaDetailsResponse = models.StationOverviewSchemaDetails{SchemaName: schemaName, VersionNumber: schemaVersion.VersionNumber, UpdatesAvailable: true}
-		schemaDetails = models.SchemaDetails{SchemaName: schemaName, VersionNumber: schemaVersion.VersionNumber}
+// destroyDeletedStations destroys the retained resources of the removed stations matching the filter,
+// the caller must hold the creation lock of the station names involved
+func destroyDeletedStations(s *Server, filter bson.M) error {
+	filter["is_deleted"] = true
+	filter["resources_removed"] = bson.M{"$ne": true}
+	cursor, err := stationsCollection.Find(context.TODO(), filter)
+	if err != nil {
+		return err
+	}
+	var stations []models.Station
+	if err = cursor.All(context.TODO(), &stations); err != nil {
+		return err
 	}
 
-	var retentionType string
-	if body.RetentionType != "" && body.RetentionValue > 0 {
-		retentionType = strings.ToLower(body.RetentionType)
-		err = validateRetentionType(retentionType)
+	for _, station := range stations {
+		err = destroyStationResources(s, station, nil)
 		if err != nil {
-			serv.Warnf("CreateStation: Station " + body.Name + ": " + err.Error())
-			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error()})
-			return
+			return err
 		}
-	} else {
-		retentionType = "message_age_sec"
-		body.RetentionValue = 604800 // 1 week
-	}
-
-	if body.StorageType != "" {
-		body.StorageType = strings.ToLower(body.StorageType)
-		err = validateStorageType(body.StorageType)
+		_, err = stationsCollection.UpdateOne(context.TODO(),
+			bson.M{"_id": station.ID},
+			bson.M{"$set": bson.M{"resources_removed": true}},
+		)
 		if err != nil {
-			serv.Warnf("CreateStation: Station " + body.Name + ": " + err.Error())
-			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error()})
-			return
+			return err
 		}
-	} else {
-		body.StorageType = "file"
 	}
+	return nil
+}
 
-	storageTypeForResponse := "disk"
-	if body.StorageType == "memory" {
-		storageTypeForResponse = body.StorageType
-	}
+// purgeDeletedStation destroys the retained streams of a removed station before its name is reused,
+// otherwise the new station would inherit them and the sweeper would later destroy them
+func (s *Server) purgeDeletedStation(sn StationName) error {
+	return destroyDeletedStations(s, bson.M{"name": sn.Ext()})
+}
 
-	if body.Replicas > 0 {
-		err = validateReplicas(body.Replicas)
-		if err != nil {
-			serv.Warnf("CreateStation: Station " + body.Name + ": " + err.Error())
-			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error()})
-			return
-		}
-	} else {
-		body.Replicas = 1
+func (s *Server) createStationDirect(c *client, reply string, msg []byte) {
+	var csr createStationRequest
+	if err := json.Unmarshal(msg, &csr); err != nil {
+		s.Errorf("createStationDirect: failed creating station: %v", err.Error())
+		respondWithErr(s, reply, err)
+		return
 	}
+	s.createStationDirectIntern(c, reply, &csr, nil)
+}
 
-	if body.IdempotencyWindow <= 0 {
-		body.IdempotencyWindow = 120000 // default
-	} else if body.IdempotencyWindow < 100 {
-		body.IdempotencyWindow = 100 // minimum is 100 millis
-	}
+func (s *Server) createStationDirectIntern(c *client,
+	reply string,
+	csr *createStationRequest,
+	nonNativeCreateStreamFunc func() error) {
+	isNative := nonNativeCreateStreamFunc == nil
+	jsApiResp := JSApiStreamCreateResponse{ApiResponse: ApiResponse{Type: JSApiStreamCreateResponseType}}
 
-	newStation := models.Station{
-		ID:                primitive.NewObjectID(),
-		Name:              stationName.Ext(),
-		RetentionType:     retentionType,
-		RetentionValue:    body.RetentionValue,
-		StorageType:       body.StorageType,
-		Replicas:          body.Replicas,
-		DedupEnabled:      body.DedupEnabled,    // TODO deprecated
-		DedupWindowInMs:   body.DedupWindowInMs, // TODO deprecated
-		CreatedByUser:     user.Username,
-		CreationDate:      time.Now(),
-		LastUpdate:        time.Now(),
-		Functions:         []models.Function{},
-		IsDeleted:         false,
-		Schema:            schemaDetails,
-		IdempotencyWindow: body.IdempotencyWindow,
-		DlsConfiguration:  body.DlsConfiguration,
-		IsNative:          true,
+	stationName, err := StationNameFromStr(csr.StationName)
+	if err != nil {
+		serv.Warnf("createStationDirect: Station " + csr.StationName + ": " + err.Error())
+		jsApiResp.Error = NewJSStreamCreateError(err)
+		respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
+		return
 	}
 
-	err = sh.S.CreateStream(stationName, newStation)
+	unlock, err := s.lockStationCreation(stationName)
 	if err != nil {
-		serv.Errorf("CreateStation: Station " + body.Name + ": " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		serv.Errorf("createStationDirect: Station " + csr.StationName + ": " + err.Error())
+		jsApiResp.Error = NewJSStreamCreateError(err)
+		respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
 		return
 	}
+	defer unlock()
 
-	err = sh.S.CreateDlsStream(stationName, newStation)
+	deletionInProgress, err := s.isStationDeletionInProgress(stationName)
 	if err != nil {
-		serv.Errorf("CreateStation: Create DLS at station " + body.Name + ": " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		serv.Errorf("createStationDirect: Station " + csr.StationName + ": " + err.Error())
+		jsApiResp.Error = NewJSStreamCreateError(err)
+		respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
 		return
 	}
-
-	var emptySchemaDetailsResponse struct{}
-	var update bson.M
-	filter := bson.M{"name": newStation.Name, "is_deleted": false}
-	if schemaName != "" {
-		update = bson.M{
-			"$setOnInsert": bson.M{
-				"_id":                      newStation.ID,
-				"retention_type":           newStation.RetentionType,
-				"retention_value":          newStation.RetentionValue,
-				"storage_type":             newStation.StorageType,
-				"replicas":                 newStation.Replicas,
-				"dedup_enabled":            newStation.DedupEnabled,    // TODO deprecated
-				"dedup_window_in_ms":       newStation.DedupWindowInMs, // TODO deprecated
-				"created_by_user":          newStation.CreatedByUser,
-				"creation_date":            newStation.CreationDate,
-				"last_update":              newStation.LastUpdate,
-				"functions":                newStation.Functions,
-				"schema":                   newStation.Schema,
-				"idempotency_window_in_ms": newStation.IdempotencyWindow,
-				"dls_configuration":        newStation.DlsConfiguration,
-				"is_native":                newStation.IsNative,
-			},
-		}
-	} else {
-		update = bson.M{
-			"$setOnInsert": bson.M{
-				"_id":                      newStation.ID,
-				"retention_type":           newStation.RetentionType,
-				"retention_value":          newStation.RetentionValue,
-				"storage_type":             newStation.StorageType,
-				"replicas":                 newStation.Replicas,
-				"dedup_enabled":            newStation.DedupEnabled,    // TODO deprecated
-				"dedup_window_in_ms":       newStation.DedupWindowInMs, // TODO deprecated
-				"created_by_user":          newStation.CreatedByUser,
-				"creation_date":            newStation.CreationDate,
-				"last_update":              newStation.LastUpdate,
-				"functions":                newStation.Functions,
-				"schema":                   emptySchemaDetailsResponse,
-				"idempotency_window_in_ms": newStation.IdempotencyWindow,
-				"dls_configuration":        newStation.DlsConfiguration,
-				"is_native":                newStation.IsNative,
-			},
-		}
+	if deletionInProgress {
+		err = ErrStationDeletionInProgress
+		serv.Warnf("createStationDirect: Station " + csr.StationName + ": " + err.Error())
+		jsApiResp.Error = NewJSStreamCreateError(err)
+		respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
+		return
 	}
-	opts := options.Update().SetUpsert(true)
-	updateResults, err := stationsCollection.UpdateOne(context.TODO(), filter, update, opts)
+
+	exist, _, err := IsStationExist(stationName)
 	if err != nil {
-		serv.Errorf("CreateStation: Station " + body.Name + ": " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		serv.Errorf("createStationDirect: Station " + csr.StationName + ": " + err.Error())
+		jsApiResp.Error = NewJSStreamCreateError(err)
+		respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
 		return
 	}
-	if updateResults.MatchedCount > 0 {
-		errMsg := "Station " + newStation.Name + " already exists"
-		serv.Warnf("CreateStation: " + errMsg)
-		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg})
+
+	if exist {
+		errMsg := "Station " + stationName.Ext() + " already exists"
+		serv.Warnf("createStationDirect: " + errMsg)
+		jsApiResp.Error = NewJSStreamNameExistError()
+		respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
 		return
 	}
 
-	if len(body.Tags) > 0 {
-		err = AddTagsToEntity(body.Tags, "station", newStation.ID)
+	newStation := models.Station{
+		ID:                 primitive.NewObjectID(),
+		Name:               stationName.Ext(),
+		CreatedByUser:      c.memphisInfo.username,
+		CreationDate:       time.Now(),
+		IsDeleted:          false,
+		RetentionType:      csr.RetentionType,
+		RetentionValue:     csr.RetentionValue,
+		StorageType:        csr.StorageType,
+		Replicas:           csr.Replicas,
+		DedupEnabled:       csr.DedupEnabled,      // TODO deprecated
+		DedupWindowInMs:    csr.DedupWindowMillis, // TODO deprecated
+		LastUpdate:         time.Now(),
+		Schema:             models.SchemaDetails{SchemaName: csr.SchemaName},
+		Functions:          []models.Function{},
+		IdempotencyWindow:  csr.IdempotencyWindow,
+		IsNative:           isNative,
+		PartitionKeyHeader: csr.PartitionKeyHeader,
+		MaxMsgSizeBytes:    csr.MaxMsgSizeBytes,
+		DeletionProtected:  csr.DeletionProtected,
+		SchemaEnforcement:  csr.SchemaEnforcement,
+		AllowedProducers:   csr.AllowedProducers,
+		AllowedConsumers:   csr.AllowedConsumers,
+		CentralDlsStation:  csr.CentralDlsStation,
+		MaxMsgDeliveries:   csr.MaxMsgDeliveries,
+		Subjects:           csr.Subjects,
+		MaxConsumerGroups:  csr.MaxConsumerGroups,
+		SchemaRequired:     csr.SchemaRequired,
+		CompactionKey:      csr.CompactionKey,
+		Description:        csr.Description,
+		Metadata:           csr.Metadata,
+		AllowNonNative:     csr.AllowNonNative,
+		Mirror:             csr.Mirror,
+	}
+	err = resolveNewStation(context.TODO(), stationName, &newStation, 0, csr.DlsConfiguration, csr.AdoptExisting)
+	if err != nil {
+		if errorCode(err) == ErrCodeServerError {
+			serv.Errorf("createStationDirect: Station " + csr.StationName + ": " + err.Error())
+		} else {
+			serv.Warnf("createStationDirect: Station " + csr.StationName + ": " + err.Error())
+		}
+		jsApiResp.Error = NewJSStreamCreateError(err)
+		respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
+		return
+	}
+
+	err = s.purgeDeletedStation(stationName)
+	if err != nil {
+		serv.Errorf("createStationDirect: Station " + csr.StationName + ": " + err.Error())
+		jsApiResp.Error = NewJSStreamCreateError(err)
+		respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
+		return
+	}
+
+	adopted := false
+	if isNative && csr.AdoptExisting {
+		streamInfo, err := s.memphisStreamInfo(stationName.Intern())
+		if err != nil && !IsNatsErr(err, JSStreamNotFoundErr) {
+			serv.Errorf("createStationDirect: Station " + csr.StationName + ": " + err.Error())
+			respondWithErr(s, reply, err)
+			return
+		}
+		if err == nil {
+			err = validateAdoptedStreamConfig(stationName, streamInfo.Config)
+			if err != nil {
+				serv.Warnf("createStationDirect: Station " + csr.StationName + ": " + err.Error())
+				respondWithErr(s, reply, err)
+				return
+			}
+			applyAdoptedStreamConfig(&newStation, streamInfo.Config)
+			adopted = true
+		}
+	}
+
+	if !adopted {
+		createStreamFunc := nonNativeCreateStreamFunc
+
+		if createStreamFunc == nil {
+			createStreamFunc = func() error {
+				return s.CreateStream(stationName, newStation)
+			}
+		}
+
+		err = createStreamFunc()
 		if err != nil {
-			serv.Errorf("CreateStation: : Station " + body.Name + " Failed adding tags: " + err.Error())
-			c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+			serv.Errorf("createStationDirect: Station " + csr.StationName + ": " + err.Error())
+			respondWithErr(s, reply, err)
 			return
 		}
 	}
 
-	message := "Station " + stationName.Ext() + " has been created by " + user.Username
+	// the streams created by this request are removed when a later step fails, an adopted stream existed before and is kept
+	rollbackStreams := func() {
+		var rollbackErr error
+		if adopted {
+			rollbackErr = rollbackDlsStream(s, stationName)
+		} else {
+			rollbackErr = rollbackStationStreams(s, stationName)
+		}
+		if rollbackErr != nil {
+			serv.Errorf("createStationDirect: Station " + csr.StationName + ": Failed removing the station streams: " + rollbackErr.Error())
+		}
+	}
+
+	err = s.CreateDlsStream(stationName, newStation)
+	if err != nil {
+		serv.Errorf("createStationDirect: Create DLS at station " + csr.StationName + ": " + err.Error())
+		rollbackStreams()
+		respondWithErr(s, reply, err)
+		return
+	}
+
+	_, err = stationsCollection.InsertOne(context.TODO(), newStation)
+	if err != nil {
+		serv.Errorf("createStationDirect: Station " + csr.StationName + ": " + err.Error())
+		rollbackStreams()
+		respondWithErr(s, reply, err)
+		return
+	}
+	message := "Station " + stationName.Ext() + " has been created by user " + c.memphisInfo.username
+	if adopted {
+		message = "Station " + stationName.Ext() + " has been created from an existing stream by user " + c.memphisInfo.username
+	}
 	serv.Noticef(message)
+
 	var auditLogs []interface{}
 	newAuditLog := models.AuditLog{
 		ID:            primitive.NewObjectID(),
 		StationName:   stationName.Ext(),
 		Message:       message,
-		CreatedByUser: user.Username,
+		CreatedByUser: c.memphisInfo.username,
 		CreationDate:  time.Now(),
-		UserType:      user.UserType,
+		UserType:      "application",
 	}
 	auditLogs = append(auditLogs, newAuditLog)
 	err = CreateAuditLogs(auditLogs)
 	if err != nil {
-		serv.Errorf("CreateStation: Station " + body.Name + ": " + err.Error())
+		serv.Errorf("createStationDirect: Station " + csr.StationName + " - create audit logs error: " + err.Error())
 	}
 
-	shouldSendAnalytics, _ := shouldSendAnalytics()
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategoryStation)
 	if shouldSendAnalytics {
 		param := analytics.EventParam{
 			Name:  "station-name",
 			Value: stationName.Ext(),
 		}
 		analyticsParams := []analytics.EventParam{param}
-		analytics.SendEventWithParams(user.Username, analyticsParams, "user-create-station")
+		analytics.SendEventWithParams(c.memphisInfo.username, analyticsParams, "user-create-station")
 	}
 
-	if schemaName != "" {
-		c.IndentedJSON(200, gin.H{
-			"id":                       primitive.NewObjectID(),
-			"name":                     stationName.Ext(),
-			"retention_type":           retentionType,
-			"retention_value":          body.RetentionValue,
-			"storage_type":             storageTypeForResponse,
-			"replicas":                 body.Replicas,
-			"dedup_enabled":            body.DedupEnabled,    // TODO deprecated
-			"dedup_window_in_ms":       body.DedupWindowInMs, // TODO deprecated
-			"created_by_user":          user.Username,
-			"creation_date":            time.Now(),
-			"last_update":              time.Now(),
-			"functions":                []models.Function{},
-			"is_deleted":               false,
-			"schema":                   schemaDetailsResponse,
-			"idempotency_window_in_ms": newStation.IdempotencyWindow,
-			"dls_configuration":        newStation.DlsConfiguration,
-		})
-	} else {
-		c.IndentedJSON(200, gin.H{
-			"id":                       primitive.NewObjectID(),
-			"name":                     stationName.Ext(),
-			"retention_type":           retentionType,
-			"retention_value":          body.RetentionValue,
-			"storage_type":             storageTypeForResponse,
-			"replicas":                 body.Replicas,
-			"dedup_enabled":            body.DedupEnabled,    // TODO deprecated
-			"dedup_window_in_ms":       body.DedupWindowInMs, // TODO deprecated
-			"created_by_user":          user.Username,
-			"creation_date":            time.Now(),
-			"last_update":              time.Now(),
-			"functions":                []models.Function{},
-			"is_deleted":               false,
-			"schema":                   emptySchemaDetailsResponse,
-			"idempotency_window_in_ms": newStation.IdempotencyWindow,
-			"dls_configuration":        newStation.DlsConfiguration,
-		})
+	respondWithErr(s, reply, nil)
+}
+
+// getStationResponse returns the station as presented by GetStation, with its tags and retention descriptor
+func (sh StationsHandler) getStationResponse(ctx context.Context, stationName string) (bool, models.GetStationResponseSchema, error) {
+	tagsHandler := TagsHandler{S: sh.S}
+
+	var station models.GetStationResponseSchema
+	err := stationsCollection.FindOne(ctx, bson.M{
+		"name": stationName,
+		"$or": []interface{}{
+			bson.M{"is_deleted": false},
+			bson.M{"is_deleted": bson.M{"$exists": false}},
+		},
+	}).Decode(&station)
+	if err == mongo.ErrNoDocuments {
+		return false, station, nil
+	} else if err != nil {
+		return false, station, err
+	}
+	tags, err := tagsHandler.GetTagsByStation(station.ID)
+	if err != nil {
+		return true, station, err
+	}
+	station.Tags = tags
+	station.RetentionDescriptor = getRetentionDescriptor(station.RetentionType, station.RetentionValue)
+	station.DlsConfiguration = redactDlsConfiguration(station.DlsConfiguration)
+	if station.StorageType == "file" {
+		station.StorageType = "disk"
 	}
+	return true, station, nil
 }
 
-func (sh StationsHandler) RemoveStation(c *gin.Context) {
-	if err := DenyForSandboxEnv(c); err != nil {
+// StationExists is a cheap existence check for clients polling before creating a station, it skips the decoding and tags lookup of GetStation
+func (sh StationsHandler) StationExists(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.StationExistsSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
 		return
 	}
-	var body models.RemoveStationSchema
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("StationExists: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, err := isStationNameTaken(ctx, stationName)
+	if err != nil {
+		serv.Errorf("StationExists: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	c.IndentedJSON(200, gin.H{"exists": exist})
+}
+
+func (sh StationsHandler) GetStation(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetStationSchema
 	ok := utils.Validate(c, &body, false, nil)
 	if !ok {
 		return
 	}
+	body.MessagesOrder = strings.ToLower(body.MessagesOrder)
+	if err := validateMessagesOrder(body.MessagesOrder); err != nil {
+		serv.Warnf("GetStation: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": ErrCodeInvalidRequest})
+		return
+	}
 
-	var stationNames []string
-	for _, name := range body.StationNames {
-		stationName, err := StationNameFromStr(name)
+	exist, station, err := sh.getStationResponse(ctx, body.StationName)
+	if err != nil {
+		serv.Errorf("GetStation: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("GetStation: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	if body.IncludeRecentMessages > 0 {
+		messagesToFetch := body.IncludeRecentMessages
+		if messagesToFetch > maxRecentMessagesInStation {
+			messagesToFetch = maxRecentMessagesInStation
+		}
+		// the messages subject depends on the full station config, e.g. keyed stations store each key on its own subject
+		stationName, err := StationNameFromStr(station.Name)
 		if err != nil {
-			serv.Warnf("RemoveStation: Station " + name + ": " + err.Error())
-			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error()})
+			serv.Errorf("GetStation: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
 			return
 		}
-
-		stationNames = append(stationNames, stationName.Ext())
-
-		exist, station, err := IsStationExist(stationName)
+		exist, fullStation, err := IsStationExistWithContext(ctx, stationName)
 		if err != nil {
-			serv.Errorf("RemoveStation: Station " + stationName.external + ": " + err.Error())
-			c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+			serv.Errorf("GetStation: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
 			return
 		}
 		if !exist {
-			errMsg := "Station " + name + " does not exist"
-			serv.Warnf("RemoveStation: " + errMsg)
-			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg})
+			errMsg := "Station " + body.StationName + " does not exist"
+			serv.Warnf("GetStation: " + errMsg)
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
 			return
 		}
-
-		err = removeStationResources(sh.S, station, nil)
+		messages, err := sh.GetMessages(fullStation, messagesToFetch, body.MessagesOrder)
 		if err != nil {
-			serv.Errorf("RemoveStation: Station " + stationName.external + ": " + err.Error())
-			c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+			serv.Errorf("GetStation: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
 			return
 		}
+		station.RecentMessages = messages
 	}
 
-	_, err := stationsCollection.UpdateMany(context.TODO(),
-		bson.M{
-			"name": bson.M{"$in": stationNames},
-			"$or": []interface{}{
-				bson.M{"is_deleted": false},
-				bson.M{"is_deleted": bson.M{"$exists": false}},
-			},
-		},
-		bson.M{"$set": bson.M{"is_deleted": true}},
-	)
-	if err != nil {
-		serv.Errorf("RemoveStation: " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+	c.IndentedJSON(200, station)
+}
+
+func (sh StationsHandler) GetStationsByNames(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetStationsByNamesSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
 		return
 	}
-
-	shouldSendAnalytics, _ := shouldSendAnalytics()
-	if shouldSendAnalytics {
-		user, _ := getUserDetailsFromMiddleware(c)
-		analytics.SendEvent(user.Username, "user-remove-station")
+	if len(body.StationNames) > maxStationsByNames {
+		errMsg := "Up to " + strconv.Itoa(maxStationsByNames) + " stations can be fetched in a single request"
+		serv.Warnf("GetStationsByNames: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
 	}
 
+	stations := []models.GetStationResponseSchema{}
+	missing := []string{}
+	seen := make(map[string]bool)
 	for _, name := range body.StationNames {
 		stationName, err := StationNameFromStr(name)
 		if err != nil {
-			serv.Errorf("RemoveStation: Station " + name + ": " + err.Error())
-			c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+			// an invalid name can not belong to any station
+			serv.Warnf("GetStationsByNames: Station " + name + ": " + err.Error())
+			missing = append(missing, name)
+			continue
 		}
-
-		user, err := getUserDetailsFromMiddleware(c)
-		if err != nil {
-			serv.Errorf("RemoveStation: Station " + name + ": " + err.Error())
-			c.AbortWithStatusJSON(401, gin.H{"message": "Unauthorized"})
+		if seen[stationName.Ext()] {
+			continue
 		}
+		seen[stationName.Ext()] = true
 
-		message := "Station " + stationName.Ext() + " has been deleted by user " + user.Username
-		serv.Noticef(message)
-
-		var auditLogs []interface{}
-		newAuditLog := models.AuditLog{
-			ID:            primitive.NewObjectID(),
-			StationName:   stationName.Ext(),
-			Message:       message,
-			CreatedByUser: user.Username,
-			CreationDate:  time.Now(),
-			UserType:      user.UserType,
-		}
-		auditLogs = append(auditLogs, newAuditLog)
-		err = CreateAuditLogs(auditLogs)
+		exist, station, err := sh.getStationResponse(ctx, stationName.Ext())
 		if err != nil {
-			serv.Warnf("RemoveStation: Station " + name + " - create audit logs error: " + err.Error())
+			serv.Errorf("GetStationsByNames: Station " + name + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+		if !exist {
+			missing = append(missing, name)
+			continue
 		}
+		stations = append(stations, station)
 	}
-	c.IndentedJSON(200, gin.H{})
+
+	c.IndentedJSON(200, gin.H{"stations": stations, "missing": missing})
 }
 
-func (s *Server) removeStationDirect(c *client, reply string, msg []byte) {
-	var dsr destroyStationRequest
-	if err := json.Unmarshal(msg, &dsr); err != nil {
-		s.Errorf("removeStationDirect: " + err.Error())
-		respondWithErr(s, reply, err)
+func (sh StationsHandler) GetStationByStreamName(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetStationByStreamNameSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
 		return
 	}
-	s.removeStationDirectIntern(c, reply, &dsr, nil)
-}
-
-func (s *Server) removeStationDirectIntern(c *client,
-	reply string,
-	dsr *destroyStationRequest,
-	nonNativeRemoveStreamFunc func() error) {
-	isNative := nonNativeRemoveStreamFunc == nil
-	jsApiResp := JSApiStreamDeleteResponse{ApiResponse: ApiResponse{Type: JSApiStreamDeleteResponseType}}
 
-	stationName, err := StationNameFromStr(dsr.StationName)
-	if err != nil {
-		serv.Warnf("removeStationDirect: Station " + dsr.StationName + ": " + err.Error())
-		jsApiResp.Error = NewJSStreamDeleteError(err)
-		respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
+	streamName := body.StreamName
+	if strings.HasPrefix(streamName, "$memphis-") && strings.HasSuffix(streamName, "-dls") { // a DLS stream maps to its station as well
+		streamName = strings.TrimSuffix(strings.TrimPrefix(streamName, "$memphis-"), "-dls")
+	} else if strings.HasPrefix(streamName, "$memphis") {
+		errMsg := "Stream " + body.StreamName + " is an internal stream which does not belong to a station"
+		serv.Warnf("GetStationByStreamName: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
 		return
 	}
+	stationName := StationNameFromStreamName(streamName)
 
-	exist, station, err := IsStationExist(stationName)
+	exist, station, err := sh.getStationResponse(ctx, stationName.Ext())
 	if err != nil {
-		serv.Errorf("removeStationDirect: Station " + dsr.StationName + ": " + err.Error())
-		jsApiResp.Error = NewJSStreamDeleteError(err)
-		respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
+		serv.Errorf("GetStationByStreamName: Stream " + body.StreamName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
 		return
 	}
 	if !exist {
-		errMsg := "Station " + station.Name + " does not exist"
-		serv.Warnf("removeStationDirect: " + errMsg)
-		err := errors.New(errMsg)
-		jsApiResp.Error = NewJSStreamDeleteError(err)
-		respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
+		errMsg := "No station found for stream " + body.StreamName
+		serv.Warnf("GetStationByStreamName: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
 		return
 	}
 
-	err = removeStationResources(s, station, nonNativeRemoveStreamFunc)
+	c.IndentedJSON(200, station)
+}
+
+// GetStationsDetails lists the stations, storageType (file/disk/memory) narrows the list when set
+func (sh StationsHandler) GetStationsDetails(ctx context.Context, storageType string) ([]models.ExtendedStationDetails, error) {
+	var exStations []models.ExtendedStationDetails
+	var stations []models.Station
+
+	poisonMsgsHandler := PoisonMessagesHandler{S: sh.S}
+	filter := bson.M{"$or": []interface{}{
+		bson.M{"is_deleted": bson.M{"$exists": false}},
+		bson.M{"is_deleted": false},
+	}}
+	if storageType != "" {
+		normalized, err := normalizeStorageTypeFilter(storageType)
+		if err != nil {
+			return []models.ExtendedStationDetails{}, err
+		}
+		filter["storage_type"] = normalized
+	}
+	cursor, err := stationsCollection.Find(ctx, filter)
 	if err != nil {
-		serv.Errorf("RemoveStation: Station " + dsr.StationName + ": " + err.Error())
-		respondWithErr(s, reply, err)
-		return
+		return []models.ExtendedStationDetails{}, err
 	}
 
-	_, err = stationsCollection.UpdateOne(context.TODO(),
-		bson.M{
-			"name": stationName.Ext(),
-			"$or": []interface{}{
+	if err = cursor.All(ctx, &stations); err != nil {
+		return []models.ExtendedStationDetails{}, err
+	}
+
+	if len(stations) == 0 {
+		return []models.ExtendedStationDetails{}, nil
+	} else {
+		tagsHandler := TagsHandler{S: sh.S}
+		for _, station := range stations {
+			totalMessages, err := sh.GetTotalMessages(station.Name)
+			if err != nil {
+				if IsNatsErr(err, JSStreamNotFoundErr) {
+					continue
+				} else {
+					return []models.ExtendedStationDetails{}, err
+				}
+			}
+			totalBytes, err := sh.GetTotalBytes(station.Name)
+			if err != nil {
+				if IsNatsErr(err, JSStreamNotFoundErr) {
+					continue
+				} else {
+					return []models.ExtendedStationDetails{}, err
+				}
+			}
+			poisonMessages, err := poisonMsgsHandler.GetTotalPoisonMsgsByStation(station.Name)
+			if err != nil {
+				if IsNatsErr(err, JSStreamNotFoundErr) {
+					continue
+				} else {
+					return []models.ExtendedStationDetails{}, err
+				}
+			}
+			lastProducedAt, lastConsumedAt, err := sh.GetStationActivity(station.Name)
+			if err != nil {
+				if IsNatsErr(err, JSStreamNotFoundErr) {
+					continue
+				} else {
+					return []models.ExtendedStationDetails{}, err
+				}
+			}
+			tags, err := tagsHandler.GetTagsByStation(station.ID)
+			if err != nil {
+				return []models.ExtendedStationDetails{}, err
+			}
+			if station.StorageType == "file" {
+				station.StorageType = "disk"
+			}
+			station.DlsConfiguration = redactDlsConfiguration(station.DlsConfiguration)
+			exStations = append(exStations, models.ExtendedStationDetails{Station: station, PoisonMessages: poisonMessages, TotalMessages: totalMessages, TotalBytes: totalBytes, Tags: tags, LastProducedAt: lastProducedAt, LastConsumedAt: lastConsumedAt})
+		}
+		if exStations == nil {
+			return []models.ExtendedStationDetails{}, nil
+		}
+		return exStations, nil
+	}
+}
+
+// stationsEnrichment selects the per station stats that are costly to compute,
+// messages and bytes are always computed since they come from the same stream info
+type stationsEnrichment struct {
+	PoisonMessages bool
+	Activity       bool
+	Tags           bool
+}
+
+var fullStationsEnrichment = stationsEnrichment{PoisonMessages: true, Activity: true, Tags: true}
+
+func (sh StationsHandler) GetAllStationsDetails(ctx context.Context) ([]models.ExtendedStation, error) {
+	return sh.getAllStationsDetails(ctx, fullStationsEnrichment)
+}
+
+func (sh StationsHandler) getAllStationsDetails(ctx context.Context, enrichment stationsEnrichment) ([]models.ExtendedStation, error) {
+	var stations []models.ExtendedStation
+	cursor, err := stationsCollection.Aggregate(ctx, mongo.Pipeline{
+		bson.D{{"$match", bson.D{{"$or", []interface{}{
+			bson.D{{"is_deleted", false}},
+			bson.D{{"is_deleted", bson.D{{"$exists", false}}}},
+		}}}}},
+		bson.D{{"$project", bson.D{{"_id", 1}, {"name", 1}, {"retention_type", 1}, {"retention_value", 1}, {"storage_type", 1}, {"replicas", 1}, {"idempotency_window_in_ms", 1}, {"created_by_user", 1}, {"creation_date", 1}, {"last_update", 1}, {"functions", 1}, {"dls_configuration", 1}, {"partition_key_header", 1}, {"max_msg_size_bytes", 1}, {"deletion_protected", 1}, {"schema_enforcement", 1}, {"is_paused", 1}, {"read_only", 1}, {"allowed_producers", 1}, {"allowed_consumers", 1}, {"central_dls_station", 1}, {"max_msg_deliveries", 1}, {"subjects", 1}, {"max_consumer_groups", 1}, {"schema_required", 1}, {"compaction_key_header", 1}, {"description", 1}, {"metadata", 1}, {"pending_schema", 1}, {"retention_pin", 1}, {"allow_non_native", 1}, {"mirror", 1}}}},
+	})
+	if err != nil {
+		return stations, err
+	}
+
+	if err = cursor.All(ctx, &stations); err != nil {
+		return stations, err
+	}
+
+	if len(stations) == 0 {
+		return []models.ExtendedStation{}, nil
+	} else {
+		poisonMsgsHandler := PoisonMessagesHandler{S: sh.S}
+		tagsHandler := TagsHandler{S: sh.S}
+		var extStations []models.ExtendedStation
+		for i := 0; i < len(stations); i++ {
+			totalMessages, err := sh.GetTotalMessages(stations[i].Name)
+			if err != nil {
+				if IsNatsErr(err, JSStreamNotFoundErr) {
+					continue
+				} else {
+					return []models.ExtendedStation{}, err
+				}
+			}
+			totalBytes, err := sh.GetTotalBytes(stations[i].Name)
+			if err != nil {
+				if IsNatsErr(err, JSStreamNotFoundErr) {
+					continue
+				} else {
+					return []models.ExtendedStation{}, err
+				}
+			}
+			if enrichment.PoisonMessages {
+				poisonMessages, err := poisonMsgsHandler.GetTotalPoisonMsgsByStation(stations[i].Name)
+				if err != nil {
+					if IsNatsErr(err, JSStreamNotFoundErr) {
+						continue
+					} else {
+						return []models.ExtendedStation{}, err
+					}
+				}
+				stations[i].PoisonMessages = poisonMessages
+			}
+			if enrichment.Activity {
+				lastProducedAt, lastConsumedAt, err := sh.GetStationActivity(stations[i].Name)
+				if err != nil {
+					if IsNatsErr(err, JSStreamNotFoundErr) {
+						continue
+					} else {
+						return []models.ExtendedStation{}, err
+					}
+				}
+				stations[i].LastProducedAt = lastProducedAt
+				stations[i].LastConsumedAt = lastConsumedAt
+			}
+			if enrichment.Tags {
+				tags, err := tagsHandler.GetTagsByStation(stations[i].ID)
+				if err != nil {
+					return []models.ExtendedStation{}, err
+				}
+				stations[i].Tags = tags
+			}
+
+			stations[i].TotalMessages = totalMessages
+			stations[i].TotalBytes = totalBytes
+			stations[i].DlsConfiguration = redactDlsConfiguration(stations[i].DlsConfiguration)
+			extStations = append(extStations, stations[i])
+		}
+		return extStations, nil
+	}
+}
+
+func (sh StationsHandler) GetStations(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetStationsSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	if body.StorageType != "" {
+		if _, err := normalizeStorageTypeFilter(body.StorageType); err != nil {
+			serv.Warnf("GetStations: " + err.Error())
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+			return
+		}
+	}
+
+	stations, err := sh.GetStationsDetails(ctx, body.StorageType)
+	if err != nil {
+		serv.Errorf("GetStations: " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	c.IndentedJSON(200, gin.H{
+		"stations": stations,
+	})
+}
+
+// GetSchemalessStations lists the stations with no schema attached, a missing or empty schema object
+func (sh StationsHandler) GetSchemalessStations(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	filter := bson.M{
+		"schema.schema_name": bson.M{"$in": []interface{}{nil, ""}},
+		"$or": []interface{}{
+			bson.M{"is_deleted": false},
+			bson.M{"is_deleted": bson.M{"$exists": false}},
+		},
+	}
+	cursor, err := stationsCollection.Find(ctx, filter, options.Find().SetSort(bson.M{"name": 1}))
+	if err != nil {
+		serv.Errorf("GetSchemalessStations: " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	stations := []models.Station{}
+	if err = cursor.All(ctx, &stations); err != nil {
+		serv.Errorf("GetSchemalessStations: " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	c.IndentedJSON(200, gin.H{
+		"stations": stations,
+	})
+}
+
+// parseStationsFields maps the requested fields to the station's json keys,
+// the id and name are always returned so the stations can be told apart
+func parseStationsFields(requested []string) (map[string]bool, error) {
+	fields := make(map[string]bool)
+	for _, field := range requested {
+		for _, f := range strings.Split(field, ",") {
+			f = strings.ToLower(strings.TrimSpace(f))
+			if f == "" {
+				continue
+			}
+			if f == "poison_messages" {
+				f = "posion_messages"
+			}
+			fields[f] = true
+		}
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	known := make(map[string]bool)
+	stationType := reflect.TypeOf(models.ExtendedStation{})
+	for i := 0; i < stationType.NumField(); i++ {
+		known[strings.Split(stationType.Field(i).Tag.Get("json"), ",")[0]] = true
+	}
+	for f := range fields {
+		if !known[f] {
+			return nil, errors.New("unknown station field " + f)
+		}
+	}
+	fields["id"] = true
+	fields["name"] = true
+	return fields, nil
+}
+
+func sortStations(stations []models.ExtendedStation, sortBy string, desc bool) {
+	less := func(i, j int) bool {
+		switch sortBy {
+		case "total_messages":
+			return stations[i].TotalMessages < stations[j].TotalMessages
+		case "poison_messages":
+			return stations[i].PoisonMessages < stations[j].PoisonMessages
+		case "creation_date":
+			return stations[i].CreationDate.Before(stations[j].CreationDate)
+		default:
+			return stations[i].Name < stations[j].Name
+		}
+	}
+	sort.SliceStable(stations, func(i, j int) bool {
+		if desc {
+			return less(j, i)
+		}
+		return less(i, j)
+	})
+}
+
+func (sh StationsHandler) GetAllStations(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetAllStationsSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	sortBy := strings.ToLower(body.SortBy)
+	if sortBy != "" && sortBy != "name" && sortBy != "total_messages" && sortBy != "poison_messages" && sortBy != "creation_date" {
+		errMsg := "sort_by can be one of the following name/total_messages/poison_messages/creation_date"
+		serv.Warnf("GetAllStations: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+	order := strings.ToLower(body.Order)
+	if order != "" && order != "asc" && order != "desc" {
+		errMsg := "order can be one of the following asc/desc"
+		serv.Warnf("GetAllStations: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+	fields, err := parseStationsFields(body.Fields)
+	if err != nil {
+		serv.Warnf("GetAllStations: " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	enrichment := fullStationsEnrichment
+	if fields != nil {
+		enrichment = stationsEnrichment{
+			PoisonMessages: fields["posion_messages"] || sortBy == "poison_messages",
+			Activity:       fields["last_produced_at"] || fields["last_consumed_at"],
+			Tags:           fields["tags"],
+		}
+	}
+	stations, err := sh.getAllStationsDetails(ctx, enrichment)
+	if err != nil {
+		serv.Errorf("GetAllStations: " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if sortBy != "" {
+		sortStations(stations, sortBy, order == "desc")
+	}
+
+	response := make([]interface{}, 0, len(stations))
+	for _, station := range stations {
+		if fields == nil {
+			response = append(response, station)
+			continue
+		}
+		stationJson, err := json.Marshal(station)
+		if err != nil {
+			serv.Errorf("GetAllStations: " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+		var stationFields map[string]interface{}
+		if err = json.Unmarshal(stationJson, &stationFields); err != nil {
+			serv.Errorf("GetAllStations: " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+		for key := range stationFields {
+			if !fields[key] {
+				delete(stationFields, key)
+			}
+		}
+		response = append(response, stationFields)
+	}
+
+	acceptsGzip := strings.Contains(c.GetHeader("Accept-Encoding"), "gzip")
+	if len(response) <= allStationsCompactThreshold && !acceptsGzip {
+		c.IndentedJSON(200, response)
+		return
+	}
+
+	err = writeStationsStream(c, response, acceptsGzip)
+	if err != nil {
+		serv.Errorf("GetAllStations: " + err.Error())
+	}
+}
+
+// writeStationsStream writes the stations as a compact JSON array one element at a time,
+// gzipped when the client supports it
+func writeStationsStream(c *gin.Context, stations []interface{}, useGzip bool) error {
+	c.Header("Content-Type", "application/json; charset=utf-8")
+	c.Header("Vary", "Accept-Encoding")
+	var w io.Writer = c.Writer
+	if useGzip {
+		c.Header("Content-Encoding", "gzip")
+		gw := gzip.NewWriter(c.Writer)
+		defer gw.Close()
+		w = gw
+	}
+	c.Status(200)
+
+	if _, err := w.Write([]byte("[")); err != nil {
+		return err
+	}
+	for i, station := range stations {
+		if i > 0 {
+			if _, err := w.Write([]byte(",")); err != nil {
+				return err
+			}
+		}
+		stationJson, err := json.Marshal(station)
+		if err != nil {
+			return err
+		}
+		if _, err = w.Write(stationJson); err != nil {
+			return err
+		}
+	}
+	_, err := w.Write([]byte("]"))
+	return err
+}
+
+// validateStationConfig runs the validations CreateStation applies and collects all of the field errors
+// instead of stopping at the first one, the returned error is set only when a validation could not be performed
+func validateStationConfig(ctx context.Context, body models.CreateStationSchema) ([]models.StationFieldError, error) {
+	fieldErrors := []models.StationFieldError{}
+	addFieldError := func(field string, err error) {
+		if err != nil {
+			fieldErrors = append(fieldErrors, models.StationFieldError{Field: field, Message: err.Error()})
+		}
+	}
+
+	stationName, err := StationNameFromStr(body.Name)
+	addFieldError("name", err)
+	if err == nil {
+		exist, _, err := IsStationExistWithContext(ctx, stationName)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			addFieldError("name", errors.New("Station "+stationName.Ext()+" already exists"))
+		}
+	}
+
+	if body.SchemaName != "" {
+		exist, schema, err := IsSchemaExist(strings.ToLower(body.SchemaName))
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			addFieldError("schema_name", schemaNotFoundError(strings.ToLower(body.SchemaName)))
+		} else if body.SchemaVersion > 0 {
+			_, err = getStationSchemaVersion(schema, body.SchemaVersion)
+			if err != nil && errorCode(err) != ErrCodeSchemaMissing {
+				return nil, err
+			}
+			addFieldError("schema_version", err)
+		}
+	}
+
+	if body.RetentionType != "" {
+		err = validateRetentionType(strings.ToLower(body.RetentionType))
+		addFieldError("retention_type", err)
+		if err == nil {
+			addFieldError("retention_value", validateRetentionValue(strings.ToLower(body.RetentionType), body.RetentionValue))
+		}
+	} else if body.RetentionValue < 0 {
+		addFieldError("retention_value", errors.New("retention value can not be negative"))
+	}
+	if body.StorageType != "" {
+		addFieldError("storage_type", validateStorageType(strings.ToLower(body.StorageType)))
+	}
+	if body.Replicas > 0 {
+		addFieldError("replicas", validateReplicas(body.Replicas))
+	}
+	if body.IdempotencyWindow < 0 {
+		addFieldError("idempotency_window_in_ms", errors.New("idempotency window can not be negative"))
+	} else if body.IdempotencyWindow > 0 {
+		addFieldError("idempotency_window_in_ms", validateIdempotencyWindow(strings.ToLower(body.RetentionType), body.RetentionValue, body.IdempotencyWindow))
+	}
+	if body.SchemaEnforcement != "" {
+		addFieldError("schema_enforcement", validateSchemaEnforcement(strings.ToLower(body.SchemaEnforcement)))
+	}
+	if body.MaxMsgSizeBytes != 0 {
+		addFieldError("max_msg_size_bytes", validateMaxMsgSize(body.MaxMsgSizeBytes))
+	}
+	_, err = normalizeClientAllowlist(body.AllowedProducers, validateProducerName)
+	addFieldError("allowed_producers", err)
+	_, err = normalizeClientAllowlist(body.AllowedConsumers, validateConsumerName)
+	addFieldError("allowed_consumers", err)
+	if body.DlsConfiguration != nil {
+		addFieldError("dls_configuration", validateDlsWebhook(body.DlsConfiguration.Webhook))
+	}
+	addFieldError("max_msg_deliveries", validateMaxMsgDeliveries(body.MaxMsgDeliveries))
+	addFieldError("max_consumer_groups", validateMaxConsumerGroups(body.MaxConsumerGroups))
+	_, err = normalizeStationMetadata(body.Description, body.Metadata)
+	addFieldError("metadata", err)
+	addFieldError("compaction_key_header", validateCompactionKey(strings.ToLower(body.RetentionType), body.CompactionKey, body.Subjects))
+	_, err = normalizePartitionKeyHeader(body.PartitionKeyHeader)
+	addFieldError("partition_key_header", err)
+
+	// these depend on a valid station name
+	if stationName.Intern() != "" {
+		_, err = normalizeCentralDlsStation(stationName, body.CentralDlsStation)
+		addFieldError("central_dls_station", err)
+		subjects, err := validateStationSubjects(stationName, body.Subjects)
+		addFieldError("subjects", err)
+		if err == nil {
+			overlap, err := findStationSubjectsOverlap(ctx, stationName, subjects)
+			if err != nil {
+				return nil, err
+			}
+			if overlap != "" {
+				addFieldError("subjects", errors.New(overlap))
+			}
+		}
+		requested := models.Station{
+			Mirror:         body.Mirror,
+			Subjects:       body.Subjects,
+			RetentionType:  strings.ToLower(body.RetentionType),
+			SchemaRequired: body.SchemaRequired,
+			AllowNonNative: body.AllowNonNative,
+		}
+		_, err = normalizeStationMirror(stationName, requested, false)
+		addFieldError("mirror", err)
+	}
+
+	return fieldErrors, nil
+}
+
+// ValidateStationConfig validates a station creation request without creating the station
+func (sh StationsHandler) ValidateStationConfig(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.CreateStationSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	fieldErrors, err := validateStationConfig(ctx, body)
+	if err != nil {
+		serv.Errorf("ValidateStationConfig: Station " + body.Name + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	c.IndentedJSON(200, gin.H{
+		"valid":  len(fieldErrors) == 0,
+		"errors": fieldErrors,
+	})
+}
+
+// stationCreationServerError logs an internal error of the station creation and hides it from the client
+// resolveNewStation validates the configuration a new station is requested with and fills in the defaults of what the request left out,
+// it is shared by the http and the sdk create paths. failures that are not caused by the request carry ErrCodeServerError
+func resolveNewStation(ctx context.Context, stationName StationName, station *models.Station, schemaVersionNumber int, dlsConfiguration *models.DlsConfiguration, adoptExisting bool) error {
+	if station.Schema.SchemaName != "" {
+		schemaName := strings.ToLower(station.Schema.SchemaName)
+		exist, schema, err := IsSchemaExist(schemaName)
+		if err != nil {
+			return withErrorCode(ErrCodeServerError, err)
+		}
+		if !exist {
+			return schemaNotFoundError(schemaName)
+		}
+		schemaVersion, err := getStationSchemaVersion(schema, schemaVersionNumber)
+		if err != nil {
+			if errorCode(err) == ErrCodeSchemaMissing {
+				return err
+			}
+			return withErrorCode(ErrCodeServerError, err)
+		}
+		station.Schema = models.SchemaDetails{SchemaName: schemaName, VersionNumber: schemaVersion.VersionNumber}
+	}
+
+	defaults := getStationDefaults()
+	if station.RetentionType != "" {
+		station.RetentionType = strings.ToLower(station.RetentionType)
+		err := validateRetentionType(station.RetentionType)
+		if err != nil {
+			return err
+		}
+		err = validateRetentionValue(station.RetentionType, station.RetentionValue)
+		if err != nil {
+			return err
+		}
+		if retentionIgnoresValue(station.RetentionType) {
+			station.RetentionValue = 0
+		}
+	} else {
+		station.RetentionType = defaults.RetentionType
+		station.RetentionValue = defaults.RetentionValue
+	}
+
+	if station.StorageType != "" {
+		station.StorageType = strings.ToLower(station.StorageType)
+		err := validateStorageType(station.StorageType)
+		if err != nil {
+			return err
+		}
+	} else {
+		station.StorageType = defaults.StorageType
+	}
+
+	if station.Replicas > 0 {
+		err := validateReplicas(station.Replicas)
+		if err != nil {
+			return err
+		}
+	} else {
+		station.Replicas = defaults.Replicas
+	}
+
+	if station.IdempotencyWindow <= 0 {
+		station.IdempotencyWindow = defaultIdempotencyWindow(station.RetentionType, station.RetentionValue, defaults.IdempotencyWindow)
+	} else if station.IdempotencyWindow < 100 {
+		station.IdempotencyWindow = 100 // minimum is 100 millis
+	}
+	err := validateIdempotencyWindow(station.RetentionType, station.RetentionValue, station.IdempotencyWindow)
+	if err != nil {
+		return err
+	}
+
+	if station.SchemaEnforcement != "" {
+		station.SchemaEnforcement = strings.ToLower(station.SchemaEnforcement)
+		err = validateSchemaEnforcement(station.SchemaEnforcement)
+		if err != nil {
+			return err
+		}
+	} else {
+		station.SchemaEnforcement = "dls"
+	}
+
+	if station.MaxMsgSizeBytes != 0 {
+		err = validateMaxMsgSize(station.MaxMsgSizeBytes)
+		if err != nil {
+			return err
+		}
+	} else {
+		station.MaxMsgSizeBytes = configuration.MAX_MESSAGE_SIZE_MB * 1024 * 1024
+	}
+
+	station.AllowedProducers, err = normalizeClientAllowlist(station.AllowedProducers, validateProducerName)
+	if err != nil {
+		return err
+	}
+	station.AllowedConsumers, err = normalizeClientAllowlist(station.AllowedConsumers, validateConsumerName)
+	if err != nil {
+		return err
+	}
+	station.CentralDlsStation, err = normalizeCentralDlsStation(stationName, station.CentralDlsStation)
+	if err != nil {
+		return err
+	}
+	station.DlsConfiguration = resolveDlsConfiguration(dlsConfiguration, defaults)
+	err = validateDlsWebhook(station.DlsConfiguration.Webhook)
+	if err != nil {
+		return err
+	}
+	err = validateMaxMsgDeliveries(station.MaxMsgDeliveries)
+	if err != nil {
+		return err
+	}
+	station.Subjects, err = validateStationSubjects(stationName, station.Subjects)
+	if err != nil {
+		return err
+	}
+	overlap, err := findStationSubjectsOverlap(ctx, stationName, station.Subjects)
+	if err != nil {
+		return withErrorCode(ErrCodeServerError, err)
+	}
+	if overlap != "" {
+		return errors.New(overlap)
+	}
+	err = validateCompactionKey(station.RetentionType, station.CompactionKey, station.Subjects)
+	if err != nil {
+		return err
+	}
+	station.PartitionKeyHeader, err = normalizePartitionKeyHeader(station.PartitionKeyHeader)
+	if err != nil {
+		return err
+	}
+	station.Metadata, err = normalizeStationMetadata(station.Description, station.Metadata)
+	if err != nil {
+		return err
+	}
+	err = validateMaxConsumerGroups(station.MaxConsumerGroups)
+	if err != nil {
+		return err
+	}
+	if station.Mirror != "" && !station.IsNative {
+		return errors.New("a non native station can not mirror another station, its stream is created by the client")
+	}
+	station.Mirror, err = normalizeStationMirror(stationName, *station, adoptExisting)
+	return err
+}
+
+func stationCreationServerError(funcName, stationName string, err error) error {
+	serv.Errorf(funcName + ": Station " + stationName + ": " + err.Error())
+	return withErrorCode(ErrCodeServerError, errors.New("Server error"))
+}
+
+// getStationSchemaVersion returns the version a new station uses, a version pinned by the request or the schema's active one
+func getStationSchemaVersion(schema models.Schema, versionNumber int) (models.SchemaVersion, error) {
+	if versionNumber <= 0 {
+		return getActiveVersionBySchemaId(schema.ID)
+	}
+	schemasHandler := SchemasHandler{}
+	schemaVersion, err := schemasHandler.GetSchemaVersion(versionNumber, schema.ID)
+	if err == mongo.ErrNoDocuments {
+		return models.SchemaVersion{}, withErrorCode(ErrCodeSchemaMissing, errors.New("Version "+strconv.Itoa(versionNumber)+" of schema "+schema.Name+" does not exist"))
+	}
+	return schemaVersion, err
+}
+
+// createStation validates the requested station, applies the creation defaults and creates its streams, document and tags,
+// it is the creation path shared by CreateStation and the import of station definitions.
+// internal errors are logged under funcName and returned as server errors, the other errors can be shown to the client
+func (sh StationsHandler) createStation(ctx context.Context, funcName string, body models.CreateStationSchema, user models.User) (models.Station, error) {
+	stationName, err := StationNameFromStr(body.Name)
+	if err != nil {
+		return models.Station{}, err
+	}
+
+	unlock, err := sh.S.lockStationCreation(stationName)
+	if err == ErrStationCreationInProgress {
+		return models.Station{}, withErrorCode(ErrCodeStationExists, errors.New("Station "+stationName.Ext()+": "+err.Error()))
+	}
+	if err != nil {
+		return models.Station{}, stationCreationServerError(funcName, body.Name, err)
+	}
+	defer unlock()
+
+	deletionInProgress, err := sh.S.isStationDeletionInProgress(stationName)
+	if err != nil {
+		return models.Station{}, stationCreationServerError(funcName, body.Name, err)
+	}
+	if deletionInProgress {
+		return models.Station{}, withErrorCode(ErrCodeStationDeletionInProgress, errors.New("Station "+stationName.Ext()+": "+ErrStationDeletionInProgress.Error()))
+	}
+
+	exist, _, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		return models.Station{}, stationCreationServerError(funcName, body.Name, err)
+	}
+	if exist {
+		return models.Station{}, withErrorCode(ErrCodeStationExists, errors.New("Station "+stationName.Ext()+" already exists"))
+	}
+
+	newStation := models.Station{
+		ID:                 primitive.NewObjectID(),
+		Name:               stationName.Ext(),
+		RetentionType:      body.RetentionType,
+		RetentionValue:     body.RetentionValue,
+		StorageType:        body.StorageType,
+		Replicas:           body.Replicas,
+		DedupEnabled:       body.DedupEnabled,    // TODO deprecated
+		DedupWindowInMs:    body.DedupWindowInMs, // TODO deprecated
+		CreatedByUser:      user.Username,
+		CreationDate:       time.Now(),
+		LastUpdate:         time.Now(),
+		Functions:          []models.Function{},
+		IsDeleted:          false,
+		Schema:             models.SchemaDetails{SchemaName: body.SchemaName},
+		IdempotencyWindow:  body.IdempotencyWindow,
+		IsNative:           true,
+		PartitionKeyHeader: body.PartitionKeyHeader,
+		MaxMsgSizeBytes:    body.MaxMsgSizeBytes,
+		DeletionProtected:  body.DeletionProtected,
+		SchemaEnforcement:  body.SchemaEnforcement,
+		ReadOnly:           body.ReadOnly,
+		AllowedProducers:   body.AllowedProducers,
+		AllowedConsumers:   body.AllowedConsumers,
+		CentralDlsStation:  body.CentralDlsStation,
+		MaxMsgDeliveries:   body.MaxMsgDeliveries,
+		Subjects:           body.Subjects,
+		MaxConsumerGroups:  body.MaxConsumerGroups,
+		SchemaRequired:     body.SchemaRequired,
+		CompactionKey:      body.CompactionKey,
+		Description:        body.Description,
+		Metadata:           body.Metadata,
+		AllowNonNative:     body.AllowNonNative,
+		Mirror:             body.Mirror,
+	}
+	err = resolveNewStation(ctx, stationName, &newStation, body.SchemaVersion, body.DlsConfiguration, false)
+	if err != nil {
+		if errorCode(err) == ErrCodeServerError {
+			return models.Station{}, stationCreationServerError(funcName, body.Name, err)
+		}
+		return models.Station{}, err
+	}
+
+	err = sh.S.purgeDeletedStation(stationName)
+	if err != nil {
+		return models.Station{}, stationCreationServerError(funcName, body.Name, err)
+	}
+
+	err = sh.S.CreateStream(stationName, newStation)
+	if err != nil {
+		return models.Station{}, stationCreationServerError(funcName, body.Name, err)
+	}
+
+	// the streams were created by this request, without the station document they would be orphaned
+	rollbackStreams := func() {
+		rollbackErr := rollbackStationStreams(sh.S, stationName)
+		if rollbackErr != nil {
+			serv.Errorf(funcName + ": Station " + body.Name + ": Failed removing the station streams: " + rollbackErr.Error())
+		}
+	}
+
+	err = sh.S.CreateDlsStream(stationName, newStation)
+	if err != nil {
+		rollbackStreams()
+		return models.Station{}, stationCreationServerError(funcName, body.Name, err)
+	}
+
+	var schema interface{} = newStation.Schema
+	if newStation.Schema.SchemaName == "" {
+		schema = struct{}{}
+	}
+	filter := bson.M{"name": newStation.Name, "is_deleted": false}
+	update := bson.M{
+		"$setOnInsert": bson.M{
+			"_id":                      newStation.ID,
+			"retention_type":           newStation.RetentionType,
+			"retention_value":          newStation.RetentionValue,
+			"storage_type":             newStation.StorageType,
+			"replicas":                 newStation.Replicas,
+			"dedup_enabled":            newStation.DedupEnabled,    // TODO deprecated
+			"dedup_window_in_ms":       newStation.DedupWindowInMs, // TODO deprecated
+			"created_by_user":          newStation.CreatedByUser,
+			"creation_date":            newStation.CreationDate,
+			"last_update":              newStation.LastUpdate,
+			"functions":                newStation.Functions,
+			"schema":                   schema,
+			"idempotency_window_in_ms": newStation.IdempotencyWindow,
+			"dls_configuration":        newStation.DlsConfiguration,
+			"is_native":                newStation.IsNative,
+			"partition_key_header":     newStation.PartitionKeyHeader,
+			"max_msg_size_bytes":       newStation.MaxMsgSizeBytes,
+			"deletion_protected":       newStation.DeletionProtected,
+			"schema_enforcement":       newStation.SchemaEnforcement,
+			"read_only":                newStation.ReadOnly,
+			"allowed_producers":        newStation.AllowedProducers,
+			"allowed_consumers":        newStation.AllowedConsumers,
+			"central_dls_station":      newStation.CentralDlsStation,
+			"max_msg_deliveries":       newStation.MaxMsgDeliveries,
+			"subjects":                 newStation.Subjects,
+			"max_consumer_groups":      newStation.MaxConsumerGroups,
+			"schema_required":          newStation.SchemaRequired,
+			"compaction_key_header":    newStation.CompactionKey,
+			"description":              newStation.Description,
+			"metadata":                 newStation.Metadata,
+			"allow_non_native":         newStation.AllowNonNative,
+			"mirror":                   newStation.Mirror,
+		},
+	}
+	opts := options.Update().SetUpsert(true)
+	// the streams already exist at this point, so the request being cancelled must not leave them without a station
+	updateResults, err := stationsCollection.UpdateOne(context.TODO(), filter, update, opts)
+	if err != nil {
+		rollbackStreams()
+		return models.Station{}, stationCreationServerError(funcName, body.Name, err)
+	}
+	if updateResults.MatchedCount > 0 {
+		return models.Station{}, withErrorCode(ErrCodeStationExists, errors.New("Station "+newStation.Name+" already exists"))
+	}
+
+	if len(body.Tags) > 0 {
+		err = AddTagsToEntity(body.Tags, "station", newStation.ID)
+		if err != nil {
+			serv.Errorf(funcName + ": Station " + body.Name + " Failed adding tags: " + err.Error())
+			rollbackErr := rollbackStationCreation(sh.S, stationName, newStation.ID)
+			if rollbackErr != nil {
+				serv.Errorf(funcName + ": Station " + body.Name + ": Failed rolling back station creation: " + rollbackErr.Error())
+				return models.Station{}, withErrorCode(ErrCodeServerError, errors.New("Station "+stationName.Ext()+" has been created but adding its tags failed"))
+			}
+			return models.Station{}, withErrorCode(ErrCodeServerError, errors.New("Station "+stationName.Ext()+" has not been created since adding its tags failed"))
+		}
+	}
+
+	return newStation, nil
+}
+
+func (sh StationsHandler) CreateStation(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.CreateStationSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	user, err := getUserDetailsFromMiddleware(c)
+	if err != nil {
+		serv.Errorf("CreateStation: Station " + body.Name + ": " + err.Error())
+		c.AbortWithStatusJSON(401, gin.H{"message": "Unauthorized", "code": ErrCodeUnauthorized})
+		return
+	}
+
+	newStation, err := sh.createStation(ctx, "CreateStation", body, user)
+	if err != nil {
+		if errorCode(err) == ErrCodeServerError {
+			c.AbortWithStatusJSON(500, gin.H{"message": err.Error(), "code": ErrCodeServerError})
+			return
+		}
+		serv.Warnf("CreateStation: Station " + body.Name + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+	stationName, _ := StationNameFromStr(newStation.Name)
+
+	message := "Station " + stationName.Ext() + " has been created by " + user.Username
+	serv.Noticef(message)
+	var auditLogs []interface{}
+	newAuditLog := models.AuditLog{
+		ID:            primitive.NewObjectID(),
+		StationName:   stationName.Ext(),
+		Message:       message,
+		CreatedByUser: user.Username,
+		CreationDate:  time.Now(),
+		UserType:      user.UserType,
+	}
+	auditLogs = append(auditLogs, newAuditLog)
+	err = CreateAuditLogs(auditLogs)
+	if err != nil {
+		serv.Errorf("CreateStation: Station " + body.Name + ": " + err.Error())
+	}
+
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategoryStation)
+	if shouldSendAnalytics {
+		param := analytics.EventParam{
+			Name:  "station-name",
+			Value: stationName.Ext(),
+		}
+		analyticsParams := []analytics.EventParam{param}
+		analytics.SendEventWithParams(user.Username, analyticsParams, "user-create-station")
+	}
+
+	if body.WaitForReady {
+		err = sh.S.waitForStreamReady(stationName.Intern(), stationReadyTimeout)
+		if err != nil {
+			errMsg := "Station " + stationName.Ext() + " has been created but is not ready yet: " + err.Error()
+			serv.Warnf("CreateStation: " + errMsg)
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+			return
+		}
+	}
+
+	var schemaDetailsResponse interface{} = struct{}{}
+	if newStation.Schema.SchemaName != "" {
+		schemaDetailsResponse = models.StationOverviewSchemaDetails{SchemaName: newStation.Schema.SchemaName, VersionNumber: newStation.Schema.VersionNumber, UpdatesAvailable: true}
+	}
+	storageTypeForResponse := "disk"
+	if newStation.StorageType == "memory" {
+		storageTypeForResponse = newStation.StorageType
+	}
+
+	c.IndentedJSON(200, gin.H{
+		"id":                       newStation.ID,
+		"name":                     stationName.Ext(),
+		"retention_type":           newStation.RetentionType,
+		"retention_value":          newStation.RetentionValue,
+		"storage_type":             storageTypeForResponse,
+		"replicas":                 newStation.Replicas,
+		"dedup_enabled":            newStation.DedupEnabled,    // TODO deprecated
+		"dedup_window_in_ms":       newStation.DedupWindowInMs, // TODO deprecated
+		"created_by_user":          user.Username,
+		"creation_date":            newStation.CreationDate,
+		"last_update":              newStation.LastUpdate,
+		"functions":                []models.Function{},
+		"is_deleted":               false,
+		"schema":                   schemaDetailsResponse,
+		"idempotency_window_in_ms": newStation.IdempotencyWindow,
+		"dls_configuration":        redactDlsConfiguration(newStation.DlsConfiguration),
+		"partition_key_header":     newStation.PartitionKeyHeader,
+		"max_msg_size_bytes":       newStation.MaxMsgSizeBytes,
+		"deletion_protected":       newStation.DeletionProtected,
+		"schema_enforcement":       newStation.SchemaEnforcement,
+		"read_only":                newStation.ReadOnly,
+		"allowed_producers":        newStation.AllowedProducers,
+		"allowed_consumers":        newStation.AllowedConsumers,
+		"central_dls_station":      newStation.CentralDlsStation,
+		"max_msg_deliveries":       newStation.MaxMsgDeliveries,
+		"subjects":                 newStation.Subjects,
+		"max_consumer_groups":      newStation.MaxConsumerGroups,
+		"schema_required":          newStation.SchemaRequired,
+		"compaction_key_header":    newStation.CompactionKey,
+		"description":              newStation.Description,
+		"metadata":                 newStation.Metadata,
+		"allow_non_native":         newStation.AllowNonNative,
+		"mirror":                   newStation.Mirror,
+	})
+}
+
+// diffStationConfig returns the fields in which a stored station differs from the desired one
+func diffStationConfig(current, desired models.Station) []models.StationFieldDiff {
+	diffs := []models.StationFieldDiff{}
+	addDiff := func(field string, currentVal, desiredVal interface{}) {
+		if !reflect.DeepEqual(currentVal, desiredVal) {
+			diffs = append(diffs, models.StationFieldDiff{Field: field, Current: currentVal, Desired: desiredVal})
+		}
+	}
+
+	addDiff("retention_type", current.RetentionType, desired.RetentionType)
+	addDiff("retention_value", current.RetentionValue, desired.RetentionValue)
+	addDiff("storage_type", current.StorageType, desired.StorageType)
+	addDiff("replicas", current.Replicas, desired.Replicas)
+	addDiff("idempotency_window_in_ms", current.IdempotencyWindow, desired.IdempotencyWindow)
+	addDiff("schema_name", current.Schema.SchemaName, desired.Schema.SchemaName)
+	addDiff("dls_configuration", redactDlsConfiguration(current.DlsConfiguration), redactDlsConfiguration(desired.DlsConfiguration))
+	addDiff("central_dls_station", current.CentralDlsStation, desired.CentralDlsStation)
+	addDiff("max_msg_deliveries", current.MaxMsgDeliveries, desired.MaxMsgDeliveries)
+	if len(current.Subjects) > 0 || len(desired.Subjects) > 0 {
+		addDiff("subjects", current.Subjects, desired.Subjects)
+	}
+	addDiff("max_consumer_groups", current.MaxConsumerGroups, desired.MaxConsumerGroups)
+	addDiff("schema_required", current.SchemaRequired, desired.SchemaRequired)
+	addDiff("allow_non_native", stationAllowsNonNative(current), stationAllowsNonNative(desired))
+	addDiff("compaction_key_header", current.CompactionKey, desired.CompactionKey)
+	addDiff("description", current.Description, desired.Description)
+	if len(current.Metadata) > 0 || len(desired.Metadata) > 0 {
+		addDiff("metadata", current.Metadata, desired.Metadata)
+	}
+	return diffs
+}
+
+func (sh StationsHandler) DiffStation(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.CreateStationSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.Name)
+	if err != nil {
+		serv.Warnf("DiffStation: Station " + body.Name + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("DiffStation: Station " + body.Name + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + stationName.Ext() + " does not exist"
+		serv.Warnf("DiffStation: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	// the desired spec gets the same defaults CreateStation would apply
+	defaults := getStationDefaults()
+	desired := models.Station{
+		RetentionType:     defaults.RetentionType,
+		RetentionValue:    defaults.RetentionValue,
+		StorageType:       defaults.StorageType,
+		Replicas:          defaults.Replicas,
+		IdempotencyWindow: defaults.IdempotencyWindow,
+		Schema:            models.SchemaDetails{SchemaName: strings.ToLower(body.SchemaName)},
+		DlsConfiguration:  resolveDlsConfiguration(body.DlsConfiguration, defaults),
+		CentralDlsStation: strings.ToLower(body.CentralDlsStation),
+		MaxMsgDeliveries:  body.MaxMsgDeliveries,
+		Subjects:          body.Subjects,
+		MaxConsumerGroups: body.MaxConsumerGroups,
+		SchemaRequired:    body.SchemaRequired,
+		CompactionKey:     body.CompactionKey,
+		Description:       body.Description,
+		Metadata:          body.Metadata,
+		AllowNonNative:    body.AllowNonNative,
+	}
+	if body.RetentionType != "" {
+		desired.RetentionType = strings.ToLower(body.RetentionType)
+		desired.RetentionValue = body.RetentionValue
+		if retentionIgnoresValue(desired.RetentionType) {
+			desired.RetentionValue = 0
+		}
+		err = validateRetentionType(desired.RetentionType)
+		if err != nil {
+			serv.Warnf("DiffStation: Station " + body.Name + ": " + err.Error())
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+			return
+		}
+		err = validateRetentionValue(desired.RetentionType, desired.RetentionValue)
+		if err != nil {
+			serv.Warnf("DiffStation: Station " + body.Name + ": " + err.Error())
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+			return
+		}
+	}
+	if body.StorageType != "" {
+		desired.StorageType = strings.ToLower(body.StorageType)
+		if desired.StorageType == "disk" {
+			desired.StorageType = "file"
+		}
+		err = validateStorageType(desired.StorageType)
+		if err != nil {
+			serv.Warnf("DiffStation: Station " + body.Name + ": " + err.Error())
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+			return
+		}
+	}
+	if body.Replicas > 0 {
+		desired.Replicas = body.Replicas
+	}
+	if body.IdempotencyWindow > 0 {
+		desired.IdempotencyWindow = body.IdempotencyWindow
+		if desired.IdempotencyWindow < 100 {
+			desired.IdempotencyWindow = 100 // minimum is 100 millis
+		}
+	} else {
+		desired.IdempotencyWindow = defaultIdempotencyWindow(desired.RetentionType, desired.RetentionValue, defaults.IdempotencyWindow)
+	}
+
+	diffs := diffStationConfig(station, desired)
+	c.IndentedJSON(200, gin.H{
+		"station_name": stationName.Ext(),
+		"in_sync":      len(diffs) == 0,
+		"diffs":        diffs,
+	})
+}
+
+// diffStreamConfig compares the stream config the stored station translates to with the live one,
+// durations are reported in the units the station stores them in
+func diffStreamConfig(stored, actual StreamConfig) []models.StationConfigDrift {
+	drifts := []models.StationConfigDrift{}
+	addDrift := func(field string, storedVal, actualVal interface{}) {
+		if storedVal != actualVal {
+			drifts = append(drifts, models.StationConfigDrift{Field: field, Stored: storedVal, Actual: actualVal})
+		}
+	}
+
+	if stored.Replicas == 0 {
+		stored.Replicas = 1
+	}
+	addDrift("max_msgs", stored.MaxMsgs, actual.MaxMsgs)
+	addDrift("max_bytes", stored.MaxBytes, actual.MaxBytes)
+	addDrift("max_age_sec", int64(stored.MaxAge.Seconds()), int64(actual.MaxAge.Seconds()))
+	addDrift("storage_type", strings.ToLower(stored.Storage.String()), strings.ToLower(actual.Storage.String()))
+	addDrift("replicas", stored.Replicas, actual.Replicas)
+	addDrift("idempotency_window_in_ms", stored.Duplicates.Milliseconds(), actual.Duplicates.Milliseconds())
+	addDrift("max_msg_size_bytes", stored.MaxMsgSize, actual.MaxMsgSize)
+	addDrift("subjects", strings.Join(stored.Subjects, ","), strings.Join(actual.Subjects, ","))
+	addDrift("max_msgs_per_subject", stored.MaxMsgsPer, actual.MaxMsgsPer)
+	return drifts
+}
+
+// GetStationConfigDrift reports the stream settings that were changed out of band, e.g. directly through NATS,
+// and no longer match the station's stored configuration
+func (sh StationsHandler) GetStationConfigDrift(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetStationSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("GetStationConfigDrift: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("GetStationConfigDrift: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + stationName.Ext() + " does not exist"
+		serv.Warnf("GetStationConfigDrift: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	streamInfo, err := sh.S.memphisStreamInfo(stationName.Intern())
+	if err != nil {
+		if IsNatsErr(err, JSStreamNotFoundErr) {
+			errMsg := "The stream of station " + stationName.Ext() + " does not exist"
+			serv.Warnf("GetStationConfigDrift: " + errMsg)
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+			return
+		}
+		serv.Errorf("GetStationConfigDrift: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	drifts := diffStreamConfig(stationStreamConfig(stationName, station), streamInfo.Config)
+	c.IndentedJSON(200, gin.H{
+		"station_name": stationName.Ext(),
+		"in_sync":      len(drifts) == 0,
+		"drifts":       drifts,
+	})
+}
+
+// GetStationDeletionImpact reports what removing the station would tear down, without removing anything
+func (sh StationsHandler) GetStationDeletionImpact(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetStationSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("GetStationDeletionImpact: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("GetStationDeletionImpact: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("GetStationDeletionImpact: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	totalMessages, err := sh.S.GetTotalMessagesInStation(stationName)
+	if err != nil {
+		serv.Errorf("GetStationDeletionImpact: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	totalBytes, err := sh.GetTotalBytes(station.Name)
+	if err != nil {
+		serv.Errorf("GetStationDeletionImpact: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	poisonMsgsHandler := PoisonMessagesHandler{S: sh.S}
+	poisonMessages, err := poisonMsgsHandler.GetTotalPoisonMsgsByStation(station.Name)
+	if err != nil {
+		serv.Errorf("GetStationDeletionImpact: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	activeFilter := bson.M{"station_id": station.ID, "is_active": true}
+	activeProducers, err := producersCollection.CountDocuments(ctx, activeFilter)
+	if err != nil {
+		serv.Errorf("GetStationDeletionImpact: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	activeConsumers, err := consumersCollection.CountDocuments(ctx, activeFilter)
+	if err != nil {
+		serv.Errorf("GetStationDeletionImpact: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	tagsHandler := TagsHandler{S: sh.S}
+	tags, err := tagsHandler.GetTagsByStation(station.ID)
+	if err != nil {
+		serv.Errorf("GetStationDeletionImpact: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	c.IndentedJSON(200, models.StationDeletionImpact{
+		StationName:       stationName.Ext(),
+		TotalMessages:     totalMessages,
+		TotalBytes:        totalBytes,
+		PoisonMessages:    poisonMessages,
+		ActiveProducers:   activeProducers,
+		ActiveConsumers:   activeConsumers,
+		Schema:            station.Schema,
+		Tags:              tags,
+		DeletionProtected: station.DeletionProtected,
+	})
+}
+
+// stationDefinition turns a station into the creation request that recreates its configuration
+func stationDefinition(station models.Station, tags []models.CreateTag) models.CreateStationSchema {
+	dlsConfiguration := exportedDlsConfiguration(station.DlsConfiguration)
+	return models.CreateStationSchema{
+		Name:               station.Name,
+		RetentionType:      station.RetentionType,
+		RetentionValue:     station.RetentionValue,
+		Replicas:           station.Replicas,
+		StorageType:        station.StorageType,
+		Tags:               tags,
+		SchemaName:         station.Schema.SchemaName,
+		SchemaVersion:      station.Schema.VersionNumber,
+		IdempotencyWindow:  station.IdempotencyWindow,
+		DlsConfiguration:   &dlsConfiguration,
+		PartitionKeyHeader: station.PartitionKeyHeader,
+		MaxMsgSizeBytes:    station.MaxMsgSizeBytes,
+		DeletionProtected:  station.DeletionProtected,
+		SchemaEnforcement:  station.SchemaEnforcement,
+		ReadOnly:           station.ReadOnly,
+		AllowedProducers:   station.AllowedProducers,
+		AllowedConsumers:   station.AllowedConsumers,
+		CentralDlsStation:  station.CentralDlsStation,
+		MaxMsgDeliveries:   station.MaxMsgDeliveries,
+		Subjects:           station.Subjects,
+		MaxConsumerGroups:  station.MaxConsumerGroups,
+		SchemaRequired:     station.SchemaRequired,
+		CompactionKey:      station.CompactionKey,
+		Description:        station.Description,
+		Metadata:           station.Metadata,
+		AllowNonNative:     station.AllowNonNative,
+		Mirror:             station.Mirror,
+	}
+}
+
+func (sh StationsHandler) ExportStationDefinitions(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var stations []models.Station
+	filter := bson.M{
+		"$or": []interface{}{
+			bson.M{"is_deleted": false},
+			bson.M{"is_deleted": bson.M{"$exists": false}},
+		},
+	}
+	cursor, err := stationsCollection.Find(ctx, filter)
+	if err != nil {
+		serv.Errorf("ExportStationDefinitions: " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if err = cursor.All(ctx, &stations); err != nil {
+		serv.Errorf("ExportStationDefinitions: " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	tagsHandler := TagsHandler{S: sh.S}
+	definitions := []models.CreateStationSchema{}
+	for _, station := range stations {
+		tags, err := tagsHandler.GetTagsByStation(station.ID)
+		if err != nil {
+			serv.Errorf("ExportStationDefinitions: Station " + station.Name + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+		definitions = append(definitions, stationDefinition(station, tags))
+	}
+
+	c.IndentedJSON(200, gin.H{
+		"exported_at": time.Now(),
+		"stations":    definitions,
+	})
+}
+
+// importStationDefinition creates a station from an exported definition through the regular creation path, existing stations are skipped
+func (sh StationsHandler) importStationDefinition(ctx context.Context, def models.CreateStationSchema, user models.User) models.StationImportResult {
+	result := models.StationImportResult{StationName: def.Name}
+	if stationName, err := StationNameFromStr(def.Name); err == nil {
+		result.StationName = stationName.Ext()
+	}
+
+	def.WaitForReady = false
+	newStation, err := sh.createStation(ctx, "ImportStationDefinitions", def, user)
+	if err != nil {
+		result.Message = err.Error()
+		if errorCode(err) == ErrCodeStationExists {
+			result.Status = "skipped"
+			return result
+		}
+		if errorCode(err) != ErrCodeServerError {
+			serv.Warnf("ImportStationDefinitions: Station " + def.Name + ": " + err.Error())
+		}
+		result.Status = "failed"
+		return result
+	}
+
+	message := "Station " + newStation.Name + " has been imported by " + user.Username
+	serv.Noticef(message)
+	var auditLogs []interface{}
+	newAuditLog := models.AuditLog{
+		ID:            primitive.NewObjectID(),
+		StationName:   newStation.Name,
+		Message:       message,
+		CreatedByUser: user.Username,
+		CreationDate:  time.Now(),
+		UserType:      user.UserType,
+	}
+	auditLogs = append(auditLogs, newAuditLog)
+	err = CreateAuditLogs(auditLogs)
+	if err != nil {
+		serv.Errorf("ImportStationDefinitions: Station " + def.Name + ": " + err.Error())
+	}
+
+	result.Status = "imported"
+	return result
+}
+
+func (sh StationsHandler) ImportStationDefinitions(c *gin.Context) {
+	if err := DenyForSandboxEnv(c); err != nil {
+		return
+	}
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.ImportStationDefinitionsSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	user, err := getUserDetailsFromMiddleware(c)
+	if err != nil {
+		serv.Errorf("ImportStationDefinitions: " + err.Error())
+		c.AbortWithStatusJSON(401, gin.H{"message": "Unauthorized", "code": ErrCodeUnauthorized})
+		return
+	}
+
+	// mirrors and stations that use a central DLS station are imported last, so their source or central DLS station of the bundle exists by then
+	definitions := body.Stations
+	sort.SliceStable(definitions, func(i, j int) bool {
+		dependsOn := func(def models.CreateStationSchema) bool {
+			return def.CentralDlsStation != "" || def.Mirror != ""
+		}
+		return !dependsOn(definitions[i]) && dependsOn(definitions[j])
+	})
+
+	results := []models.StationImportResult{}
+	for _, def := range definitions {
+		results = append(results, sh.importStationDefinition(ctx, def, user))
+	}
+
+	c.IndentedJSON(200, gin.H{"stations": results})
+}
+
+func (sh StationsHandler) RemoveStation(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if err := DenyForSandboxEnv(c); err != nil {
+		return
+	}
+	var body models.RemoveStationSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	user, err := getUserDetailsFromMiddleware(c)
+	if err != nil {
+		serv.Errorf("RemoveStation: " + err.Error())
+		c.AbortWithStatusJSON(401, gin.H{"message": "Unauthorized", "code": ErrCodeUnauthorized})
+		return
+	}
+
+	// every station is removed or reported as failed on its own, one bad name does not block the rest of the list
+	removedStations := make([]string, 0)
+	failedStations := make([]models.FailedStationRemoval, 0)
+	failStation := func(name, message, code string) {
+		failedStations = append(failedStations, models.FailedStationRemoval{StationName: name, Message: message, Code: code})
+	}
+
+	var stations []models.Station
+	var releaseDeletions []func()
+	defer func() {
+		for _, release := range releaseDeletions {
+			release()
+		}
+	}()
+	requested := make(map[string]bool)
+	for _, name := range body.StationNames {
+		stationName, err := StationNameFromStr(name)
+		if err != nil {
+			serv.Warnf("RemoveStation: Station " + name + ": " + err.Error())
+			failStation(name, err.Error(), errorCode(err))
+			continue
+		}
+		if requested[stationName.Intern()] {
+			continue
+		}
+		requested[stationName.Intern()] = true
+
+		exist, station, err := IsStationExistWithContext(ctx, stationName)
+		if err != nil {
+			serv.Errorf("RemoveStation: Station " + stationName.external + ": " + err.Error())
+			failStation(stationName.Ext(), "Server error", ErrCodeServerError)
+			continue
+		}
+		if !exist {
+			errMsg := "Station " + name + " does not exist"
+			serv.Warnf("RemoveStation: " + errMsg)
+			failStation(stationName.Ext(), errMsg, ErrCodeStationNotFound)
+			continue
+		}
+		if station.DeletionProtected && !body.OverrideDeletionProtection {
+			errMsg := "Station " + stationName.Ext() + " is protected from deletion, disable the protection or override it explicitly"
+			serv.Warnf("RemoveStation: " + errMsg)
+			failStation(station.Name, errMsg, ErrCodeStationProtected)
+			continue
+		}
+
+		releaseDeletion, err := sh.S.markStationDeletion(stationName)
+		if err != nil {
+			serv.Errorf("RemoveStation: Station " + stationName.Ext() + ": " + err.Error())
+			failStation(station.Name, "Server error", ErrCodeServerError)
+			continue
+		}
+		releaseDeletions = append(releaseDeletions, releaseDeletion)
+		stations = append(stations, station)
+	}
+
+	// a central DLS station can be removed only along with the stations using it
+	removedNames := make(map[string]bool)
+	for _, station := range stations {
+		removedNames[station.Name] = true
+	}
+	var removable []models.Station
+	for _, station := range stations {
+		dependents, err := getCentralDlsDependents(ctx, station.Name, removedNames)
+		if err != nil {
+			serv.Errorf("RemoveStation: Station " + station.Name + ": " + err.Error())
+			failStation(station.Name, "Server error", ErrCodeServerError)
+			continue
+		}
+		if len(dependents) > 0 {
+			err = centralDlsInUseError(station.Name, dependents)
+			serv.Warnf("RemoveStation: " + err.Error())
+			failStation(station.Name, err.Error(), errorCode(err))
+			continue
+		}
+		removable = append(removable, station)
+	}
+	stations = removable
+
+	// the stations are torn down concurrently so a long list does not time out
+	var lock sync.Mutex
+	wg := sync.WaitGroup{}
+	workers := make(chan struct{}, removeStationsWorkers)
+	for _, station := range stations {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(station models.Station) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			err := removeStationResources(sh.S, station, nil)
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				serv.Errorf("RemoveStation: Station " + station.Name + ": " + err.Error())
+				failStation(station.Name, "Server error, please retry removing the station", ErrCodeServerError)
+				return
+			}
+			removedStations = append(removedStations, station.Name)
+		}(station)
+	}
+	wg.Wait()
+	sort.Strings(removedStations)
+	sort.Slice(failedStations, func(i, j int) bool {
+		return failedStations[i].StationName < failedStations[j].StationName
+	})
+
+	if len(removedStations) == 0 {
+		removeStationsFailed(c, failedStations)
+		return
+	}
+
+	// the resources are already deactivated at this point, so the request being cancelled must not leave the stations behind
+	_, err = stationsCollection.UpdateMany(context.TODO(),
+		bson.M{
+			"name": bson.M{"$in": removedStations},
+			"$or": []interface{}{
+				bson.M{"is_deleted": false},
+				bson.M{"is_deleted": bson.M{"$exists": false}},
+			},
+		},
+		bson.M{"$set": bson.M{"is_deleted": true, "deleted_at": time.Now(), "resources_removed": !stationStreamsRetained(false)}},
+	)
+	if err != nil {
+		serv.Errorf("RemoveStation: " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategoryStation)
+	if shouldSendAnalytics {
+		user, _ := getUserDetailsFromMiddleware(c)
+		analytics.SendEvent(user.Username, "user-remove-station")
+	}
+
+	for _, name := range removedStations {
+		message := "Station " + name + " has been deleted by user " + user.Username
+		serv.Noticef(message)
+
+		var auditLogs []interface{}
+		newAuditLog := models.AuditLog{
+			ID:            primitive.NewObjectID(),
+			StationName:   name,
+			Message:       message,
+			CreatedByUser: user.Username,
+			CreationDate:  time.Now(),
+			UserType:      user.UserType,
+		}
+		auditLogs = append(auditLogs, newAuditLog)
+		err = CreateAuditLogs(auditLogs)
+		if err != nil {
+			serv.Warnf("RemoveStation: Station " + name + " - create audit logs error: " + err.Error())
+		}
+	}
+
+	c.IndentedJSON(200, gin.H{"removed_stations": removedStations, "failed_stations": failedStations})
+}
+
+// removeStationsFailed responds to a removal that did not remove any station, the status is a server error only if one of the failures was
+func removeStationsFailed(c *gin.Context, failedStations []models.FailedStationRemoval) {
+	status := configuration.SHOWABLE_ERROR_STATUS_CODE
+	message := "None of the stations could be removed"
+	code := ErrCodeInvalidRequest
+	if len(failedStations) == 1 {
+		message = failedStations[0].Message
+		code = failedStations[0].Code
+	}
+	for _, failed := range failedStations {
+		if failed.Code == ErrCodeServerError {
+			status = 500
+			code = ErrCodeServerError
+		}
+	}
+	c.AbortWithStatusJSON(status, gin.H{"message": message, "code": code, "removed_stations": make([]string, 0), "failed_stations": failedStations})
+}
+
+func (s *Server) removeStationDirect(c *client, reply string, msg []byte) {
+	var dsr destroyStationRequest
+	if err := json.Unmarshal(msg, &dsr); err != nil {
+		s.Errorf("removeStationDirect: " + err.Error())
+		respondWithErr(s, reply, err)
+		return
+	}
+	s.removeStationDirectIntern(c, reply, &dsr, nil)
+}
+
+func (s *Server) removeStationDirectIntern(c *client,
+	reply string,
+	dsr *destroyStationRequest,
+	nonNativeRemoveStreamFunc func() error) {
+	isNative := nonNativeRemoveStreamFunc == nil
+	jsApiResp := JSApiStreamDeleteResponse{ApiResponse: ApiResponse{Type: JSApiStreamDeleteResponseType}}
+
+	stationName, err := StationNameFromStr(dsr.StationName)
+	if err != nil {
+		serv.Warnf("removeStationDirect: Station " + dsr.StationName + ": " + err.Error())
+		jsApiResp.Error = NewJSStreamDeleteError(err)
+		respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
+		return
+	}
+
+	exist, station, err := IsStationExist(stationName)
+	if err != nil {
+		serv.Errorf("removeStationDirect: Station " + dsr.StationName + ": " + err.Error())
+		jsApiResp.Error = NewJSStreamDeleteError(err)
+		respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
+		return
+	}
+	if !exist {
+		errMsg := "Station " + station.Name + " does not exist"
+		serv.Warnf("removeStationDirect: " + errMsg)
+		err := errors.New(errMsg)
+		jsApiResp.Error = NewJSStreamDeleteError(err)
+		respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
+		return
+	}
+	if station.DeletionProtected && !dsr.OverrideDeletionProtection {
+		errMsg := "Station " + stationName.Ext() + " is protected from deletion, disable the protection or override it explicitly"
+		serv.Warnf("removeStationDirect: " + errMsg)
+		err := errors.New(errMsg)
+		jsApiResp.Error = NewJSStreamDeleteError(err)
+		respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
+		return
+	}
+
+	dependents, err := getCentralDlsDependents(context.TODO(), station.Name, nil)
+	if err != nil {
+		serv.Errorf("removeStationDirect: Station " + dsr.StationName + ": " + err.Error())
+		jsApiResp.Error = NewJSStreamDeleteError(err)
+		respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
+		return
+	}
+	if len(dependents) > 0 {
+		err = centralDlsInUseError(station.Name, dependents)
+		serv.Warnf("removeStationDirect: " + err.Error())
+		jsApiResp.Error = NewJSStreamDeleteError(err)
+		respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
+		return
+	}
+
+	releaseDeletion, err := s.markStationDeletion(stationName)
+	if err != nil {
+		serv.Errorf("removeStationDirect: Station " + dsr.StationName + ": " + err.Error())
+		jsApiResp.Error = NewJSStreamDeleteError(err)
+		respondWithErrOrJsApiResp(!isNative, c, c.acc, _EMPTY_, reply, _EMPTY_, jsApiResp, err)
+		return
+	}
+	defer releaseDeletion()
+
+	err = removeStationResources(s, station, nonNativeRemoveStreamFunc)
+	if err != nil {
+		serv.Errorf("RemoveStation: Station " + dsr.StationName + ": " + err.Error())
+		respondWithErr(s, reply, err)
+		return
+	}
+
+	_, err = stationsCollection.UpdateOne(context.TODO(),
+		bson.M{
+			"name": stationName.Ext(),
+			"$or": []interface{}{
 				bson.M{"is_deleted": false},
 				bson.M{"is_deleted": bson.M{"$exists": false}},
 			},
 		},
-		bson.M{"$set": bson.M{"is_deleted": true}},
-	)
+		bson.M{"$set": bson.M{"is_deleted": true, "deleted_at": time.Now(), "resources_removed": !stationStreamsRetained(!isNative)}},
+	)
+	if err != nil {
+		serv.Errorf("RemoveStation error: Station " + dsr.StationName + ": " + err.Error())
+		respondWithErr(s, reply, err)
+		return
+	}
+
+	message := "Station " + stationName.Ext() + " has been deleted by user " + c.memphisInfo.username
+	serv.Noticef(message)
+	var auditLogs []interface{}
+	newAuditLog := models.AuditLog{
+		ID:            primitive.NewObjectID(),
+		StationName:   stationName.Ext(),
+		Message:       message,
+		CreatedByUser: c.memphisInfo.username,
+		CreationDate:  time.Now(),
+		UserType:      "application",
+	}
+	auditLogs = append(auditLogs, newAuditLog)
+	err = CreateAuditLogs(auditLogs)
+	if err != nil {
+		serv.Warnf("removeStationDirect: Station " + stationName.Ext() + " - create audit logs error: " + err.Error())
+	}
+	respondWithErr(s, reply, nil)
+	return
+}
+
+func (sh StationsHandler) GetTotalMessages(stationNameExt string) (int, error) {
+	stationName, err := StationNameFromStr(stationNameExt)
+	if err != nil {
+		return 0, err
+	}
+	totalMessages, err := sh.S.GetTotalMessagesInStation(stationName)
+	return totalMessages, err
+}
+
+func (sh StationsHandler) GetStationActivity(stationNameExt string) (*time.Time, *time.Time, error) {
+	stationName, err := StationNameFromStr(stationNameExt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sh.S.GetStationActivity(stationName)
+}
+
+func (sh StationsHandler) GetTotalBytes(stationNameExt string) (int64, error) {
+	stationName, err := StationNameFromStr(stationNameExt)
+	if err != nil {
+		return 0, err
+	}
+	totalBytes, err := sh.S.GetTotalBytesInStation(stationName)
+	return totalBytes, err
+}
+
+// GetSchemaFailureRate returns the share of messages produced to the station within the window
+// which were rejected by its schema and sent to the DLS
+func (sh StationsHandler) GetSchemaFailureRate(station models.Station, window time.Duration) (float64, error) {
+	if !station.IsNative || station.Schema.SchemaName == "" {
+		return 0, nil
+	}
+	stationName, err := StationNameFromStr(station.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	since := time.Now().Add(-window)
+	dlsStream, _, err := getStationDlsLocation(station)
+	if err != nil {
+		return 0, err
+	}
+	dlsSubject, err := getStationDlsSubject(station, "schema", ">")
+	if err != nil {
+		return 0, err
+	}
+	schemaFailedMsgs, err := sh.S.memphisCountMsgsSince(dlsStream, dlsSubject, since)
+	if err != nil {
+		return 0, err
+	}
+	if schemaFailedMsgs == 0 {
+		return 0, nil
+	}
+	storedMsgs, err := sh.S.memphisCountMsgsSince(stationName.Intern(), stationMsgsSubject(stationName, station), since)
+	if err != nil {
+		return 0, err
+	}
+
+	// schema failed messages never reach the station's stream so they are part of the throughput as well
+	return float64(schemaFailedMsgs) / float64(schemaFailedMsgs+storedMsgs), nil
+}
+
+func (sh StationsHandler) GetTotalMessagesAcrossAllStations() (int, error) {
+	totalMessages, err := sh.S.GetTotalMessagesAcrossAllStations()
+	return totalMessages, err
+}
+
+func (sh StationsHandler) GetAvgMsgSize(station models.Station) (int64, error) {
+	avgMsgSize, err := sh.S.GetAvgMsgSizeInStation(station)
+	return avgMsgSize, err
+}
+
+func validateMessagesOrder(order string) error {
+	if order != "" && order != messagesOrderOldest && order != messagesOrderNewest {
+		return errors.New("messages order can be one of the following oldest/newest")
+	}
+	return nil
+}
+
+func (sh StationsHandler) GetMessages(station models.Station, messagesToFetch int, order string) ([]models.MessageDetails, error) {
+	messages, err := sh.S.GetMessages(station, messagesToFetch, order)
+	if err != nil {
+		return messages, err
+	}
+
+	return messages, nil
+}
+
+func (sh StationsHandler) GetLeaderAndFollowers(station models.Station) (string, []string, error) {
+	if sh.S.JetStreamIsClustered() {
+		leader, followers, err := sh.S.GetLeaderAndFollowers(station)
+		if err != nil {
+			return "", []string{}, err
+		}
+
+		return leader, followers, nil
+	} else {
+		return "broker-0", []string{}, nil
+	}
+}
+
+// getReplicaHealth compares the configured replicas of a station to its leader and the followers which are
+// reachable and caught up with it, the cluster info is nil when JetStream is not clustered
+func getReplicaHealth(station models.Station, leader string, cluster *ClusterInfo) models.ReplicaHealth {
+	inSync := 0
+	if leader != "" {
+		inSync++
+	}
+	if cluster != nil {
+		for _, replica := range cluster.Replicas {
+			if replica.Current && !replica.Offline {
+				inSync++
+			}
+		}
+	}
+	configured := station.Replicas
+	if configured <= 0 {
+		configured = 1
+	}
+
+	return models.ReplicaHealth{
+		Configured:      configured,
+		InSync:          inSync,
+		UnderReplicated: inSync < configured,
+	}
+}
+
+// getIdempotencyWindowState compares the idempotency window the station translates to with the stream's actual one,
+// they diverge when the stream was changed out of band
+func getIdempotencyWindowState(configured, actual StreamConfig) models.IdempotencyWindowState {
+	return models.IdempotencyWindowState{
+		ConfiguredMs: configured.Duplicates.Milliseconds(),
+		ActualMs:     actual.Duplicates.Milliseconds(),
+		Diverged:     configured.Duplicates != actual.Duplicates,
+	}
+}
+
+// GetIdempotencyWindowState takes the config of the station's stream info the overview already fetched
+func (sh StationsHandler) GetIdempotencyWindowState(station models.Station, streamConfig StreamConfig) (models.IdempotencyWindowState, error) {
+	sn, err := StationNameFromStr(station.Name)
+	if err != nil {
+		return models.IdempotencyWindowState{}, err
+	}
+	return getIdempotencyWindowState(stationStreamConfig(sn, station), streamConfig), nil
+}
+
+func getCgStatus(members []models.CgMember) (bool, bool) {
+	deletedCount := 0
+	for _, member := range members {
+		if member.IsActive {
+			return true, false
+		}
+
+		if member.IsDeleted {
+			deletedCount++
+		}
+	}
+
+	if len(members) == deletedCount {
+		return false, true
+	}
+
+	return false, false
+}
+
+// parseDlsMsgId extracts the station and the message sequence from a DLS message id built by GetDlsMsgId
+func parseDlsMsgId(dlsMsgId string) (StationName, int, error) {
+	splitId := strings.Split(dlsMsgId, dlsMsgSep)
+	if len(splitId) != 4 {
+		return StationName{}, 0, errors.New("invalid message id " + dlsMsgId + ", expected the format <station>" + dlsMsgSep + "<producer>" + dlsMsgSep + "<sequence>" + dlsMsgSep + "<time sent>")
+	}
+	sn, err := StationNameFromStr(splitId[0])
+	if err != nil {
+		return StationName{}, 0, errors.New("invalid station in message id " + dlsMsgId + ": " + err.Error())
+	}
+	seq, err := strconv.Atoi(splitId[2])
+	if err != nil {
+		return StationName{}, 0, errors.New("invalid sequence in message id " + dlsMsgId)
+	}
+	return sn, seq, nil
+}
+
+func (sh StationsHandler) GetDlsMessageJourneyDetails(ctx context.Context, dlsMsgId string) (models.DlsMessageResponse, error) {
+	dlsMsgId = strings.ReplaceAll(dlsMsgId, " ", "+")
+	poisonMsgsHandler := PoisonMessagesHandler{S: sh.S}
+	var dlsMessage models.DlsMessageResponse
+	sn, seq, err := parseDlsMsgId(dlsMsgId)
+	if err != nil {
+		return dlsMessage, err
+	}
+	exist, station, err := IsStationExistWithContext(ctx, sn)
+	if err != nil {
+		return dlsMessage, err
+	}
+	if !exist {
+		return dlsMessage, errors.New("Station " + station.Name + " does not exist")
+	}
+
+	poisoned, schemaFailed, err := poisonMsgsHandler.GetDlsMsgsByStationFull(station)
+	if err != nil {
+		return dlsMessage, err
+	}
+	for _, dlm := range poisoned {
+		if dlm.ID == dlsMsgId {
+			dlsMessage = dlm
+			headersJson := dlsMessage.Message.Headers
+			for header := range headersJson {
+				if strings.HasPrefix(header, "$memphis") {
+					delete(headersJson, header)
+				}
+			}
+			dlsMessage.Message.Headers = headersJson
+			break
+		}
+	}
+
+	if dlsMessage.ID == "" {
+		for _, dlm := range schemaFailed {
+			if dlm.ID == dlsMsgId {
+				dlsMessage = dlm
+				break
+			}
+		}
+	}
+	if dlsMessage.ID == "" {
+		return dlsMessage, nil
+	}
+
+	poisonedCgs, err := GetPoisonedCgsByMessage(sn.Intern(), models.MessageDetails{MessageSeq: seq, ProducedBy: dlsMessage.Producer.Name, TimeSent: dlsMessage.Message.TimeSent})
+	if err != nil {
+		return dlsMessage, err
+	}
+
+	cgs := make([]models.PoisonedCg, 0)
+	sort.Slice(poisonedCgs, func(i, j int) bool {
+		return poisonedCgs[i].PoisoningTime.After(poisonedCgs[j].PoisoningTime)
+	})
+	cgCheck := make(map[string]bool)
+	for _, cg := range poisonedCgs {
+		if _, value := cgCheck[cg.CgName]; value {
+			continue
+		}
+		cgCheck[cg.CgName] = true
+		// a cg whose details can not be read (e.g. just deleted) is marked instead of failing the whole journey
+		cgMembers, err := GetConsumerGroupMembers(ctx, cg.CgName, station)
+		if err != nil {
+			serv.Warnf("GetDlsMessageJourneyDetails: Station " + sn.Ext() + ": Consumer group " + cg.CgName + ": " + err.Error())
+			cg.InfoUnavailable = true
+			cgMembers = []models.CgMember{}
+		}
+
+		isActive, isDeleted := getCgStatus(cgMembers)
+		cgInfo, err := sh.S.GetCgInfo(sn, cg.CgName)
+		if err != nil {
+			serv.Warnf("GetDlsMessageJourneyDetails: Station " + sn.Ext() + ": Consumer group " + cg.CgName + ": " + err.Error())
+			cg.InfoUnavailable = true
+		} else {
+			cg.UnprocessedMessages = int(cgInfo.NumPending)
+			cg.InProcessMessages = cgInfo.NumAckPending
+		}
+		totalPms, err := GetTotalPoisonMsgsByCg(sn.Intern(), cg.CgName)
+		if err != nil {
+			serv.Warnf("GetDlsMessageJourneyDetails: Station " + sn.Ext() + ": Consumer group " + cg.CgName + ": " + err.Error())
+			cg.InfoUnavailable = true
+		}
+		if len(cgMembers) > 0 {
+			cg.MaxAckTimeMs = cgMembers[0].MaxAckTimeMs
+			cg.MaxMsgDeliveries = cgMembers[0].MaxMsgDeliveries
+		}
+		cg.DeliveryCount = sh.S.getPoisonedCgDeliveryCount(sn, cg, seq)
+		cg.TotalPoisonMessages = totalPms
+		cg.CgMembers = cgMembers
+		cg.IsActive = isActive
+		cg.IsDeleted = isDeleted
+		cgs = append(cgs, cg)
+	}
+
+	dlsMessage.PoisonedCgs = cgs
+
+	return dlsMessage, nil
+}
+
+func (sh StationsHandler) GetStationConsumerGroups(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetStationSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("GetStationConsumerGroups: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("GetStationConsumerGroups: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("GetStationConsumerGroups: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	cgNames, err := consumersCollection.Distinct(ctx, "consumers_group", bson.M{"station_id": station.ID})
+	if err != nil {
+		serv.Errorf("GetStationConsumerGroups: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	cgs := make([]models.StationConsumerGroup, 0)
+	for _, name := range cgNames {
+		cgName, ok := name.(string)
+		if !ok || cgName == "" {
+			continue
+		}
+		cgMembers, err := GetConsumerGroupMembers(ctx, cgName, station)
+		if err != nil {
+			serv.Errorf("GetStationConsumerGroups: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+		if len(cgMembers) == 0 {
+			continue
+		}
+
+		isActive, isDeleted := getCgStatus(cgMembers)
+		cg := models.StationConsumerGroup{
+			Name:             cgName,
+			MaxAckTimeMs:     cgMembers[0].MaxAckTimeMs,
+			MaxMsgDeliveries: cgMembers[0].MaxMsgDeliveries,
+			IsActive:         isActive,
+			IsDeleted:        isDeleted,
+			Members:          cgMembers,
+		}
+		if !isDeleted {
+			cgInfo, err := sh.S.GetCgInfo(stationName, cgName)
+			if err != nil {
+				serv.Errorf("GetStationConsumerGroups: Station " + body.StationName + ": " + err.Error())
+				c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+				return
+			}
+			cg.UnprocessedMessages = int(cgInfo.NumPending)
+			cg.InProcessMessages = cgInfo.NumAckPending
+		}
+		cgs = append(cgs, cg)
+	}
+
+	sort.Slice(cgs, func(i, j int) bool {
+		return cgs[i].Name < cgs[j].Name
+	})
+
+	c.IndentedJSON(200, cgs)
+}
+
+func (sh StationsHandler) GetStationDlsRate(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetStationDlsRateSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	if body.Minutes <= 0 {
+		body.Minutes = 10 // default
+	} else if body.Minutes > 1440 {
+		errMsg := "minutes can not exceed 1440 (24 hours)"
+		serv.Warnf("GetStationDlsRate: Station " + body.StationName + ": " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("GetStationDlsRate: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("GetStationDlsRate: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("GetStationDlsRate: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	streamName, _, err := getStationDlsLocation(station)
+	if err != nil {
+		serv.Errorf("GetStationDlsRate: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	poisonSubject, err := getStationDlsSubject(station, "poison", ">")
+	if err != nil {
+		serv.Errorf("GetStationDlsRate: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	schemaSubject, err := getStationDlsSubject(station, "schema", ">")
+	if err != nil {
+		serv.Errorf("GetStationDlsRate: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	since := time.Now().Add(-time.Duration(body.Minutes) * time.Minute)
+	poisonMsgs, err := sh.S.memphisCountMsgsSince(streamName, poisonSubject, since)
+	if err != nil {
+		serv.Errorf("GetStationDlsRate: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	schemaFailedMsgs, err := sh.S.memphisCountMsgsSince(streamName, schemaSubject, since)
+	if err != nil {
+		serv.Errorf("GetStationDlsRate: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	c.IndentedJSON(200, gin.H{
+		"station_name":           stationName.Ext(),
+		"window_in_minutes":      body.Minutes,
+		"poison_messages":        poisonMsgs,
+		"schema_failed_messages": schemaFailedMsgs,
+		"rate_per_minute":        float64(poisonMsgs+schemaFailedMsgs) / float64(body.Minutes),
+	})
+}
+
+// SuggestRetention sizes the bytes/messages/age retention values so the station fits the given disk budget,
+// based on the ingest rate over the sampled window and the average message size
+func (sh StationsHandler) SuggestRetention(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.SuggestRetentionSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	if body.Minutes <= 0 {
+		body.Minutes = 60 // default
+	} else if body.Minutes > 1440 {
+		errMsg := "minutes can not exceed 1440 (24 hours)"
+		serv.Warnf("SuggestRetention: Station " + body.StationName + ": " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("SuggestRetention: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("SuggestRetention: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("SuggestRetention: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	avgMsgSize, err := sh.GetAvgMsgSize(station)
+	if err != nil {
+		serv.Errorf("SuggestRetention: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	streamInfo, err := sh.S.memphisStreamInfo(stationName.Intern())
+	if err != nil {
+		serv.Errorf("SuggestRetention: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	// the ingest rate is the sequence delta between the first message of the window and the last one
+	since := time.Now().Add(-time.Duration(body.Minutes) * time.Minute)
+	firstSeq, pending, err := sh.S.memphisFirstSeqSince(stationName.Intern(), _EMPTY_, since)
+	if err != nil {
+		serv.Errorf("SuggestRetention: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	var ingestedMsgs uint64
+	if pending > 0 && streamInfo.State.LastSeq >= firstSeq {
+		ingestedMsgs = streamInfo.State.LastSeq - firstSeq + 1
+	}
+	ratePerSec := float64(ingestedMsgs) / (float64(body.Minutes) * 60)
+
+	if avgMsgSize == 0 || ratePerSec == 0 {
+		errMsg := "Not enough data in station " + stationName.Ext() + " to suggest a retention, try a wider sample window"
+		serv.Warnf("SuggestRetention: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+
+	suggestedMsgs := body.TargetBytes / avgMsgSize
+	suggestedAgeSec := int64(float64(suggestedMsgs) / ratePerSec)
+
+	c.IndentedJSON(200, gin.H{
+		"station_name":      stationName.Ext(),
+		"window_in_minutes": body.Minutes,
+		"ingest_rate":       ratePerSec,
+		"avg_msg_size":      avgMsgSize,
+		"target_bytes":      body.TargetBytes,
+		"bytes":             body.TargetBytes,
+		"messages":          suggestedMsgs,
+		"message_age_sec":   suggestedAgeSec,
+	})
+}
+
+// retentionLimitStats compares a current value with its configured limit, growth is the expected increase per second
+func retentionLimitStats(current, limit int64, growth float64) models.RetentionLimitStats {
+	stats := models.RetentionLimitStats{Current: current, Limit: limit}
+	if limit <= 0 {
+		return stats
+	}
+	stats.UsagePercent = float64(current) / float64(limit) * 100
+	stats.Trimming = current >= limit
+	if !stats.Trimming && growth > 0 {
+		trimmingStart := time.Now().Add(time.Duration(float64(limit-current) / growth * float64(time.Second)))
+		stats.TrimmingStart = &trimmingStart
+	}
+	return stats
+}
+
+// GetRetentionStats shows how close the station is to each of its retention limits and,
+// at the current ingest rate, when trimming is expected to begin
+func (sh StationsHandler) GetRetentionStats(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetRetentionStatsSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	if body.Minutes <= 0 {
+		body.Minutes = 60 // default
+	} else if body.Minutes > 1440 {
+		errMsg := "minutes can not exceed 1440 (24 hours)"
+		serv.Warnf("GetRetentionStats: Station " + body.StationName + ": " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("GetRetentionStats: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("GetRetentionStats: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("GetRetentionStats: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	avgMsgSize, err := sh.GetAvgMsgSize(station)
+	if err != nil {
+		serv.Errorf("GetRetentionStats: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	streamInfo, err := sh.S.memphisStreamInfo(stationName.Intern())
+	if err != nil {
+		serv.Errorf("GetRetentionStats: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	since := time.Now().Add(-time.Duration(body.Minutes) * time.Minute)
+	firstSeq, pending, err := sh.S.memphisFirstSeqSince(stationName.Intern(), _EMPTY_, since)
+	if err != nil {
+		serv.Errorf("GetRetentionStats: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	var ingestedMsgs uint64
+	if pending > 0 && streamInfo.State.LastSeq >= firstSeq {
+		ingestedMsgs = streamInfo.State.LastSeq - firstSeq + 1
+	}
+	ratePerSec := float64(ingestedMsgs) / (float64(body.Minutes) * 60)
+
+	var oldestMsgAgeSec int64
+	if streamInfo.State.Msgs > 0 {
+		oldestMsgAgeSec = int64(time.Since(streamInfo.State.FirstTime).Seconds())
+	}
+
+	// the oldest message ages one second per second regardless of the ingest rate
+	var ageGrowth float64
+	if streamInfo.State.Msgs > 0 {
+		ageGrowth = 1
+	}
+
+	c.IndentedJSON(200, gin.H{
+		"station_name":      stationName.Ext(),
+		"retention_type":    station.RetentionType,
+		"retention_value":   station.RetentionValue,
+		"window_in_minutes": body.Minutes,
+		"ingest_rate":       ratePerSec,
+		"avg_msg_size":      avgMsgSize,
+		"messages":          retentionLimitStats(int64(streamInfo.State.Msgs), streamInfo.Config.MaxMsgs, ratePerSec),
+		"bytes":             retentionLimitStats(int64(streamInfo.State.Bytes), streamInfo.Config.MaxBytes, ratePerSec*float64(avgMsgSize)),
+		"message_age_sec":   retentionLimitStats(oldestMsgAgeSec, int64(streamInfo.Config.MaxAge.Seconds()), ageGrowth),
+	})
+}
+
+// GetStationDedupStats reports how many duplicate messages the station's idempotency window has rejected,
+// the counter covers the time since the station's stream was loaded by this broker
+func (sh StationsHandler) GetStationDedupStats(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetStationSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("GetStationDedupStats: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, _, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("GetStationDedupStats: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("GetStationDedupStats: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	streamInfo, err := sh.S.memphisStreamInfo(stationName.Intern())
+	if err != nil {
+		serv.Errorf("GetStationDedupStats: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	tracked, rejected, since, err := sh.S.memphisStreamDedupStats(stationName.Intern())
+	if IsNatsErr(err, JSStreamNotFoundErr) {
+		errMsg := "Deduplication stats of station " + stationName.Ext() + " are available only through a broker holding a replica of it"
+		serv.Warnf("GetStationDedupStats: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+	if err != nil {
+		serv.Errorf("GetStationDedupStats: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	c.IndentedJSON(200, gin.H{
+		"station_name":             stationName.Ext(),
+		"idempotency_window_in_ms": streamInfo.Config.Duplicates.Milliseconds(),
+		"tracked_msg_ids":          tracked,
+		"duplicates_rejected":      rejected,
+		"since":                    since,
+	})
+}
+
+// GetStationAuditLogs returns the station's audit logs in chronological order, a page at a time
+func (sh StationsHandler) GetStationAuditLogs(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetStationAuditLogsSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	if body.PageSize == 0 {
+		body.PageSize = defaultAuditLogsPageSize
+	} else if body.PageSize > maxAuditLogsPageSize {
+		errMsg := "page size can not exceed " + strconv.Itoa(maxAuditLogsPageSize)
+		serv.Warnf("GetStationAuditLogs: Station " + body.StationName + ": " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+	if !body.From.IsZero() && !body.To.IsZero() && body.To.Before(body.From) {
+		errMsg := "to has to be after from"
+		serv.Warnf("GetStationAuditLogs: Station " + body.StationName + ": " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("GetStationAuditLogs: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, _, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("GetStationAuditLogs: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("GetStationAuditLogs: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	auditLogs, total, err := getStationAuditLogsPage(ctx, stationName, body.From, body.To, body.Page, body.PageSize)
+	if err != nil {
+		serv.Errorf("GetStationAuditLogs: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	c.IndentedJSON(200, gin.H{
+		"audit_logs": auditLogs,
+		"page":       body.Page,
+		"page_size":  body.PageSize,
+		"total":      total,
+	})
+}
+
+func (sh StationsHandler) GetStationStreamName(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetStationSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("GetStationStreamName: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("GetStationStreamName: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("GetStationStreamName: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	dlsStream, _, err := getStationDlsLocation(station)
+	if err != nil {
+		serv.Errorf("GetStationStreamName: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	c.IndentedJSON(200, gin.H{
+		"station_name":    stationName.Ext(),
+		"internal_name":   stationName.Intern(),
+		"dls_stream_name": dlsStream,
+	})
+}
+
+func (sh StationsHandler) GetStationSubjects(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetStationSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("GetStationSubjects: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("GetStationSubjects: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("GetStationSubjects: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	// Memphis producers publish on the station's final subject, raw NATS clients may publish on any of the subjects
+	c.IndentedJSON(200, gin.H{
+		"station_name":    stationName.Ext(),
+		"subjects":        stationSubjects(stationName, station),
+		"produce_subject": stationProduceSubject(stationName, station),
+	})
+}
+
+func (sh StationsHandler) GetStationActiveSchema(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetStationSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("GetStationActiveSchema: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("GetStationActiveSchema: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("GetStationActiveSchema: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+	if station.Schema.SchemaName == "" {
+		errMsg := "Station " + body.StationName + " has no schema attached"
+		serv.Warnf("GetStationActiveSchema: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeSchemaMissing})
+		return
+	}
+
+	exist, schema, err := IsSchemaExist(station.Schema.SchemaName)
+	if err != nil {
+		serv.Errorf("GetStationActiveSchema: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Schema " + station.Schema.SchemaName + " attached to station " + body.StationName + " does not exist"
+		serv.Warnf("GetStationActiveSchema: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeSchemaMissing})
+		return
+	}
+
+	schemasHandler := SchemasHandler{S: sh.S}
+	schemaVersion, err := schemasHandler.GetSchemaVersion(station.Schema.VersionNumber, schema.ID)
+	if err != nil {
+		serv.Errorf("GetStationActiveSchema: Station " + body.StationName + ": Schema " + schema.Name + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	c.IndentedJSON(200, models.StationActiveSchemaResponse{
+		SchemaName:        schema.Name,
+		SchemaType:        schema.Type,
+		VersionNumber:     schemaVersion.VersionNumber,
+		SchemaContent:     schemaVersion.SchemaContent,
+		MessageStructName: schemaVersion.MessageStructName,
+		Descriptor:        schemaVersion.Descriptor,
+		Enforcement:       getStationSchemaEnforcement(station),
+	})
+}
+
+func (sh StationsHandler) GetStationSchemaVersionBreakdown(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetStationSchemaVersionBreakdownSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	if body.SampleSize <= 0 {
+		body.SampleSize = 1000 // default
+	} else if body.SampleSize > 10000 {
+		errMsg := "sample size can not exceed 10000 messages"
+		serv.Warnf("GetStationSchemaVersionBreakdown: Station " + body.StationName + ": " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("GetStationSchemaVersionBreakdown: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("GetStationSchemaVersionBreakdown: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("GetStationSchemaVersionBreakdown: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+	if !station.IsNative {
+		errMsg := "Schema versions are not tracked for messages of non native station " + stationName.Ext()
+		serv.Warnf("GetStationSchemaVersionBreakdown: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+
+	streamInfo, err := sh.S.memphisStreamInfo(stationName.Intern())
+	if err != nil {
+		serv.Errorf("GetStationSchemaVersionBreakdown: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	amount := body.SampleSize
+	startSequence := streamInfo.State.FirstSeq
+	if streamInfo.State.Msgs > uint64(amount) {
+		startSequence = streamInfo.State.LastSeq - uint64(amount) + 1
+	} else {
+		amount = int(streamInfo.State.Msgs)
+	}
+
+	versions := make(map[string]int)
+	sampled := 0
+	if amount > 0 {
+		msgs, err := sh.S.memphisGetMsgs(stationMsgsSubject(stationName, station), stationName.Intern(), startSequence, amount, 5*time.Second, true)
+		if err != nil {
+			serv.Errorf("GetStationSchemaVersionBreakdown: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+
+		for _, msg := range msgs {
+			version := unknownSchemaVersion
+			headersJson, err := DecodeHeader(msg.Header)
+			if err == nil && headersJson[schemaVersionHeader] != "" {
+				version = headersJson[schemaVersionHeader]
+			}
+			versions[version]++
+			sampled++
+		}
+	}
+
+	c.IndentedJSON(200, gin.H{
+		"station_name":     stationName.Ext(),
+		"schema_name":      station.Schema.SchemaName,
+		"active_version":   station.Schema.VersionNumber,
+		"sampled_messages": sampled,
+		"versions":         versions,
+	})
+}
+
+// getProducersSchemaStatus returns the schema version of every active producer of the station, taken from the newest of its
+// messages within the sampled window, along with the amount of sampled messages
+func (sh StationsHandler) getProducersSchemaStatus(ctx context.Context, stationName StationName, station models.Station, sampleSize int) ([]models.ProducerSchemaStatus, int, error) {
+	var producers []models.Producer
+	cursor, err := producersCollection.Find(ctx, bson.M{"station_id": station.ID, "is_active": true}, options.Find().SetSort(bson.M{"name": 1}))
+	if err != nil {
+		return nil, 0, err
+	}
+	if err = cursor.All(ctx, &producers); err != nil {
+		return nil, 0, err
+	}
+
+	streamInfo, err := sh.S.memphisStreamInfo(stationName.Intern())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	amount := sampleSize
+	startSequence := streamInfo.State.FirstSeq
+	if streamInfo.State.Msgs > uint64(amount) {
+		startSequence = streamInfo.State.LastSeq - uint64(amount) + 1
+	} else {
+		amount = int(streamInfo.State.Msgs)
+	}
+
+	// keyed by producer name and connection id, holding the newest sampled message of each producer
+	lastSeen := make(map[string]models.ProducerSchemaStatus)
+	if amount > 0 && len(producers) > 0 {
+		msgs, err := sh.S.memphisGetMsgs(stationMsgsSubject(stationName, station), stationName.Intern(), startSequence, amount, 5*time.Second, true)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for _, msg := range msgs {
+			headersJson, err := DecodeHeader(msg.Header)
+			if err != nil {
+				continue
+			}
+			connectionIdHeader := headersJson["$memphis_connectionId"]
+			producedByHeader := strings.ToLower(headersJson["$memphis_producedBy"])
+			if connectionIdHeader == "" || producedByHeader == "" {
+				continue
+			}
+			version := unknownSchemaVersion
+			if headersJson[schemaVersionHeader] != "" {
+				version = headersJson[schemaVersionHeader]
+			}
+
+			key := producedByHeader + "_" + connectionIdHeader
+			if seen, ok := lastSeen[key]; ok && seen.LastSeen.After(msg.Time) {
+				continue
+			}
+			msgTime := msg.Time
+			lastSeen[key] = models.ProducerSchemaStatus{SchemaVersion: version, LastSeen: &msgTime}
+		}
+	}
+
+	activeVersion := ""
+	if station.Schema.SchemaName != "" {
+		activeVersion = strconv.Itoa(station.Schema.VersionNumber)
+	}
+	statuses := make([]models.ProducerSchemaStatus, 0, len(producers))
+	for _, producer := range producers {
+		status, ok := lastSeen[producer.Name+"_"+producer.ConnectionId.Hex()]
+		if !ok {
+			status.SchemaVersion = unknownSchemaVersion
+		}
+		status.Name = producer.Name
+		status.ConnectionId = producer.ConnectionId
+		status.UpToDate = activeVersion != "" && status.SchemaVersion == activeVersion
+		statuses = append(statuses, status)
+	}
+
+	return statuses, amount, nil
+}
+
+// getSchemaStatusStation validates the sample size and the station shared by the producers schema status endpoints,
+// on failure the response is already written and false is returned
+func getSchemaStatusStation(c *gin.Context, ctx context.Context, body *models.GetStationProducersSchemaStatusSchema, funcName string) (StationName, models.Station, bool) {
+	if body.SampleSize <= 0 {
+		body.SampleSize = 1000 // default
+	} else if body.SampleSize > 10000 {
+		errMsg := "sample size can not exceed 10000 messages"
+		serv.Warnf(funcName + ": Station " + body.StationName + ": " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return StationName{}, models.Station{}, false
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf(funcName + ": Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return StationName{}, models.Station{}, false
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf(funcName + ": Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return StationName{}, models.Station{}, false
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf(funcName + ": " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return StationName{}, models.Station{}, false
+	}
+	if !station.IsNative {
+		errMsg := "Schema versions are not tracked for messages of non native station " + stationName.Ext()
+		serv.Warnf(funcName + ": " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return StationName{}, models.Station{}, false
+	}
+
+	return stationName, station, true
+}
+
+// GetStationProducersSchemaStatus reports, for every active producer of a station, the schema version
+// stamped on the most recent message it produced within the sampled window
+func (sh StationsHandler) GetStationProducersSchemaStatus(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetStationProducersSchemaStatusSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	stationName, station, ok := getSchemaStatusStation(c, ctx, &body, "GetStationProducersSchemaStatus")
+	if !ok {
+		return
+	}
+
+	statuses, amount, err := sh.getProducersSchemaStatus(ctx, stationName, station, body.SampleSize)
+	if err != nil {
+		serv.Errorf("GetStationProducersSchemaStatus: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	allUpToDate := true
+	for _, status := range statuses {
+		if !status.UpToDate {
+			allUpToDate = false
+		}
+	}
+
+	c.IndentedJSON(200, gin.H{
+		"station_name":     stationName.Ext(),
+		"schema_name":      station.Schema.SchemaName,
+		"active_version":   station.Schema.VersionNumber,
+		"sampled_messages": amount,
+		"all_up_to_date":   allUpToDate,
+		"producers":        statuses,
+	})
+}
+
+// GetStationSchemaSkew counts the active producers still producing with a schema version older than the station's one,
+// upgrading the schema again while some producers lag behind risks breaking them
+func (sh StationsHandler) GetStationSchemaSkew(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetStationProducersSchemaStatusSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	stationName, station, ok := getSchemaStatusStation(c, ctx, &body, "GetStationSchemaSkew")
+	if !ok {
+		return
+	}
+	if station.Schema.SchemaName == "" {
+		errMsg := "Station " + stationName.Ext() + " has no schema attached"
+		serv.Warnf("GetStationSchemaSkew: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeSchemaMissing})
+		return
+	}
+
+	statuses, amount, err := sh.getProducersSchemaStatus(ctx, stationName, station, body.SampleSize)
+	if err != nil {
+		serv.Errorf("GetStationSchemaSkew: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	skew := getSchemaSkew(statuses, station.Schema.VersionNumber)
+
+	c.IndentedJSON(200, gin.H{
+		"station_name":     stationName.Ext(),
+		"schema_name":      station.Schema.SchemaName,
+		"active_version":   station.Schema.VersionNumber,
+		"sampled_messages": amount,
+		"skew":             skew,
+	})
+}
+
+// getSchemaSkew sorts the producers by the schema version they produce with compared to the active one,
+// producers without a sampled message or a version header are counted as unknown, producers on a version newer
+// than the active one (e.g. after a rollback) are reported apart as they are not up to date either
+func getSchemaSkew(statuses []models.ProducerSchemaStatus, activeVersion int) models.SchemaSkew {
+	skew := models.SchemaSkew{Versions: make(map[string]int), LaggingProducers: []string{}, AheadProducers: []string{}}
+	for _, status := range statuses {
+		skew.ActiveProducers++
+		skew.Versions[status.SchemaVersion]++
+		version, err := strconv.Atoi(status.SchemaVersion)
+		switch {
+		case err != nil:
+			skew.UnknownVersion++
+		case version < activeVersion:
+			skew.OlderVersions++
+			skew.LaggingProducers = append(skew.LaggingProducers, status.Name)
+		case version > activeVersion:
+			skew.NewerVersions++
+			skew.AheadProducers = append(skew.AheadProducers, status.Name)
+		default:
+			skew.CurrentVersion++
+		}
+	}
+	return skew
+}
+
+// GetPoisonMessageTrend returns the series of poison messages per interval over the last hours,
+// so it can be seen whether failures are increasing or being resolved
+func (sh StationsHandler) GetPoisonMessageTrend(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetPoisonMessageTrendSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	if body.Hours <= 0 {
+		body.Hours = 24 // default
+	}
+	if body.IntervalMinutes <= 0 {
+		body.IntervalMinutes = 60 // default
+	}
+	if body.Hours > 168 {
+		errMsg := "hours can not exceed 168 (7 days)"
+		serv.Warnf("GetPoisonMessageTrend: Station " + body.StationName + ": " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+	bucketsAmount := body.Hours * 60 / body.IntervalMinutes
+	if bucketsAmount < 1 || bucketsAmount > maxPoisonTrendBuckets {
+		errMsg := "the interval has to split the hours into 1 to " + strconv.Itoa(maxPoisonTrendBuckets) + " buckets"
+		serv.Warnf("GetPoisonMessageTrend: Station " + body.StationName + ": " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("GetPoisonMessageTrend: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("GetPoisonMessageTrend: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("GetPoisonMessageTrend: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	// buckets are aligned to the interval, the last one holds the current time
+	interval := time.Duration(body.IntervalMinutes) * time.Minute
+	to := time.Now().Truncate(interval).Add(interval)
+	from := to.Add(-time.Duration(bucketsAmount) * interval)
+
+	poisonMsgsHandler := PoisonMessagesHandler{S: sh.S}
+	buckets, err := poisonMsgsHandler.GetPoisonMsgsTrendByStation(station, from, interval, bucketsAmount)
+	if err != nil {
+		serv.Errorf("GetPoisonMessageTrend: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	total := 0
+	for _, bucket := range buckets {
+		total += bucket.Count
+	}
+
+	c.IndentedJSON(200, gin.H{
+		"station_name":        stationName.Ext(),
+		"interval_in_minutes": body.IntervalMinutes,
+		"total":               total,
+		"buckets":             buckets,
+	})
+}
+
+// GetStationPoisonByCg returns the station's poison messages count per consumer group in one call,
+// the groups generating the most failures first
+func (sh StationsHandler) GetStationPoisonByCg(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetStationPoisonByCgSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("GetStationPoisonByCg: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("GetStationPoisonByCg: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("GetStationPoisonByCg: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	poisonMsgsHandler := PoisonMessagesHandler{S: sh.S}
+	counts, total, err := poisonMsgsHandler.GetPoisonMsgsCountByCg(station)
+	if err != nil {
+		serv.Errorf("GetStationPoisonByCg: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	// the station's current consumer groups are listed even with no poison messages
+	cgs, err := consumersCollection.Distinct(ctx, "consumers_group", bson.M{"station_id": station.ID, "is_deleted": false})
+	if err != nil {
+		serv.Errorf("GetStationPoisonByCg: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	for _, cg := range cgs {
+		cgName, ok := cg.(string)
+		if !ok {
+			continue
+		}
+		if _, ok := counts[cgName]; !ok {
+			counts[cgName] = 0
+		}
+	}
+
+	c.IndentedJSON(200, gin.H{
+		"station_name":    stationName.Ext(),
+		"total":           total,
+		"consumer_groups": sortCgPoisonCounts(counts),
+	})
+}
+
+// sortCgPoisonCounts orders the per consumer group counts by the amount of poison messages, then by name
+func sortCgPoisonCounts(counts map[string]int) []models.CgPoisonCount {
+	cgCounts := make([]models.CgPoisonCount, 0, len(counts))
+	for cgName, count := range counts {
+		cgCounts = append(cgCounts, models.CgPoisonCount{CgName: cgName, PoisonMessages: count})
+	}
+	sort.Slice(cgCounts, func(i, j int) bool {
+		if cgCounts[i].PoisonMessages != cgCounts[j].PoisonMessages {
+			return cgCounts[i].PoisonMessages > cgCounts[j].PoisonMessages
+		}
+		return cgCounts[i].CgName < cgCounts[j].CgName
+	})
+	return cgCounts
+}
+
+// filterDlsMessages keeps the dead letters matching the given consumer group, reason and creation time range,
+// empty filters match everything and a consumer group filter never matches schema failures
+func filterDlsMessages(msgs []models.DlsMessageResponse, cgName, reason string, from, to time.Time) []models.DlsMessageResponse {
+	filtered := make([]models.DlsMessageResponse, 0, len(msgs))
+	for _, msg := range msgs {
+		if reason != "" && !strings.EqualFold(msg.Reason, reason) {
+			continue
+		}
+		if !from.IsZero() && msg.CreationDate.Before(from) {
+			continue
+		}
+		if !to.IsZero() && msg.CreationDate.After(to) {
+			continue
+		}
+		if cgName != "" {
+			poisonedCg := false
+			for _, cg := range msg.PoisonedCgs {
+				if cg.CgName == cgName {
+					poisonedCg = true
+					break
+				}
+			}
+			if !poisonedCg {
+				continue
+			}
+		}
+		filtered = append(filtered, msg)
+	}
+	return filtered
+}
+
+// GetAllPoisonMessages returns the poison and schema failed messages of all the stations, newest first and a page at a time,
+// stations whose dead letters could not be read are listed as unavailable instead of failing the request
+func (sh StationsHandler) GetAllPoisonMessages(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetAllPoisonMessagesSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	if body.PageSize == 0 {
+		body.PageSize = defaultPoisonMessagesPage
+	} else if body.PageSize > maxPoisonMessagesPage {
+		errMsg := "page size can not exceed " + strconv.Itoa(maxPoisonMessagesPage)
+		serv.Warnf("GetAllPoisonMessages: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+	if !body.From.IsZero() && !body.To.IsZero() && body.To.Before(body.From) {
+		errMsg := "to has to be after from"
+		serv.Warnf("GetAllPoisonMessages: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+
+	var stations []models.Station
+	if body.StationName != "" {
+		stationName, err := StationNameFromStr(body.StationName)
+		if err != nil {
+			serv.Warnf("GetAllPoisonMessages: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+			return
+		}
+		exist, station, err := IsStationExistWithContext(ctx, stationName)
+		if err != nil {
+			serv.Errorf("GetAllPoisonMessages: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+		if !exist {
+			errMsg := "Station " + body.StationName + " does not exist"
+			serv.Warnf("GetAllPoisonMessages: " + errMsg)
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+			return
+		}
+		stations = append(stations, station)
+	} else {
+		filter := bson.M{"$or": []interface{}{
+			bson.M{"is_deleted": bson.M{"$exists": false}},
+			bson.M{"is_deleted": false},
+		}}
+		cursor, err := stationsCollection.Find(ctx, filter)
+		if err != nil {
+			serv.Errorf("GetAllPoisonMessages: " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+		if err = cursor.All(ctx, &stations); err != nil {
+			serv.Errorf("GetAllPoisonMessages: " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+	}
+
+	poisonMsgsHandler := PoisonMessagesHandler{S: sh.S}
+	messages := make([]models.DlsMessageResponse, 0)
+	unavailableStations := make([]string, 0)
+	var lock sync.Mutex
+	wg := sync.WaitGroup{}
+	workers := make(chan struct{}, poisonMessagesFetchWorkers)
+	for _, station := range stations {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(station models.Station) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			poisonMessages, schemaMessages, err := poisonMsgsHandler.GetDlsMsgsByStationFull(station)
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				serv.Warnf("GetAllPoisonMessages: Station " + station.Name + ": " + err.Error())
+				unavailableStations = append(unavailableStations, station.Name)
+				return
+			}
+			messages = append(messages, filterDlsMessages(poisonMessages, body.CgName, body.Reason, body.From, body.To)...)
+			messages = append(messages, filterDlsMessages(schemaMessages, body.CgName, body.Reason, body.From, body.To)...)
+		}(station)
+	}
+	wg.Wait()
+
+	sort.Slice(messages, func(i, j int) bool {
+		if messages[i].CreationDate.Equal(messages[j].CreationDate) {
+			return messages[i].ID < messages[j].ID
+		}
+		return messages[i].CreationDate.After(messages[j].CreationDate)
+	})
+	sort.Strings(unavailableStations)
+
+	total := len(messages)
+	start := body.Page * body.PageSize
+	if start > total {
+		start = total
+	}
+	end := start + body.PageSize
+	if end > total {
+		end = total
+	}
+
+	c.IndentedJSON(200, gin.H{
+		"poison_messages":      messages[start:end],
+		"page":                 body.Page,
+		"page_size":            body.PageSize,
+		"total":                total,
+		"unavailable_stations": unavailableStations,
+	})
+}
+
+func (sh StationsHandler) GetPoisonMessageJourney(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetPoisonMessageJourneySchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	_, _, err := parseDlsMsgId(body.MessageId)
+	if err != nil {
+		serv.Warnf("GetPoisonMessageJourney: " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	poisonMessage, err := sh.GetDlsMessageJourneyDetails(ctx, body.MessageId)
+	if err != nil {
+		serv.Errorf("GetPoisonMessageJourney: " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategoryBrowse)
+	if shouldSendAnalytics {
+		user, _ := getUserDetailsFromMiddleware(c)
+		analytics.SendEvent(user.Username, "user-enter-message-journey")
+	}
+
+	c.IndentedJSON(200, poisonMessage)
+}
+
+// getDlsMessageRaw reads a single DLS message by its id straight from the subject it is stored on,
+// with none of the consumer groups enrichment of GetDlsMessageJourneyDetails
+func (sh StationsHandler) getDlsMessageRaw(station models.Station, dlsMsgId string) (models.DlsMessageResponse, bool, error) {
+	streamName, _, err := getStationDlsLocation(station)
+	if err != nil {
+		return models.DlsMessageResponse{}, false, err
+	}
+
+	for _, msgType := range []string{"poison", "schema"} {
+		subject, err := getStationDlsSubject(station, msgType, dlsMsgId)
+		if err != nil {
+			return models.DlsMessageResponse{}, false, err
+		}
+		msg, err := sh.S.memphisGetLastMessageBySubject(streamName, subject)
+		if IsNatsErr(err, JSNoMessageFoundErr) {
+			continue
+		}
+		if err != nil {
+			return models.DlsMessageResponse{}, false, err
+		}
+
+		var dlsMsg models.DlsMessage
+		err = json.Unmarshal(msg.Data, &dlsMsg)
+		if err != nil {
+			return models.DlsMessageResponse{}, false, err
+		}
+		if msgType == "poison" {
+			for header := range dlsMsg.Message.Headers {
+				if strings.HasPrefix(header, "$memphis") {
+					delete(dlsMsg.Message.Headers, header)
+				}
+			}
+		}
+		return models.DlsMessageResponse{
+			ID:           dlsMsgId,
+			StationName:  dlsMsg.StationName,
+			MessageSeq:   dlsMsg.MessageSeq,
+			Producer:     dlsMsg.Producer,
+			Message:      dlsMsg.Message,
+			CreationDate: dlsMsg.CreationDate,
+			PoisonedCgs:  []models.PoisonedCg{},
+			AgeInDls:     int64(time.Since(msg.Time).Seconds()),
+			Reason:       getDlsMsgReason(dlsMsg, msgType, stationSchemaFailureReasons(station)),
+		}, true, nil
+	}
+
+	return models.DlsMessageResponse{}, false, nil
+}
+
+// GetDlsMessageRaw returns a DLS message's payload, headers and producer for a quick inspection,
+// unlike GetPoisonMessageJourney it does not look up the consumer groups the message poisoned
+func (sh StationsHandler) GetDlsMessageRaw(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetPoisonMessageJourneySchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	msgId := strings.ReplaceAll(body.MessageId, " ", "+")
+	sn, _, err := parseDlsMsgId(msgId)
+	if err != nil {
+		serv.Warnf("GetDlsMessageRaw: " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, sn)
+	if err != nil {
+		serv.Errorf("GetDlsMessageRaw: Station " + sn.Ext() + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + sn.Ext() + " does not exist"
+		serv.Warnf("GetDlsMessageRaw: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	dlsMessage, found, err := sh.getDlsMessageRaw(station, msgId)
+	if err != nil {
+		serv.Errorf("GetDlsMessageRaw: Station " + sn.Ext() + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !found {
+		errMsg := "Message " + msgId + " does not exist in the DLS of station " + sn.Ext()
+		serv.Warnf("GetDlsMessageRaw: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeMessageNotFound})
+		return
+	}
+
+	c.IndentedJSON(200, dlsMessage)
+}
+
+func (sh StationsHandler) AckPoisonMessages(c *gin.Context) {
+	var body models.AckPoisonMessagesSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+	batchSize, err := resolveDlsFetchBatchSize(body.FetchBatchSize)
+	if err != nil {
+		serv.Warnf("AckPoisonMessages: " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+	timeout := 1 * time.Second
+	splitId := strings.Split(body.PoisonMessageIds[0], dlsMsgSep)
+	stationName := splitId[0]
+	sn, err := StationNameFromStr(stationName)
+	if err != nil {
+		serv.Errorf("AckPoisonMessages: " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	station, err := getDlsStation(sn)
+	if err != nil {
+		serv.Errorf("AckPoisonMessages: " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	streamName, _, err := getStationDlsLocation(station)
+	if err != nil {
+		serv.Errorf("AckPoisonMessages: " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	for _, msgId := range body.PoisonMessageIds {
+		filter, err := getStationDlsSubject(station, "poison", msgId)
+		if err != nil {
+			serv.Errorf("AckPoisonMessages: " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+		err = sh.S.fetchDlsMsgs(streamName, filter, batchSize, timeout, func(msgs []StoredMsg) error {
+			for _, msg := range msgs {
+				_, err := sh.S.memphisDeleteMsgFromStream(streamName, msg.Sequence)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			serv.Errorf("AckPoisonMessages: " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+	}
+
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategoryPoison)
+	if shouldSendAnalytics {
+		user, _ := getUserDetailsFromMiddleware(c)
+		analytics.SendEvent(user.Username, "user-ack-poison-message")
+	}
+
+	c.IndentedJSON(200, gin.H{})
+}
+
+// startResendJob registers an async resend job, the amount of jobs running on this broker is bounded so resends of large DLS backlogs can not pile up.
+// jobs are stored in the db so every broker of the cluster can report their progress
+func (s *Server) startResendJob(stationName string, totalIds int, username string) (primitive.ObjectID, error) {
+	s.memphis.resendJobsMu.Lock()
+	defer s.memphis.resendJobsMu.Unlock()
+
+	_, err := resendJobsCollection.DeleteMany(context.TODO(), bson.M{"finished_at": bson.M{"$lt": time.Now().Add(-resendJobRetention)}})
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	running, err := resendJobsCollection.CountDocuments(context.TODO(), bson.M{"broker": s.memphis.serverID, "status": "running"})
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	if running >= maxRunningResendJobs {
+		return primitive.NilObjectID, ErrTooManyResendJobs
+	}
+
+	job := models.ResendJob{
+		ID:           primitive.NewObjectID(),
+		StationName:  stationName,
+		Broker:       s.memphis.serverID,
+		Status:       "running",
+		TotalIds:     totalIds,
+		CreatedBy:    username,
+		CreationDate: time.Now(),
+	}
+	_, err = resendJobsCollection.InsertOne(context.TODO(), job)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return job.ID, nil
+}
+
+func updateResendJob(jobId primitive.ObjectID, update bson.M) error {
+	_, err := resendJobsCollection.UpdateOne(context.TODO(), bson.M{"_id": jobId}, update)
+	return err
+}
+
+func getResendJob(ctx context.Context, jobId string) (bool, models.ResendJob, error) {
+	id, err := primitive.ObjectIDFromHex(jobId)
+	if err != nil {
+		return false, models.ResendJob{}, nil
+	}
+	var job models.ResendJob
+	err = resendJobsCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&job)
+	if err == mongo.ErrNoDocuments {
+		return false, models.ResendJob{}, nil
+	} else if err != nil {
+		return false, models.ResendJob{}, err
+	}
+	return true, job, nil
+}
+
+// failInterruptedResendJobs marks the jobs this broker was running before it restarted as failed, nothing is left to finish them
+func (s *Server) failInterruptedResendJobs() {
+	_, err := resendJobsCollection.UpdateMany(context.TODO(),
+		bson.M{"broker": s.memphis.serverID, "status": "running"},
+		bson.M{"$set": bson.M{"status": "failed", "error": "The job was interrupted by a broker restart", "finished_at": time.Now()}},
+	)
+	if err != nil {
+		s.Errorf("failInterruptedResendJobs: " + err.Error())
+	}
+}
+
+// resendPoisonMsgs resends the poison messages of the given ids to their poisoned consumer groups,
+// progress is called after each id with the amount of messages resent for it
+func (sh StationsHandler) resendPoisonMsgs(station models.Station, msgIds []string, stripHeaders map[string]bool, batchSize uint64, progress func(resent int)) error {
+	timeout := 1 * time.Second
+	streamName, _, err := getStationDlsLocation(station)
+	if err != nil {
+		return err
+	}
+	for _, msgId := range msgIds {
+		filter, err := getStationDlsSubject(station, "poison", msgId)
+		if err != nil {
+			return err
+		}
+		resent := 0
+		err = sh.S.fetchDlsMsgs(streamName, filter, batchSize, timeout, func(msgs []StoredMsg) error {
+			for _, msg := range msgs {
+				var dlsMsg models.DlsMessage
+				err := json.Unmarshal(msg.Data, &dlsMsg)
+				if err != nil {
+					return err
+				}
+				stationName := replaceDelimiters(dlsMsg.StationName)
+				cgName := replaceDelimiters(dlsMsg.PoisonedCg.CgName)
+				headersJson := map[string]string{}
+				for key, value := range dlsMsg.Message.Headers {
+					if stripHeaders[strings.ToLower(key)] {
+						continue
+					}
+					headersJson[key] = value
+				}
+				headersJson["$memphis_pm_id"] = dlsMsg.ID
+				headersJson["$memphis_pm_sequence"] = strconv.FormatUint(msg.Sequence, 10)
+				headers, err := json.Marshal(headersJson)
+				if err != nil {
+					return fmt.Errorf("Poisoned consumer group: %v: %v", dlsMsg.PoisonedCg.CgName, err.Error())
+				}
+				data, err := hex.DecodeString(dlsMsg.Message.Data)
+				if err != nil {
+					return fmt.Errorf("Poisoned consumer group: %v: %v", dlsMsg.PoisonedCg.CgName, err.Error())
+				}
+				err = sh.S.ResendPoisonMessage("$memphis_dls_"+stationName+"_"+cgName, []byte(data), headers)
+				if err != nil {
+					return fmt.Errorf("Poisoned consumer group: %v: %v", dlsMsg.PoisonedCg.CgName, err.Error())
+				}
+				resent++
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if progress != nil {
+			progress(resent)
+		}
+	}
+	return nil
+}
+
+func (sh StationsHandler) ResendPoisonMessages(c *gin.Context) {
+	var body models.ResendPoisonMessagesSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+	// headers are matched case insensitively, the memphis headers are needed to track the resent message
+	stripHeaders := make(map[string]bool)
+	for _, header := range body.StripHeaders {
+		if strings.HasPrefix(header, "$memphis") {
+			errMsg := "Memphis headers can not be stripped from resent messages"
+			serv.Warnf("ResendPoisonMessages: " + errMsg)
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+			return
+		}
+		stripHeaders[strings.ToLower(header)] = true
+	}
+	batchSize, err := resolveDlsFetchBatchSize(body.FetchBatchSize)
+	if err != nil {
+		serv.Warnf("ResendPoisonMessages: " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+	splitId := strings.Split(body.PoisonMessageIds[0], dlsMsgSep)
+	stationName := splitId[0]
+	sn, err := StationNameFromStr(stationName)
+	if err != nil {
+		serv.Errorf("ResendPoisonMessages: " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	station, err := getDlsStation(sn)
+	if err != nil {
+		serv.Errorf("ResendPoisonMessages: " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	// the resent messages would be rejected by the station
+	if station.ReadOnly {
+		errMsg := "Poison messages can not be resent to station " + sn.Ext() + ", " + ErrStationReadOnly.Error()
+		serv.Warnf("ResendPoisonMessages: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+
+	user, _ := getUserDetailsFromMiddleware(c)
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategoryPoison)
+	if body.Async {
+		jobId, err := sh.S.startResendJob(sn.Ext(), len(body.PoisonMessageIds), user.Username)
+		if err == ErrTooManyResendJobs {
+			serv.Warnf("ResendPoisonMessages: Station " + sn.Ext() + ": " + err.Error())
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+			return
+		} else if err != nil {
+			serv.Errorf("ResendPoisonMessages: Station " + sn.Ext() + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+		go func() {
+			err := sh.resendPoisonMsgs(station, body.PoisonMessageIds, stripHeaders, batchSize, func(resent int) {
+				err := updateResendJob(jobId, bson.M{"$inc": bson.M{"processed_ids": 1, "resent_messages": resent}})
+				if err != nil {
+					serv.Warnf("ResendPoisonMessages: Job " + jobId.Hex() + ": " + err.Error())
+				}
+			})
+			update := bson.M{"status": "completed", "finished_at": time.Now()}
+			if err != nil {
+				serv.Errorf("ResendPoisonMessages: Job " + jobId.Hex() + ": " + err.Error())
+				update["status"] = "failed"
+				update["error"] = "Server error"
+			}
+			err = updateResendJob(jobId, bson.M{"$set": update})
+			if err != nil {
+				serv.Errorf("ResendPoisonMessages: Job " + jobId.Hex() + ": " + err.Error())
+			}
+		}()
+
+		if shouldSendAnalytics {
+			analytics.SendEvent(user.Username, "user-resend-poison-message")
+		}
+		c.IndentedJSON(200, gin.H{"job_id": jobId.Hex()})
+		return
+	}
+
+	err = sh.resendPoisonMsgs(station, body.PoisonMessageIds, stripHeaders, batchSize, nil)
+	if err != nil {
+		serv.Errorf("ResendPoisonMessages: " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	if shouldSendAnalytics {
+		analytics.SendEvent(user.Username, "user-resend-poison-message")
+	}
+
+	c.IndentedJSON(200, gin.H{})
+}
+
+// GetResendJobStatus returns the progress of an async resend job, the job is read from the db so any broker can answer
+func (sh StationsHandler) GetResendJobStatus(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetResendJobStatusSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	exist, job, err := getResendJob(ctx, body.JobId)
+	if err != nil {
+		serv.Errorf("GetResendJobStatus: Job " + body.JobId + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Resend job " + body.JobId + " does not exist"
+		serv.Warnf("GetResendJobStatus: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+
+	c.IndentedJSON(200, job)
+}
+
+// reprocessedMsgHeaders returns the headers a schema failed message is reproduced with,
+// messages produced without the Memphis headers are stamped so a station requiring them accepts the message
+func reprocessedMsgHeaders(dlsMsg models.DlsMessage) map[string]string {
+	headers := map[string]string{}
+	for key, value := range dlsMsg.Message.Headers {
+		headers[key] = value
+	}
+	stampMemphisProducerHeaders(headers, "$memphis_dls")
+	return headers
+}
+
+// reprocessedMsgSubject returns the subject a reprocessed message is produced on,
+// the key of a keyed station's message is taken from its compaction key header
+func reprocessedMsgSubject(sn StationName, station models.Station, headers map[string]string) (string, error) {
+	subject := stationProduceSubject(sn, station)
+	if !isKeyedRetention(station.RetentionType) {
+		return subject, nil
+	}
+	key := headers[station.CompactionKey]
+	if key == "" || strings.ContainsAny(key, ". *>") {
+		return "", errors.New("the message has no valid " + station.CompactionKey + " header to be keyed by")
+	}
+	return strings.Replace(subject, "<"+station.CompactionKey+">", key, 1), nil
+}
+
+func (sh StationsHandler) ReprocessSchemaFailedMessages(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.ReprocessSchemaFailedMessagesSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("ReprocessSchemaFailedMessages: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("ReprocessSchemaFailedMessages: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("ReprocessSchemaFailedMessages: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+	if !station.IsNative {
+		errMsg := "Schema failed messages can not be reprocessed in the non native station " + stationName.Ext()
+		serv.Warnf("ReprocessSchemaFailedMessages: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+	// the reprocessed messages would be rejected by the station and lost once removed from the DLS
+	if station.ReadOnly {
+		errMsg := "Schema failed messages can not be reprocessed in station " + stationName.Ext() + ", " + ErrStationReadOnly.Error()
+		serv.Warnf("ReprocessSchemaFailedMessages: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+
+	dlsStream, _, err := getStationDlsLocation(station)
+	if err != nil {
+		serv.Errorf("ReprocessSchemaFailedMessages: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	streamInfo, err := sh.S.memphisStreamInfo(dlsStream)
+	if err != nil {
+		serv.Errorf("ReprocessSchemaFailedMessages: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	// the messages are validated against the version the station uses, as its producers would
+	var schema models.Schema
+	var schemaVersion models.SchemaVersion
+	if station.Schema.SchemaName != "" {
+		var exist bool
+		exist, schema, err = IsSchemaExist(station.Schema.SchemaName)
+		if err != nil {
+			serv.Errorf("ReprocessSchemaFailedMessages: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+		if !exist {
+			errMsg := "Schema " + station.Schema.SchemaName + " of station " + stationName.Ext() + " does not exist"
+			serv.Warnf("ReprocessSchemaFailedMessages: " + errMsg)
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeSchemaMissing})
+			return
+		}
+		schemaVersion, err = SchemasHandler{}.GetSchemaVersion(station.Schema.VersionNumber, schema.ID)
+		if err != nil {
+			serv.Errorf("ReprocessSchemaFailedMessages: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+	}
+
+	user, err := getUserDetailsFromMiddleware(c)
+	if err != nil {
+		serv.Errorf("ReprocessSchemaFailedMessages: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(401, gin.H{"message": "Unauthorized", "code": ErrCodeUnauthorized})
+		return
+	}
+
+	reprocessed := 0
+	kept := 0
+	if streamInfo.State.Msgs > 0 {
+		filter, err := getStationDlsSubject(station, "schema", ">")
+		if err != nil {
+			serv.Errorf("ReprocessSchemaFailedMessages: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+		msgs, err := sh.S.memphisGetMsgs(filter, dlsStream, streamInfo.State.FirstSeq, int(streamInfo.State.Msgs), 3*time.Second, false)
+		if err != nil {
+			serv.Errorf("ReprocessSchemaFailedMessages: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+
+		for _, msg := range msgs {
+			var dlsMsg models.DlsMessage
+			err = json.Unmarshal(msg.Data, &dlsMsg)
+			if err != nil {
+				serv.Errorf("ReprocessSchemaFailedMessages: Station " + body.StationName + ": " + err.Error())
+				c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+				return
+			}
+			data, err := hex.DecodeString(dlsMsg.Message.Data)
+			if err != nil {
+				serv.Errorf("ReprocessSchemaFailedMessages: Station " + body.StationName + ": Message ID " + dlsMsg.ID + ": " + err.Error())
+				c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+				return
+			}
+			headers := reprocessedMsgHeaders(dlsMsg)
+
+			// messages still failing the schema or lacking their key stay in the DLS
+			if station.Schema.SchemaName != "" {
+				validationErrs, err := validateMessageAgainstSchema(schema.Type, schemaVersion, data)
+				if err != nil {
+					serv.Errorf("ReprocessSchemaFailedMessages: Station " + body.StationName + ": Schema " + schema.Name + ": " + err.Error())
+					c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+					return
+				}
+				if len(validationErrs) > 0 {
+					kept++
+					continue
+				}
+			}
+			subject, err := reprocessedMsgSubject(stationName, station, headers)
+			if err != nil {
+				serv.Warnf("ReprocessSchemaFailedMessages: Station " + body.StationName + ": Message ID " + dlsMsg.ID + ": " + err.Error())
+				kept++
+				continue
+			}
+
+			// the message goes back to the station as if it was just produced, with its original headers
+			sh.S.sendInternalMsgWithHeaderLocked(sh.S.GlobalAccount(), subject, headers, data)
+			_, err = sh.S.memphisDeleteMsgFromStream(dlsStream, msg.Sequence)
+			if err != nil {
+				serv.Errorf("ReprocessSchemaFailedMessages: Station " + body.StationName + ": Message ID " + dlsMsg.ID + ": " + err.Error())
+				c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+				return
+			}
+			reprocessed++
+		}
+	}
+
+	if reprocessed > 0 || kept > 0 {
+		message := strconv.Itoa(reprocessed) + " schema failed messages of station " + stationName.Ext() + " have been reprocessed by user " + user.Username
+		if kept > 0 {
+			message += ", " + strconv.Itoa(kept) + " messages still fail the schema or lack their key and have been kept in the DLS"
+		}
+		serv.Noticef(message)
+		var auditLogs []interface{}
+		newAuditLog := models.AuditLog{
+			ID:            primitive.NewObjectID(),
+			StationName:   stationName.Ext(),
+			Message:       message,
+			CreatedByUser: user.Username,
+			CreationDate:  time.Now(),
+			UserType:      user.UserType,
+		}
+		auditLogs = append(auditLogs, newAuditLog)
+		err = CreateAuditLogs(auditLogs)
+		if err != nil {
+			serv.Warnf("ReprocessSchemaFailedMessages: Station " + body.StationName + " - create audit logs error: " + err.Error())
+		}
+	}
+
+	c.IndentedJSON(200, gin.H{"reprocessed_messages": reprocessed, "kept_messages": kept})
+}
+
+func (sh StationsHandler) GetMessageDetails(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetMessageDetailsSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	if body.IsPoisonMessage {
+		_, _, err := parseDlsMsgId(body.MessageId)
+		if err != nil {
+			serv.Warnf("GetMessageDetails: Message ID: " + body.MessageId + ": " + err.Error())
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+			return
+		}
+
+		poisonMessage, err := sh.GetDlsMessageJourneyDetails(ctx, body.MessageId)
+		if err != nil {
+			serv.Errorf("GetMessageDetails: Message ID: " + body.MessageId + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+
+		c.IndentedJSON(200, poisonMessage)
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("GetMessageDetails: Message ID: " + body.MessageId + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if !exist {
+		errMsg := "Station " + stationName.external + " does not exist"
+		serv.Warnf("GetMessageDetails: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+	if err != nil {
+		serv.Errorf("GetMessageDetails: Message ID: " + body.MessageId + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	msg, err := sh.getMessageBySeq(ctx, station, stationName, body.MessageSeq, nil)
+	if err == ErrMissingMsgHeaders {
+		serv.Warnf("GetMessageDetails: " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+	if IsNatsErr(err, JSNoMessageFoundErr) {
+		errMsg := "Message sequence " + strconv.Itoa(body.MessageSeq) + " no longer exists in station " + stationName.Ext()
+		serv.Warnf("GetMessageDetails: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeMessageNotFound})
+		return
+	}
+	if err != nil {
+		serv.Errorf("GetMessageDetails: Message ID: " + body.MessageId + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if body.Decode {
+		msg.Message.Decoded, err = decodeStationMessage(station, msg.Message.SchemaVersion, msg.Message.Data)
+		if err != nil {
+			msg.Message.DecodeError = err.Error()
+		}
+	}
+	c.IndentedJSON(200, msg)
+}
+
+// messageSchemaVersion returns the schema version the producer stamped on the message, 0 when it is missing or invalid
+func messageSchemaVersion(headers map[string]string) int {
+	version, err := strconv.Atoi(headers[schemaVersionHeader])
+	if err != nil || version <= 0 {
+		return 0
+	}
+	return version
+}
+
+// decodeStationMessage decodes a hex payload with the schema version the message was produced with, messages without
+// a version fall back to the version attached to the station. it is best effort as the message may have been produced without a schema
+func decodeStationMessage(station models.Station, versionNumber int, hexData string) (json.RawMessage, error) {
+	if station.Schema.SchemaName == "" {
+		return nil, errors.New("no schema is attached to the station")
+	}
+	payload, err := hex.DecodeString(hexData)
+	if err != nil {
+		return nil, err
+	}
+	exist, schema, err := IsSchemaExist(station.Schema.SchemaName)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, schemaNotFoundError(station.Schema.SchemaName)
+	}
+	if versionNumber <= 0 {
+		versionNumber = station.Schema.VersionNumber
+	}
+	schemaVersion, err := getStationSchemaVersion(schema, versionNumber)
+	if err != nil {
+		return nil, err
+	}
+	return decodeMessageWithSchema(schema.Type, schemaVersion, payload)
+}
+
+// getMessageDisposition tells what happened to a message of the station: live, in_dls when only its DLS copy is left,
+// trimmed by the retention, deleted or not_produced when the sequence is beyond the last message of the station
+func (sh StationsHandler) getMessageDisposition(station models.Station, sn StationName, messageSeq uint64) (string, error) {
+	streamInfo, err := sh.S.memphisStreamInfo(sn.Intern())
+	if err != nil {
+		return "", err
+	}
+	if messageSeq > streamInfo.State.LastSeq {
+		return "not_produced", nil
+	}
+	if messageSeq >= streamInfo.State.FirstSeq {
+		_, err = sh.S.GetMessage(sn, messageSeq)
+		if err == nil {
+			return "live", nil
+		}
+		if !IsNatsErr(err, JSNoMessageFoundErr) {
+			return "", err
+		}
+	}
+
+	poisonMsgsHandler := PoisonMessagesHandler{S: sh.S}
+	poisonMessages, schemaFailedMessages, err := poisonMsgsHandler.GetDlsMsgsByStationLight(station)
+	if err != nil {
+		return "", err
+	}
+	for _, dlsMsg := range append(poisonMessages, schemaFailedMessages...) {
+		if uint64(dlsMsg.MessageSeq) == messageSeq {
+			return "in_dls", nil
+		}
+	}
+
+	// keyed stations drop the older messages of a key from the middle of the stream
+	if messageSeq < streamInfo.State.FirstSeq || isKeyedRetention(station.RetentionType) {
+		return "trimmed", nil
+	}
+	return "deleted", nil
+}
+
+func (sh StationsHandler) MessageExists(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.MessageExistsSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("MessageExists: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("MessageExists: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("MessageExists: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	disposition, err := sh.getMessageDisposition(station, stationName, uint64(body.MessageSeq))
+	if err != nil {
+		serv.Errorf("MessageExists: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	c.IndentedJSON(200, gin.H{
+		"station_name": stationName.Ext(),
+		"message_seq":  body.MessageSeq,
+		"exists":       disposition == "live",
+		"disposition":  disposition,
+	})
+}
+
+// messageDetailsCache shares consumer group, producer and connection lookups
+// between messages of the same station when fetching details in batch
+type messageDetailsCache struct {
+	cgs         map[string]models.PoisonedCg
+	producers   map[string]models.Producer
+	connections map[primitive.ObjectID]models.Connection
+}
+
+func newMessageDetailsCache() *messageDetailsCache {
+	return &messageDetailsCache{
+		cgs:         make(map[string]models.PoisonedCg),
+		producers:   make(map[string]models.Producer),
+		connections: make(map[primitive.ObjectID]models.Connection),
+	}
+}
+
+func (sh StationsHandler) getMessageBySeq(ctx context.Context, station models.Station, stationName StationName, messageSeq int, cache *messageDetailsCache) (models.MessageResponse, error) {
+	sm, err := sh.S.GetMessage(stationName, uint64(messageSeq))
+	if err != nil {
+		return models.MessageResponse{}, err
+	}
+
+	if !station.IsNative {
+		msg := models.MessageResponse{
+			MessageSeq: messageSeq,
+			Message: models.MessagePayload{
+				TimeSent: sm.Time,
+				Size:     len(sm.Subject) + len(sm.Data) + len(sm.Header),
+				Data:     hex.EncodeToString(sm.Data),
+				Headers:  map[string]string{},
+			},
+			Producer: models.ProducerDetails{
+				Name:          "",
+				ConnectionId:  primitive.ObjectID{},
+				ClientAddress: "",
+				CreatedByUser: "",
+				IsActive:      false,
+				IsDeleted:     false,
+			},
+			PoisonedCgs: []models.PoisonedCg{},
+		}
+		return msg, nil
+	}
+	headersJson, err := DecodeHeader(sm.Header)
+	if err != nil {
+		return models.MessageResponse{}, err
+	}
+
+	connectionIdHeader := headersJson["$memphis_connectionId"]
+	producedByHeader := strings.ToLower(headersJson["$memphis_producedBy"])
+
+	//This check for backward compatability
+	if connectionIdHeader == "" || producedByHeader == "" {
+		connectionIdHeader = headersJson["connectionId"]
+		producedByHeader = strings.ToLower(headersJson["producedBy"])
+		if connectionIdHeader == "" || producedByHeader == "" {
+			return models.MessageResponse{}, ErrMissingMsgHeaders
+		}
+	}
+
+	schemaVersion := messageSchemaVersion(headersJson)
+	for header := range headersJson {
+		if strings.HasPrefix(header, "$memphis") {
+			delete(headersJson, header)
+		}
+	}
+
+	connectionId, _ := primitive.ObjectIDFromHex(connectionIdHeader)
+	poisonedCgs, err := GetPoisonedCgsByMessage(stationName.Intern(), models.MessageDetails{MessageSeq: int(sm.Sequence), ProducedBy: producedByHeader, TimeSent: sm.Time})
+	if err != nil {
+		return models.MessageResponse{}, err
+	}
+
+	for i, cg := range poisonedCgs {
+		if cache != nil {
+			if cached, ok := cache.cgs[cg.CgName]; ok {
+				poisonedCgs[i].MaxAckTimeMs = cached.MaxAckTimeMs
+				poisonedCgs[i].MaxMsgDeliveries = cached.MaxMsgDeliveries
+				poisonedCgs[i].DeliveryCount = sh.S.getPoisonedCgDeliveryCount(stationName, poisonedCgs[i], messageSeq)
+				poisonedCgs[i].UnprocessedMessages = cached.UnprocessedMessages
+				poisonedCgs[i].InProcessMessages = cached.InProcessMessages
+				poisonedCgs[i].TotalPoisonMessages = cached.TotalPoisonMessages
+				poisonedCgs[i].IsActive = cached.IsActive
+				poisonedCgs[i].IsDeleted = cached.IsDeleted
+				poisonedCgs[i].InfoUnavailable = cached.InfoUnavailable
+				continue
+			}
+		}
+
+		// a cg whose consumer can not be read (e.g. just deleted) is marked instead of failing the whole message
+		cgInfo, err := sh.S.GetCgInfo(stationName, cg.CgName)
+		if err != nil {
+			serv.Warnf("getMessageBySeq: Station " + stationName.Ext() + ": Consumer group " + cg.CgName + ": " + err.Error())
+			poisonedCgs[i].InfoUnavailable = true
+		} else {
+			poisonedCgs[i].UnprocessedMessages = int(cgInfo.NumPending)
+			poisonedCgs[i].InProcessMessages = cgInfo.NumAckPending
+		}
+
+		totalPoisonMsgs, err := GetTotalPoisonMsgsByCg(stationName.Ext(), cg.CgName)
+		if err != nil {
+			serv.Warnf("getMessageBySeq: Station " + stationName.Ext() + ": Consumer group " + cg.CgName + ": " + err.Error())
+			poisonedCgs[i].InfoUnavailable = true
+		}
+
+		cgMembers, err := GetConsumerGroupMembers(ctx, cg.CgName, station)
+		if err != nil {
+			serv.Warnf("getMessageBySeq: Station " + stationName.Ext() + ": Consumer group " + cg.CgName + ": " + err.Error())
+			poisonedCgs[i].InfoUnavailable = true
+		}
+
+		isActive, isDeleted := getCgStatus(cgMembers)
+
+		if len(cgMembers) > 0 {
+			poisonedCgs[i].MaxAckTimeMs = cgMembers[0].MaxAckTimeMs
+			poisonedCgs[i].MaxMsgDeliveries = cgMembers[0].MaxMsgDeliveries
+		}
+		poisonedCgs[i].DeliveryCount = sh.S.getPoisonedCgDeliveryCount(stationName, poisonedCgs[i], messageSeq)
+		poisonedCgs[i].TotalPoisonMessages = totalPoisonMsgs
+		poisonedCgs[i].IsActive = isActive
+		poisonedCgs[i].IsDeleted = isDeleted
+		if cache != nil {
+			cache.cgs[cg.CgName] = poisonedCgs[i]
+		}
+	}
+
+	producerKey := producedByHeader + "_" + connectionIdHeader
+	producer, cached := models.Producer{}, false
+	if cache != nil {
+		producer, cached = cache.producers[producerKey]
+	}
+	if !cached {
+		filter := bson.M{"name": producedByHeader, "station_id": station.ID, "connection_id": connectionId}
+		err = producersCollection.FindOne(ctx, filter).Decode(&producer)
+		if err == mongo.ErrNoDocuments {
+			// the producer record may have been purged while its messages remain in the station
+			producer = models.Producer{Name: producedByHeader, IsActive: false, IsDeleted: true}
+		} else if err != nil {
+			return models.MessageResponse{}, err
+		}
+		if cache != nil {
+			cache.producers[producerKey] = producer
+		}
+	}
+
+	conn, cached := models.Connection{}, false
+	if cache != nil {
+		conn, cached = cache.connections[connectionId]
+	}
+	if !cached {
+		_, conn, err = IsConnectionExist(connectionId)
+		if err != nil {
+			return models.MessageResponse{}, err
+		}
+		if cache != nil {
+			cache.connections[connectionId] = conn
+		}
+	}
+
+	sort.Slice(poisonedCgs, func(i, j int) bool {
+		return poisonedCgs[i].PoisoningTime.After(poisonedCgs[j].PoisoningTime)
+	})
+
+	msg := models.MessageResponse{
+		MessageSeq: messageSeq,
+		Message: models.MessagePayload{
+			TimeSent:      sm.Time,
+			Size:          len(sm.Subject) + len(sm.Data) + len(sm.Header),
+			Data:          hex.EncodeToString(sm.Data),
+			Headers:       headersJson,
+			SchemaVersion: schemaVersion,
+		},
+		Producer: models.ProducerDetails{
+			Name:          producedByHeader,
+			ConnectionId:  connectionId,
+			ClientAddress: conn.ClientAddress,
+			CreatedByUser: producer.CreatedByUser,
+			IsActive:      producer.IsActive,
+			IsDeleted:     producer.IsDeleted,
+		},
+		PoisonedCgs: poisonedCgs,
+	}
+	return msg, nil
+}
+
+func (sh StationsHandler) GetMessagesDetails(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetMessagesDetailsSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	messageSeqs := body.MessageSeqs
+	if len(messageSeqs) == 0 {
+		if body.FromSeq <= 0 || body.ToSeq < body.FromSeq {
+			errMsg := "Either message_seqs or a valid from_seq/to_seq range has to be provided"
+			serv.Warnf("GetMessagesDetails: Station " + body.StationName + ": " + errMsg)
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+			return
+		}
+		if body.ToSeq-body.FromSeq+1 > maxMessagesDetailsBatch {
+			errMsg := "Up to " + strconv.Itoa(maxMessagesDetailsBatch) + " messages can be fetched in a single request"
+			serv.Warnf("GetMessagesDetails: Station " + body.StationName + ": " + errMsg)
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+			return
+		}
+		for seq := body.FromSeq; seq <= body.ToSeq; seq++ {
+			messageSeqs = append(messageSeqs, seq)
+		}
+	}
+	if len(messageSeqs) > maxMessagesDetailsBatch {
+		errMsg := "Up to " + strconv.Itoa(maxMessagesDetailsBatch) + " messages can be fetched in a single request"
+		serv.Warnf("GetMessagesDetails: Station " + body.StationName + ": " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("GetMessagesDetails: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("GetMessagesDetails: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + stationName.external + " does not exist"
+		serv.Warnf("GetMessagesDetails: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	cache := newMessageDetailsCache()
+	messages := []models.MessageResponse{}
+	missingSeqs := []int{}
+	for _, seq := range messageSeqs {
+		msg, err := sh.getMessageBySeq(ctx, station, stationName, seq, cache)
+		if err == ErrMissingMsgHeaders || IsNatsErr(err, JSNoMessageFoundErr) {
+			missingSeqs = append(missingSeqs, seq)
+			continue
+		}
+		if err != nil {
+			serv.Errorf("GetMessagesDetails: Station " + body.StationName + ": Message sequence " + strconv.Itoa(seq) + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+		messages = append(messages, msg)
+	}
+
+	c.IndentedJSON(200, gin.H{
+		"messages":     messages,
+		"missing_seqs": missingSeqs,
+	})
+}
+
+func (sh StationsHandler) GetMessagesByTimeRange(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetMessagesByTimeRangeSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	if body.To.Before(body.From) {
+		errMsg := "to has to be later than from"
+		serv.Warnf("GetMessagesByTimeRange: Station " + body.StationName + ": " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+	pageSize := body.PageSize
+	if pageSize == 0 {
+		pageSize = defaultTimeRangePageSize
+	}
+	if pageSize > maxMessagesDetailsBatch {
+		errMsg := "Up to " + strconv.Itoa(maxMessagesDetailsBatch) + " messages can be fetched in a single request"
+		serv.Warnf("GetMessagesByTimeRange: Station " + body.StationName + ": " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("GetMessagesByTimeRange: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("GetMessagesByTimeRange: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + stationName.external + " does not exist"
+		serv.Warnf("GetMessagesByTimeRange: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	messages, nextCursor, err := sh.S.GetMessagesByTimeRange(station, body.From, body.To, body.Cursor, pageSize)
+	if err != nil {
+		if IsNatsErr(err, JSStreamNotFoundErr) {
+			errMsg := "Station " + stationName.external + " does not exist"
+			serv.Warnf("GetMessagesByTimeRange: " + errMsg)
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+			return
+		}
+		serv.Errorf("GetMessagesByTimeRange: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	c.IndentedJSON(200, gin.H{
+		"messages":    messages,
+		"next_cursor": nextCursor,
+	})
+}
+
+// DeleteMessages removes specific messages from the station's stream, unlike purge it leaves the rest of the station untouched
+func (sh StationsHandler) DeleteMessages(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if err := DenyForSandboxEnv(c); err != nil {
+		return
+	}
+	var body models.DeleteMessagesSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	if len(body.MessageSeqs) == 0 {
+		errMsg := "At least one message sequence has to be provided"
+		serv.Warnf("DeleteMessages: Station " + body.StationName + ": " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+	if len(body.MessageSeqs) > maxMessagesDeleteBatch {
+		errMsg := "Up to " + strconv.Itoa(maxMessagesDeleteBatch) + " messages can be deleted in a single request"
+		serv.Warnf("DeleteMessages: Station " + body.StationName + ": " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("DeleteMessages: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, _, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("DeleteMessages: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + stationName.Ext() + " does not exist"
+		serv.Warnf("DeleteMessages: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	user, err := getUserDetailsFromMiddleware(c)
+	if err != nil {
+		serv.Errorf("DeleteMessages: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(401, gin.H{"message": "Unauthorized", "code": ErrCodeUnauthorized})
+		return
+	}
+
+	results := []models.DeletedMessageResult{}
+	deletedSeqs := []string{}
+	for _, seq := range body.MessageSeqs {
+		result := models.DeletedMessageResult{MessageSeq: seq}
+		_, err := sh.S.memphisDeleteMsgFromStream(stationName.Intern(), seq)
+		if err != nil {
+			if IsNatsErr(err, JSStreamNotFoundErr) {
+				errMsg := "Station " + stationName.Ext() + " does not exist"
+				serv.Warnf("DeleteMessages: " + errMsg)
+				c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+				return
+			}
+			serv.Warnf("DeleteMessages: Station " + body.StationName + ": Message sequence " + strconv.FormatUint(seq, 10) + ": " + err.Error())
+			result.Error = err.Error()
+		} else {
+			result.Deleted = true
+			deletedSeqs = append(deletedSeqs, strconv.FormatUint(seq, 10))
+		}
+		results = append(results, result)
+	}
+
+	if len(deletedSeqs) > 0 {
+		message := "Messages " + strings.Join(deletedSeqs, ", ") + " have been deleted from station " + stationName.Ext() + " by user " + user.Username
+		serv.Noticef(message)
+		var auditLogs []interface{}
+		newAuditLog := models.AuditLog{
+			ID:            primitive.NewObjectID(),
+			StationName:   stationName.Ext(),
+			Message:       message,
+			CreatedByUser: user.Username,
+			CreationDate:  time.Now(),
+			UserType:      user.UserType,
+		}
+		auditLogs = append(auditLogs, newAuditLog)
+		err = CreateAuditLogs(auditLogs)
+		if err != nil {
+			serv.Warnf("DeleteMessages: Station " + body.StationName + " - create audit logs error: " + err.Error())
+		}
+	}
+
+	c.IndentedJSON(200, gin.H{"messages": results})
+}
+
+func (sh StationsHandler) GetMessageById(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetMessageByIdSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	msgId := strings.ReplaceAll(body.MessageId, " ", "+")
+	splitId := strings.Split(msgId, dlsMsgSep)
+	if len(splitId) < 3 {
+		errMsg := "Message ID " + body.MessageId + " is not valid"
+		serv.Warnf("GetMessageById: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+	messageSeq, err := strconv.Atoi(splitId[2])
+	if err != nil {
+		errMsg := "Message ID " + body.MessageId + " is not valid"
+		serv.Warnf("GetMessageById: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+
+	stationName, err := StationNameFromStr(splitId[0])
+	if err != nil {
+		serv.Warnf("GetMessageById: Message ID: " + body.MessageId + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("GetMessageById: Message ID: " + body.MessageId + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + stationName.external + " does not exist"
+		serv.Warnf("GetMessageById: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	dlsMessage, err := sh.GetDlsMessageJourneyDetails(ctx, msgId)
+	if err != nil {
+		serv.Errorf("GetMessageById: Message ID: " + body.MessageId + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if dlsMessage.ID != "" {
+		c.IndentedJSON(200, models.MessageByIdResponse{Source: "dls", DlsMessage: &dlsMessage})
+		return
+	}
+
+	msg, err := sh.getMessageBySeq(ctx, station, stationName, messageSeq, nil)
+	if err == ErrMissingMsgHeaders {
+		serv.Warnf("GetMessageById: " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+	if IsNatsErr(err, JSNoMessageFoundErr) {
+		errMsg := "Message ID " + body.MessageId + " was not found"
+		serv.Warnf("GetMessageById: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeMessageNotFound})
+		return
+	}
+	if err != nil {
+		serv.Errorf("GetMessageById: Message ID: " + body.MessageId + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	c.IndentedJSON(200, models.MessageByIdResponse{Source: "station", Message: &msg})
+}
+
+func (sh StationsHandler) UseSchema(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.UseSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	schemaName := strings.ToLower(body.SchemaName)
+	exist, schema, err := IsSchemaExist(schemaName)
 	if err != nil {
-		serv.Errorf("RemoveStation error: Station " + dsr.StationName + ": " + err.Error())
-		respondWithErr(s, reply, err)
+		serv.Errorf("UseSchema: Schema " + body.SchemaName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server Error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Schema " + schemaName + " does not exist"
+		serv.Warnf("UseSchema: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeSchemaMissing})
 		return
 	}
 
-	message := "Station " + stationName.Ext() + " has been deleted by user " + c.memphisInfo.username
+	schemaVersion, err := getActiveVersionBySchemaId(schema.ID)
+	if err != nil {
+		serv.Errorf("UseSchema: Schema " + body.SchemaName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": err.Error(), "code": ErrCodeServerError})
+		return
+	}
+	schemaDetailsResponse := models.StationOverviewSchemaDetails{SchemaName: schemaName, VersionNumber: schemaVersion.VersionNumber, UpdatesAvailable: false}
+	schemaDetails := models.SchemaDetails{SchemaName: schemaName, VersionNumber: schemaVersion.VersionNumber}
+	// an effective time in the past means the schema is attached right away
+	scheduled := body.EffectiveAt.After(time.Now())
+
+	user, err := getUserDetailsFromMiddleware(c)
+	if err != nil {
+		serv.Errorf("UseSchema: Schema " + body.SchemaName + ": " + err.Error())
+		c.AbortWithStatusJSON(401, gin.H{"message": "Unauthorized", "code": ErrCodeUnauthorized})
+	}
+
+	for _, stationName := range body.StationNames {
+		stationName, err := StationNameFromStr(stationName)
+		if err != nil {
+			serv.Warnf("UseSchema: Schema " + body.SchemaName + " at station " + stationName.Ext() + ": " + err.Error())
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+			return
+		}
+
+		exist, station, err := IsStationExistWithContext(ctx, stationName)
+		if err != nil {
+			serv.Errorf("UseSchema: Schema " + body.SchemaName + " at station " + stationName.Ext() + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+		if !exist {
+			errMsg := "Station " + station.Name + " does not exist"
+			serv.Warnf("UseSchema: Schema " + body.SchemaName + ": " + errMsg)
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+			return
+		}
+
+		if scheduled {
+			pendingSchema := models.PendingSchema{SchemaName: schemaName, VersionNumber: schemaVersion.VersionNumber, EffectiveAt: body.EffectiveAt, ScheduledBy: user.Username, UserType: user.UserType}
+			err = sh.scheduleSchemaAttachment(ctx, stationName, station, pendingSchema, user)
+		} else {
+			err = sh.attachSchemaToStation(ctx, stationName, station, schema, schemaDetails, user)
+		}
+		if err == ErrStationSchemaChanged || err == ErrNonNativeStationSchema {
+			serv.Warnf("UseSchema: Schema " + body.SchemaName + " at station " + stationName.Ext() + ": " + err.Error())
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+			return
+		}
+		if err != nil {
+			serv.Errorf("UseSchema: Schema " + body.SchemaName + " at station " + stationName.Ext() + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": err.Error(), "code": ErrCodeServerError})
+			return
+		}
+	}
+
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategorySchema)
+	if shouldSendAnalytics {
+		user, _ := getUserDetailsFromMiddleware(c)
+		analytics.SendEvent(user.Username, "user-attach-schema-to-station")
+	}
+
+	c.IndentedJSON(200, schemaDetailsResponse)
+}
+
+// updateStationSchema sets the schema only if the station has not been updated since it was read,
+// so the SDK and the UI can not silently override each other's schema attachment
+// an attachment made now supersedes a schema scheduled to be attached later
+func updateStationSchema(ctx context.Context, station models.Station, schemaDetails models.SchemaDetails) error {
+	return updateUnchangedStation(ctx, station, bson.M{
+		"$set":   bson.M{"schema": schemaDetails, "last_update": time.Now()},
+		"$unset": bson.M{"pending_schema": ""},
+	})
+}
+
+// scheduleStationSchema stores a schema to be attached once its effective time arrives, replacing a previously scheduled one
+func scheduleStationSchema(ctx context.Context, station models.Station, pendingSchema models.PendingSchema) error {
+	return updateUnchangedStation(ctx, station, bson.M{"$set": bson.M{"pending_schema": pendingSchema, "last_update": time.Now()}})
+}
+
+func updateUnchangedStation(ctx context.Context, station models.Station, update bson.M) error {
+	filter := bson.M{"name": station.Name, "is_deleted": false, "last_update": station.LastUpdate}
+	if station.LastUpdate.IsZero() { // stations created before last_update was tracked
+		filter["last_update"] = bson.M{"$in": []interface{}{nil, station.LastUpdate}}
+	}
+	res, err := stationsCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrStationSchemaChanged
+	}
+	return nil
+}
+
+// attachSchemaToStation sets the schema version on the station, audits it and notifies the station's producers
+func (sh StationsHandler) attachSchemaToStation(ctx context.Context, stationName StationName, station models.Station, schema models.Schema, schemaDetails models.SchemaDetails, user models.User) error {
+	if !station.IsNative {
+		return ErrNonNativeStationSchema
+	}
+	err := updateStationSchema(ctx, station, schemaDetails)
+	if err != nil {
+		return err
+	}
+
+	message := "Schema " + schema.Name + " has been attached to station " + stationName.Ext() + " by user " + user.Username
 	serv.Noticef(message)
+
 	var auditLogs []interface{}
 	newAuditLog := models.AuditLog{
 		ID:            primitive.NewObjectID(),
-		StationName:   stationName.Ext(),
+		StationName:   stationName.Intern(),
 		Message:       message,
-		CreatedByUser: c.memphisInfo.username,
+		CreatedByUser: user.Username,
 		CreationDate:  time.Now(),
-		UserType:      "application",
+		UserType:      user.UserType,
 	}
 	auditLogs = append(auditLogs, newAuditLog)
 	err = CreateAuditLogs(auditLogs)
 	if err != nil {
-		serv.Warnf("removeStationDirect: Station " + stationName.Ext() + " - create audit logs error: " + err.Error())
+		serv.Errorf("attachSchemaToStation: Schema " + schema.Name + " at station " + stationName.Ext() + " - create audit logs: " + err.Error())
 	}
-	respondWithErr(s, reply, nil)
-	return
-}
 
-func (sh StationsHandler) GetTotalMessages(stationNameExt string) (int, error) {
-	stationName, err := StationNameFromStr(stationNameExt)
+	updateContent, err := generateSchemaUpdateInit(schema)
 	if err != nil {
-		return 0, err
+		return err
 	}
-	totalMessages, err := sh.S.GetTotalMessagesInStation(stationName)
-	return totalMessages, err
+	updateContent.Enforcement = getStationSchemaEnforcement(station)
+	update := models.ProducerSchemaUpdate{
+		UpdateType: models.SchemaUpdateTypeInit,
+		Init:       *updateContent,
+	}
+	sh.S.updateStationProducersOfSchemaChange(stationName, update)
+	return nil
 }
 
-func (sh StationsHandler) GetTotalMessagesAcrossAllStations() (int, error) {
-	totalMessages, err := sh.S.GetTotalMessagesAcrossAllStations()
-	return totalMessages, err
+// scheduleSchemaAttachment stores the schema as pending, the station's producers keep the current schema until it becomes effective
+func (sh StationsHandler) scheduleSchemaAttachment(ctx context.Context, stationName StationName, station models.Station, pendingSchema models.PendingSchema, user models.User) error {
+	if !station.IsNative {
+		return ErrNonNativeStationSchema
+	}
+	err := scheduleStationSchema(ctx, station, pendingSchema)
+	if err != nil {
+		return err
+	}
+
+	message := "Schema " + pendingSchema.SchemaName + " has been scheduled to be attached to station " + stationName.Ext() + " at " + pendingSchema.EffectiveAt.UTC().Format(time.RFC3339) + " by user " + user.Username
+	serv.Noticef(message)
+
+	var auditLogs []interface{}
+	newAuditLog := models.AuditLog{
+		ID:            primitive.NewObjectID(),
+		StationName:   stationName.Intern(),
+		Message:       message,
+		CreatedByUser: user.Username,
+		CreationDate:  time.Now(),
+		UserType:      user.UserType,
+	}
+	auditLogs = append(auditLogs, newAuditLog)
+	err = CreateAuditLogs(auditLogs)
+	if err != nil {
+		serv.Errorf("scheduleSchemaAttachment: Schema " + pendingSchema.SchemaName + " at station " + stationName.Ext() + " - create audit logs: " + err.Error())
+	}
+
+	sh.S.schedulePendingSchemasActivation(pendingSchema.EffectiveAt)
+	return nil
 }
 
-func (sh StationsHandler) GetAvgMsgSize(station models.Station) (int64, error) {
-	avgMsgSize, err := sh.S.GetAvgMsgSizeInStation(station)
-	return avgMsgSize, err
+// schedulePendingSchemasActivation activates the pending schemas right when they become effective,
+// the periodic activation covers the schedules lost on restarts
+func (s *Server) schedulePendingSchemasActivation(effectiveAt time.Time) {
+	time.AfterFunc(time.Until(effectiveAt), s.activatePendingSchemas)
 }
 
-func (sh StationsHandler) GetMessages(station models.Station, messagesToFetch int) ([]models.MessageDetails, error) {
-	messages, err := sh.S.GetMessages(station, messagesToFetch)
+// activatePendingSchemas attaches the scheduled schemas whose effective time has arrived and notifies the stations' producers,
+// the pending schema is matched on update so a schema is activated once even when several brokers race on it
+func (s *Server) activatePendingSchemas() {
+	var stations []models.Station
+	filter := bson.M{"is_deleted": false, "pending_schema.effective_at": bson.M{"$lte": time.Now()}}
+	cursor, err := stationsCollection.Find(context.TODO(), filter)
 	if err != nil {
-		return messages, err
+		s.Errorf("activatePendingSchemas: " + err.Error())
+		return
+	}
+	if err = cursor.All(context.TODO(), &stations); err != nil {
+		s.Errorf("activatePendingSchemas: " + err.Error())
+		return
 	}
 
-	return messages, nil
-}
+	for _, station := range stations {
+		pendingSchema := station.PendingSchema
+		stationName, err := StationNameFromStr(station.Name)
+		if err != nil {
+			s.Errorf("activatePendingSchemas: Station " + station.Name + ": " + err.Error())
+			continue
+		}
+		pendingFilter := bson.M{"_id": station.ID, "is_deleted": false, "pending_schema": pendingSchema}
 
-func (sh StationsHandler) GetLeaderAndFollowers(station models.Station) (string, []string, error) {
-	if sh.S.JetStreamIsClustered() {
-		leader, followers, err := sh.S.GetLeaderAndFollowers(station)
+		exist, schema, err := IsSchemaExist(pendingSchema.SchemaName)
 		if err != nil {
-			return "", []string{}, err
+			s.Errorf("activatePendingSchemas: Station " + station.Name + ": " + err.Error())
+			continue
+		}
+		if !exist {
+			s.Warnf("activatePendingSchemas: Station " + station.Name + ": the scheduled schema " + pendingSchema.SchemaName + " no longer exists")
+			_, err = stationsCollection.UpdateOne(context.TODO(), pendingFilter, bson.M{"$unset": bson.M{"pending_schema": ""}})
+			if err != nil {
+				s.Errorf("activatePendingSchemas: Station " + station.Name + ": " + err.Error())
+			}
+			continue
+		}
+		schemaVersion, err := getStationSchemaVersion(schema, pendingSchema.VersionNumber)
+		if err != nil {
+			s.Errorf("activatePendingSchemas: Station " + station.Name + ": " + err.Error())
+			continue
 		}
 
-		return leader, followers, nil
-	} else {
-		return "broker-0", []string{}, nil
+		schemaDetails := models.SchemaDetails{SchemaName: pendingSchema.SchemaName, VersionNumber: pendingSchema.VersionNumber}
+		res, err := stationsCollection.UpdateOne(context.TODO(), pendingFilter, bson.M{
+			"$set":   bson.M{"schema": schemaDetails, "last_update": time.Now()},
+			"$unset": bson.M{"pending_schema": ""},
+		})
+		if err != nil {
+			s.Errorf("activatePendingSchemas: Station " + station.Name + ": " + err.Error())
+			continue
+		}
+		if res.ModifiedCount == 0 {
+			continue
+		}
+		// schedules stored before the scheduling user was recorded are audited as root
+		username, userType := pendingSchema.ScheduledBy, pendingSchema.UserType
+		if username == "" {
+			username, userType = "root", "root"
+		}
+		message := "Scheduled schema " + schema.Name + " has been attached to station " + stationName.Ext() + ", it was scheduled by user " + username
+		s.Noticef(message)
+		var auditLogs []interface{}
+		newAuditLog := models.AuditLog{
+			ID:            primitive.NewObjectID(),
+			StationName:   stationName.Ext(),
+			Message:       message,
+			CreatedByUser: username,
+			CreationDate:  time.Now(),
+			UserType:      userType,
+		}
+		auditLogs = append(auditLogs, newAuditLog)
+		err = CreateAuditLogs(auditLogs)
+		if err != nil {
+			s.Warnf("activatePendingSchemas: Station " + station.Name + " - create audit logs error: " + err.Error())
+		}
+
+		updateContent := generateSchemaVersionUpdateInit(schema, schemaVersion)
+		updateContent.Enforcement = getStationSchemaEnforcement(station)
+		s.updateStationProducersOfSchemaChange(stationName, models.ProducerSchemaUpdate{
+			UpdateType: models.SchemaUpdateTypeInit,
+			Init:       *updateContent,
+		})
 	}
 }
 
-func getCgStatus(members []models.CgMember) (bool, bool) {
-	deletedCount := 0
-	for _, member := range members {
-		if member.IsActive {
-			return true, false
-		}
+func (sh StationsHandler) UseSchemaByTag(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
 
-		if member.IsDeleted {
-			deletedCount++
+	var body models.UseSchemaByTagSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	schemaName := strings.ToLower(body.SchemaName)
+	exist, schema, err := IsSchemaExist(schemaName)
+	if err != nil {
+		serv.Errorf("UseSchemaByTag: Schema " + body.SchemaName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Schema " + schemaName + " does not exist"
+		serv.Warnf("UseSchemaByTag: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeSchemaMissing})
+		return
+	}
+
+	tagName := strings.ToLower(body.TagName)
+	var tag models.Tag
+	err = tagsCollection.FindOne(ctx, bson.M{"name": tagName}).Decode(&tag)
+	if err == mongo.ErrNoDocuments {
+		errMsg := "Tag " + tagName + " does not exist"
+		serv.Warnf("UseSchemaByTag: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
+		return
+	}
+	if err != nil {
+		serv.Errorf("UseSchemaByTag: Tag " + body.TagName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	schemaVersion, err := getActiveVersionBySchemaId(schema.ID)
+	if err != nil {
+		serv.Errorf("UseSchemaByTag: Schema " + body.SchemaName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	schemaDetails := models.SchemaDetails{SchemaName: schemaName, VersionNumber: schemaVersion.VersionNumber}
+
+	user, err := getUserDetailsFromMiddleware(c)
+	if err != nil {
+		serv.Errorf("UseSchemaByTag: Schema " + body.SchemaName + ": " + err.Error())
+		c.AbortWithStatusJSON(401, gin.H{"message": "Unauthorized", "code": ErrCodeUnauthorized})
+		return
+	}
+
+	var stations []models.Station
+	filter := bson.M{"_id": bson.M{"$in": tag.Stations}, "is_deleted": false}
+	cursor, err := stationsCollection.Find(ctx, filter)
+	if err != nil {
+		serv.Errorf("UseSchemaByTag: Tag " + body.TagName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if err = cursor.All(ctx, &stations); err != nil {
+		serv.Errorf("UseSchemaByTag: Tag " + body.TagName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+
+	results := []models.StationSchemaAttachResult{}
+	for _, station := range stations {
+		result := models.StationSchemaAttachResult{StationName: station.Name}
+		stationName, err := StationNameFromStr(station.Name)
+		if err == nil {
+			err = sh.attachSchemaToStation(ctx, stationName, station, schema, schemaDetails, user)
 		}
+		if err == ErrNonNativeStationSchema {
+			serv.Warnf("UseSchemaByTag: Schema " + body.SchemaName + " at station " + station.Name + ": " + err.Error())
+			result.Error = err.Error()
+		} else if err != nil {
+			serv.Errorf("UseSchemaByTag: Schema " + body.SchemaName + " at station " + station.Name + ": " + err.Error())
+			result.Error = err.Error()
+		} else {
+			result.Attached = true
+		}
+		results = append(results, result)
 	}
 
-	if len(members) == deletedCount {
-		return false, true
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategorySchema)
+	if shouldSendAnalytics {
+		analytics.SendEvent(user.Username, "user-attach-schema-to-stations-by-tag")
+	}
+
+	c.IndentedJSON(200, gin.H{
+		"schema":   models.StationOverviewSchemaDetails{SchemaName: schemaName, VersionNumber: schemaVersion.VersionNumber, UpdatesAvailable: false},
+		"tag_name": tagName,
+		"stations": results,
+	})
+}
+
+// ValidateMessageAgainstStationSchema checks a payload against the active version of the station's schema
+// without producing it, so payloads can be tested before wiring up a producer
+func (sh StationsHandler) ValidateMessageAgainstStationSchema(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.ValidateStationMessageSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	var payload []byte
+	var err error
+	switch strings.ToLower(body.PayloadEncoding) {
+	case "", "utf8":
+		payload = []byte(body.Payload)
+	case "base64":
+		payload, err = base64.StdEncoding.DecodeString(body.Payload)
+	case "hex":
+		payload, err = hex.DecodeString(body.Payload)
+	default:
+		err = errors.New("payload_encoding can be one of the following utf8/base64/hex")
+	}
+	if err != nil {
+		serv.Warnf("ValidateMessageAgainstStationSchema: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
 	}
 
-	return false, false
-}
-
-func (sh StationsHandler) GetDlsMessageJourneyDetails(dlsMsgId string) (models.DlsMessageResponse, error) {
-	dlsMsgId = strings.ReplaceAll(dlsMsgId, " ", "+")
-	poisonMsgsHandler := PoisonMessagesHandler{S: sh.S}
-	var dlsMessage models.DlsMessageResponse
-	splitId := strings.Split(dlsMsgId, dlsMsgSep)
-	stationName := splitId[0]
-	sn, err := StationNameFromStr(stationName)
+	stationName, err := StationNameFromStr(body.StationName)
 	if err != nil {
-		return dlsMessage, err
+		serv.Warnf("ValidateMessageAgainstStationSchema: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
 	}
-	exist, station, err := IsStationExist(sn)
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
 	if err != nil {
-		return dlsMessage, err
+		serv.Errorf("ValidateMessageAgainstStationSchema: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
 	}
 	if !exist {
-		return dlsMessage, errors.New("Station " + station.Name + " does not exist")
+		errMsg := "Station " + stationName.Ext() + " does not exist"
+		serv.Warnf("ValidateMessageAgainstStationSchema: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+	if station.Schema.SchemaName == "" {
+		errMsg := "Station " + stationName.Ext() + " has no schema attached"
+		serv.Warnf("ValidateMessageAgainstStationSchema: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeSchemaMissing})
+		return
 	}
 
-	poisoned, schemaFailed, err := poisonMsgsHandler.GetDlsMsgsByStationFull(station)
+	exist, schema, err := IsSchemaExist(station.Schema.SchemaName)
 	if err != nil {
-		return dlsMessage, err
-	}
-	for _, dlm := range poisoned {
-		if dlm.ID == dlsMsgId {
-			dlsMessage = dlm
-			headersJson := dlsMessage.Message.Headers
-			for header := range headersJson {
-				if strings.HasPrefix(header, "$memphis") {
-					delete(headersJson, header)
-				}
-			}
-			dlsMessage.Message.Headers = headersJson
-			break
-		}
+		serv.Errorf("ValidateMessageAgainstStationSchema: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
 	}
-
-	if dlsMessage.ID == "" {
-		for _, dlm := range schemaFailed {
-			if dlm.ID == dlsMsgId {
-				dlsMessage = dlm
-				break
-			}
-		}
+	if !exist {
+		errMsg := "Schema " + station.Schema.SchemaName + " of station " + stationName.Ext() + " does not exist"
+		serv.Warnf("ValidateMessageAgainstStationSchema: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeSchemaMissing})
+		return
 	}
-	seq, err := strconv.Atoi(splitId[2])
+	// the message is checked against the version the station is pinned to, which is what its producers validate with
+	schemaVersion, err := getStationSchemaVersion(schema, station.Schema.VersionNumber)
 	if err != nil {
-		return dlsMessage, err
+		if errorCode(err) == ErrCodeSchemaMissing {
+			serv.Warnf("ValidateMessageAgainstStationSchema: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": ErrCodeSchemaMissing})
+			return
+		}
+		serv.Errorf("ValidateMessageAgainstStationSchema: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
 	}
-	poisonedCgs, err := GetPoisonedCgsByMessage(sn.Intern(), models.MessageDetails{MessageSeq: seq, ProducedBy: dlsMessage.Producer.Name, TimeSent: dlsMessage.Message.TimeSent})
+
+	validationErrs, err := validateMessageAgainstSchema(schema.Type, schemaVersion, payload)
 	if err != nil {
-		return dlsMessage, err
+		serv.Errorf("ValidateMessageAgainstStationSchema: Station " + body.StationName + ": Schema " + schema.Name + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
 	}
 
-	cgs := make([]models.PoisonedCg, 0)
-	sort.Slice(poisonedCgs, func(i, j int) bool {
-		return poisonedCgs[i].PoisoningTime.After(poisonedCgs[j].PoisoningTime)
+	c.IndentedJSON(200, gin.H{
+		"is_valid":       len(validationErrs) == 0,
+		"schema_name":    schema.Name,
+		"schema_type":    schema.Type,
+		"version_number": schemaVersion.VersionNumber,
+		"errors":         validationErrs,
 	})
-	cgCheck := make(map[string]bool)
-	for _, cg := range poisonedCgs {
-		if _, value := cgCheck[cg.CgName]; value {
-			continue
-		}
-		cgCheck[cg.CgName] = true
-		cgMembers, err := GetConsumerGroupMembers(cg.CgName, station)
-		if err != nil {
-			return dlsMessage, err
-		}
+}
 
-		isActive, isDeleted := getCgStatus(cgMembers)
-		cgInfo, err := sh.S.GetCgInfo(sn, cg.CgName)
-		if err != nil {
-			return dlsMessage, err
-		}
-		totalPms, err := GetTotalPoisonMsgsByCg(sn.Intern(), cg.CgName)
-		if err != nil {
-			return dlsMessage, err
-		}
-		cg.MaxAckTimeMs = cgMembers[0].MaxAckTimeMs
-		cg.MaxMsgDeliveries = cgMembers[0].MaxMsgDeliveries
-		cg.UnprocessedMessages = int(cgInfo.NumPending)
-		cg.InProcessMessages = cgInfo.NumAckPending
-		cg.TotalPoisonMessages = totalPms
-		cg.CgMembers = cgMembers
-		cg.IsActive = isActive
-		cg.IsDeleted = isDeleted
-		cgs = append(cgs, cg)
+func (s *Server) useSchemaDirect(c *client, reply string, msg []byte) {
+	var asr attachSchemaRequest
+	if err := json.Unmarshal(msg, &asr); err != nil {
+		errMsg := "failed attaching schema " + asr.Name + ": " + err.Error()
+		s.Errorf("useSchemaDirect: At station " + asr.StationName + " " + errMsg)
+		respondWithErr(s, reply, errors.New(errMsg))
+		return
+	}
+	stationName, err := StationNameFromStr(asr.StationName)
+	if err != nil {
+		serv.Warnf("useSchemaDirect: Schema " + asr.Name + " at station " + asr.StationName + ": " + err.Error())
+		respondWithErr(s, reply, err)
+		return
 	}
 
-	dlsMessage.PoisonedCgs = cgs
-
-	return dlsMessage, nil
-}
+	exist, station, err := IsStationExist(stationName)
+	if err != nil {
+		serv.Errorf("useSchemaDirect: Schema " + asr.Name + " at station " + asr.StationName + ": " + err.Error())
+		respondWithErr(s, reply, err)
+		return
+	}
 
-func (sh StationsHandler) GetPoisonMessageJourney(c *gin.Context) {
-	var body models.GetPoisonMessageJourneySchema
-	ok := utils.Validate(c, &body, false, nil)
-	if !ok {
+	if !exist {
+		errMsg := "Station " + stationName.external + " does not exist"
+		serv.Warnf("useSchemaDirect: " + errMsg)
+		respondWithErr(s, reply, errors.New("memphis: "+errMsg))
+		return
+	}
+	if !station.IsNative {
+		serv.Warnf("useSchemaDirect: Schema " + asr.Name + " at station " + asr.StationName + ": " + ErrNonNativeStationSchema.Error())
+		respondWithErr(s, reply, errors.New("memphis: "+ErrNonNativeStationSchema.Error()))
 		return
 	}
 
-	poisonMessage, err := sh.GetDlsMessageJourneyDetails(body.MessageId)
+	var schemaDetails models.SchemaDetails
+	schemaName := strings.ToLower(asr.Name)
+	exist, schema, err := IsSchemaExist(schemaName)
 	if err != nil {
-		serv.Errorf("GetPoisonMessageJourney: " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		serv.Errorf("useSchemaDirect: Schema " + asr.Name + " at station " + asr.StationName + ": " + err.Error())
+		respondWithErr(s, reply, err)
 		return
 	}
-
-	shouldSendAnalytics, _ := shouldSendAnalytics()
-	if shouldSendAnalytics {
-		user, _ := getUserDetailsFromMiddleware(c)
-		analytics.SendEvent(user.Username, "user-enter-message-journey")
+	if !exist {
+		err := schemaNotFoundError(schemaName)
+		serv.Warnf("useSchemaDirect: Station " + asr.StationName + ": " + err.Error())
+		respondWithErr(s, reply, err)
+		return
 	}
 
-	c.IndentedJSON(200, poisonMessage)
-}
+	schemaVersion, err := getActiveVersionBySchemaId(schema.ID)
+	if err != nil {
+		serv.Errorf("useSchemaDirect: Schema " + asr.Name + " at station " + asr.StationName + ": " + err.Error())
+		respondWithErr(s, reply, err)
+		return
+	}
+	schemaDetails = models.SchemaDetails{SchemaName: schemaName, VersionNumber: schemaVersion.VersionNumber}
 
-func (sh StationsHandler) AckPoisonMessages(c *gin.Context) {
-	var body models.AckPoisonMessagesSchema
-	ok := utils.Validate(c, &body, false, nil)
-	if !ok {
+	err = updateStationSchema(context.TODO(), station, schemaDetails)
+	if err == ErrStationSchemaChanged {
+		serv.Warnf("useSchemaDirect: Schema " + asr.Name + " at station " + asr.StationName + ": " + err.Error())
+		respondWithErr(s, reply, errors.New("memphis: "+err.Error()))
 		return
 	}
-	timeout := 1 * time.Second
-	splitId := strings.Split(body.PoisonMessageIds[0], dlsMsgSep)
-	stationName := splitId[0]
-	sn, err := StationNameFromStr(stationName)
 	if err != nil {
-		serv.Errorf("AckPoisonMessages: " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		serv.Errorf("useSchemaDirect: Schema " + asr.Name + " at station " + asr.StationName + ": " + err.Error())
+		respondWithErr(s, reply, err)
 		return
 	}
-	streamName := fmt.Sprintf(dlsStreamName, sn.Intern())
-	for _, msgId := range body.PoisonMessageIds {
-		uid := serv.memphis.nuid.Next()
-		durableName := "$memphis_fetch_dls_consumer_" + uid
-		var msgs []StoredMsg
-		streamInfo, err := serv.memphisStreamInfo(streamName)
-		if err != nil {
-			serv.Errorf("AckPoisonMessages: " + err.Error())
-			c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-			return
-		}
-		filter := GetDlsSubject("poison", sn.Intern(), msgId)
-		amount := streamInfo.State.Msgs
-		cc := ConsumerConfig{
-			DeliverPolicy: DeliverAll,
-			AckPolicy:     AckExplicit,
-			Durable:       durableName,
-			FilterSubject: filter,
-		}
 
-		err = serv.memphisAddConsumer(streamName, &cc)
-		if err != nil {
-			serv.Errorf("AckPoisonMessages: " + err.Error())
-			c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-			return
-		}
+	username := c.getClientInfo(true).Name
+	message := "Schema " + schemaName + " has been attached to station " + stationName.Ext() + " by user " + username
+	serv.Noticef(message)
 
-		responseChan := make(chan StoredMsg)
-		subject := fmt.Sprintf(JSApiRequestNextT, streamName, durableName)
-		reply := durableName + "_reply"
-		req := []byte(strconv.FormatUint(amount, 10))
+	var auditLogs []interface{}
+	newAuditLog := models.AuditLog{
+		ID:            primitive.NewObjectID(),
+		StationName:   stationName.Intern(),
+		Message:       message,
+		CreatedByUser: username,
+		CreationDate:  time.Now(),
+		UserType:      "sdk",
+	}
+	auditLogs = append(auditLogs, newAuditLog)
+	err = CreateAuditLogs(auditLogs)
+	if err != nil {
+		serv.Errorf("useSchemaDirect : Schema " + asr.Name + " at station " + asr.StationName + " - create audit logs: " + err.Error())
+	}
 
-		sub, err := serv.subscribeOnGlobalAcc(reply, reply+"_sid", func(_ *client, subject, reply string, msg []byte) {
-			go func(respCh chan StoredMsg, subject, reply string, msg []byte) {
-				// ack
-				serv.sendInternalAccountMsg(serv.GlobalAccount(), reply, []byte(_EMPTY_))
-				rawTs := tokenAt(reply, 8)
-				seq, _, _ := ackReplyInfo(reply)
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategorySchema)
+	if shouldSendAnalytics {
+		analytics.SendEvent("sdk", "user-attach-schema-to-station")
+	}
 
-				intTs, err := strconv.Atoi(rawTs)
-				if err != nil {
-					serv.Errorf("GetTotalPoisonMsgsByCg: " + err.Error())
-				}
+	updateContent, err := generateSchemaUpdateInit(schema)
+	if err != nil {
+		serv.Errorf("useSchemaDirect: Schema " + asr.Name + " at station " + asr.StationName + ": " + err.Error())
+		return
+	}
+	updateContent.Enforcement = getStationSchemaEnforcement(station)
 
-				respCh <- StoredMsg{
-					Subject:  subject,
-					Sequence: uint64(seq),
-					Data:     msg,
-					Time:     time.Unix(0, int64(intTs)),
-				}
-			}(responseChan, subject, reply, copyBytes(msg))
-		})
-		if err != nil {
-			serv.Errorf("AckPoisonMessages: " + err.Error())
-			c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-			return
-		}
+	update := models.ProducerSchemaUpdate{
+		UpdateType: models.SchemaUpdateTypeInit,
+		Init:       *updateContent,
+	}
 
-		serv.sendInternalAccountMsgWithReply(serv.GlobalAccount(), subject, reply, nil, req, true)
+	serv.updateStationProducersOfSchemaChange(stationName, update)
+	respondWithErr(s, reply, nil)
+}
 
-		timer := time.NewTimer(timeout)
-		for i := uint64(0); i < amount; i++ {
-			select {
-			case <-timer.C:
-				goto cleanup
-			case msg := <-responseChan:
-				msgs = append(msgs, msg)
-			}
-		}
+// validateSchemaDetach refuses detaching the schema of a station that requires one unless it is explicitly overridden
+func validateSchemaDetach(station models.Station, override bool) error {
+	if station.SchemaRequired && station.Schema.SchemaName != "" && !override {
+		return errors.New("Station " + station.Name + " requires a schema, disable the requirement or override it explicitly")
+	}
 
-	cleanup:
-		timer.Stop()
-		serv.unsubscribeOnGlobalAcc(sub)
-		err = serv.memphisRemoveConsumer(streamName, durableName)
-		if err != nil {
-			serv.Errorf("AckPoisonMessages: " + err.Error())
-			c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-			return
-		}
+	return nil
+}
 
-		for _, msg := range msgs {
-			_, err = sh.S.memphisDeleteMsgFromStream(streamName, msg.Sequence)
-			if err != nil {
-				serv.Errorf("AckPoisonMessages: " + err.Error())
-				c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-				return
-			}
+func removeSchemaFromStation(ctx context.Context, s *Server, sn StationName, updateDB bool) error {
+	exist, _, err := IsStationExistWithContext(ctx, sn)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.New("Station " + sn.external + " does not exist")
+	}
+
+	if updateDB {
+		_, err = stationsCollection.UpdateOne(ctx,
+			bson.M{
+				"name": sn.Ext(),
+				"$or": []interface{}{
+					bson.M{"is_deleted": false},
+					bson.M{"is_deleted": bson.M{"$exists": false}},
+				},
+			},
+			bson.M{"$set": bson.M{"schema": bson.M{}, "last_update": time.Now()}, "$unset": bson.M{"pending_schema": ""}},
+		)
+		if err != nil {
+			return err
 		}
 	}
 
-	shouldSendAnalytics, _ := shouldSendAnalytics()
-	if shouldSendAnalytics {
-		user, _ := getUserDetailsFromMiddleware(c)
-		analytics.SendEvent(user.Username, "user-ack-poison-message")
+	update := models.ProducerSchemaUpdate{
+		UpdateType: models.SchemaUpdateTypeDrop,
 	}
 
-	c.IndentedJSON(200, gin.H{})
+	s.updateStationProducersOfSchemaChange(sn, update)
+	return nil
 }
 
-func (sh StationsHandler) ResendPoisonMessages(c *gin.Context) {
-	var body models.ResendPoisonMessagesSchema
-	ok := utils.Validate(c, &body, false, nil)
-	if !ok {
+func (s *Server) removeSchemaFromStationDirect(c *client, reply string, msg []byte) {
+	var dsr detachSchemaRequest
+	if err := json.Unmarshal(msg, &dsr); err != nil {
+		s.Errorf("removeSchemaFromStationDirect: failed removing schema at station " + dsr.StationName + ": " + err.Error())
+		respondWithErr(s, reply, err)
+		return
+	}
+	stationName, err := StationNameFromStr(dsr.StationName)
+	if err != nil {
+		serv.Warnf("removeSchemaFromStationDirect: At station " + dsr.StationName + ": " + err.Error())
+		respondWithErr(s, reply, err)
+		return
+	}
+	exist, station, err := IsStationExist(stationName)
+	if err != nil {
+		serv.Errorf("removeSchemaFromStationDirect: At station " + dsr.StationName + ": " + err.Error())
+		respondWithErr(s, reply, err)
 		return
 	}
-	timeout := 1 * time.Second
-	splitId := strings.Split(body.PoisonMessageIds[0], dlsMsgSep)
-	stationName := splitId[0]
-	sn, err := StationNameFromStr(stationName)
+	if !exist {
+		errMsg := "Station " + stationName.Ext() + " does not exist"
+		serv.Warnf("removeSchemaFromStationDirect: " + errMsg)
+		respondWithErr(s, reply, errors.New("memphis: "+errMsg))
+		return
+	}
+	err = validateSchemaDetach(station, dsr.OverrideSchemaRequired)
 	if err != nil {
-		serv.Errorf("ResendPoisonMessages: " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		serv.Warnf("removeSchemaFromStationDirect: " + err.Error())
+		respondWithErr(s, reply, errors.New("memphis: "+err.Error()))
 		return
 	}
-	streamName := fmt.Sprintf(dlsStreamName, sn.Intern())
-	for _, msgId := range body.PoisonMessageIds {
-		uid := serv.memphis.nuid.Next()
-		durableName := "$memphis_fetch_dls_consumer_" + uid
-		var msgs []StoredMsg
-		streamInfo, err := serv.memphisStreamInfo(streamName)
-		if err != nil {
-			serv.Errorf("ResendPoisonMessages: " + err.Error())
-			c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-			return
-		}
-		filter := GetDlsSubject("poison", sn.Intern(), msgId)
-		amount := streamInfo.State.Msgs
-		cc := ConsumerConfig{
-			DeliverPolicy: DeliverAll,
-			AckPolicy:     AckExplicit,
-			Durable:       durableName,
-			FilterSubject: filter,
-		}
-
-		err = serv.memphisAddConsumer(streamName, &cc)
-		if err != nil {
-			serv.Errorf("ResendPoisonMessages: " + err.Error())
-			c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-			return
-		}
-
-		responseChan := make(chan StoredMsg)
-		subject := fmt.Sprintf(JSApiRequestNextT, streamName, durableName)
-		reply := durableName + "_reply"
-		req := []byte(strconv.FormatUint(amount, 10))
-
-		sub, err := serv.subscribeOnGlobalAcc(reply, reply+"_sid", func(_ *client, subject, reply string, msg []byte) {
-			go func(respCh chan StoredMsg, subject, reply string, msg []byte) {
-				// ack
-				serv.sendInternalAccountMsg(serv.GlobalAccount(), reply, []byte(_EMPTY_))
-				rawTs := tokenAt(reply, 8)
-				seq, _, _ := ackReplyInfo(reply)
-
-				intTs, err := strconv.Atoi(rawTs)
-				if err != nil {
-					serv.Errorf("ResendPoisonMessages: " + err.Error())
-				}
-
-				respCh <- StoredMsg{
-					Subject:  subject,
-					Sequence: uint64(seq),
-					Data:     msg,
-					Time:     time.Unix(0, int64(intTs)),
-				}
-			}(responseChan, subject, reply, copyBytes(msg))
-		})
-		if err != nil {
-			serv.Errorf("ResendPoisonMessages: " + err.Error())
-			c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-			return
-		}
-
-		serv.sendInternalAccountMsgWithReply(serv.GlobalAccount(), subject, reply, nil, req, true)
-
-		timer := time.NewTimer(timeout)
-		for i := uint64(0); i < amount; i++ {
-			select {
-			case <-timer.C:
-				goto cleanup
-			case msg := <-responseChan:
-				msgs = append(msgs, msg)
-			}
-		}
-
-	cleanup:
-		timer.Stop()
-		serv.unsubscribeOnGlobalAcc(sub)
-		err = serv.memphisRemoveConsumer(streamName, durableName)
-		if err != nil {
-			serv.Errorf("ResendPoisonMessages: " + err.Error())
-			c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-			return
-		}
 
-		for _, msg := range msgs {
-			var dlsMsg models.DlsMessage
-			err = json.Unmarshal(msg.Data, &dlsMsg)
-			if err != nil {
-				serv.Errorf("ResendPoisonMessages: " + err.Error())
-				c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-				return
-			}
-			stationName := replaceDelimiters(dlsMsg.StationName)
-			cgName := replaceDelimiters(dlsMsg.PoisonedCg.CgName)
-			headersJson := map[string]string{}
-			for key, value := range dlsMsg.Message.Headers {
-				headersJson[key] = value
-			}
-			headersJson["$memphis_pm_id"] = dlsMsg.ID
-			headersJson["$memphis_pm_sequence"] = strconv.FormatUint(msg.Sequence, 10)
-			headers, err := json.Marshal(headersJson)
-			if err != nil {
-				serv.Errorf("ResendPoisonMessages: Poisoned consumer group: " + dlsMsg.PoisonedCg.CgName + ": " + err.Error())
-				c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-				return
-			}
-			data, err := hex.DecodeString(dlsMsg.Message.Data)
-			if err != nil {
-				serv.Errorf("ResendPoisonMessages: Poisoned consumer group: " + dlsMsg.PoisonedCg.CgName + ": " + err.Error())
-				c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-				return
-			}
-			err = sh.S.ResendPoisonMessage("$memphis_dls_"+stationName+"_"+cgName, []byte(data), headers)
-			if err != nil {
-				serv.Errorf("ResendPoisonMessages: Poisoned consumer group: " + dlsMsg.PoisonedCg.CgName + ": " + err.Error())
-				c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-				return
-			}
-		}
+	err = removeSchemaFromStation(context.TODO(), serv, stationName, true)
+	if err != nil {
+		serv.Errorf("removeSchemaFromStationDirect: At station " + dsr.StationName + ": " + err.Error())
+		respondWithErr(s, reply, err)
+		return
 	}
+	respondWithErr(s, reply, nil)
+}
 
-	shouldSendAnalytics, _ := shouldSendAnalytics()
-	if shouldSendAnalytics {
-		user, _ := getUserDetailsFromMiddleware(c)
-		analytics.SendEvent(user.Username, "user-resend-poison-message")
-	}
+func (sh StationsHandler) RemoveSchemaFromStation(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
 
-	c.IndentedJSON(200, gin.H{})
-}
+	if err := DenyForSandboxEnv(c); err != nil {
+		return
+	}
 
-func (sh StationsHandler) GetMessageDetails(c *gin.Context) {
-	var body models.GetMessageDetailsSchema
+	var body models.RemoveSchemaFromStation
 	ok := utils.Validate(c, &body, false, nil)
 	if !ok {
 		return
 	}
 
-	if body.IsPoisonMessage {
-		poisonMessage, err := sh.GetDlsMessageJourneyDetails(body.MessageId)
-		if err != nil {
-			serv.Errorf("GetMessageDetails: Message ID: " + body.MessageId + ": " + err.Error())
-			c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-			return
-		}
-
-		c.IndentedJSON(200, poisonMessage)
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("RemoveSchemaFromStation: At station" + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
 		return
 	}
-
-	stationName, err := StationNameFromStr(body.StationName)
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
 	if err != nil {
-		serv.Warnf("GetMessageDetails: Message ID: " + body.MessageId + ": " + err.Error())
-		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error()})
+		serv.Errorf("RemoveSchemaFromStation: At station" + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
 		return
 	}
-
-	exist, station, err := IsStationExist(stationName)
 	if !exist {
-		errMsg := "Station " + stationName.external + " does not exist"
-		serv.Warnf("GetMessageDetails: " + errMsg)
-		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg})
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("RemoveSchemaFromStation: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+	if station.Schema.SchemaName == "" {
+		// detach is idempotent, nothing to remove and nothing to audit
+		c.IndentedJSON(200, gin.H{"message": "Station " + stationName.Ext() + " has no schema attached"})
 		return
 	}
+	err = validateSchemaDetach(station, body.OverrideSchemaRequired)
 	if err != nil {
-		serv.Errorf("GetMessageDetails: Message ID: " + body.MessageId + ": " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		serv.Warnf("RemoveSchemaFromStation: " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
 		return
 	}
 
-	sm, err := sh.S.GetMessage(stationName, uint64(body.MessageSeq))
+	err = removeSchemaFromStation(ctx, sh.S, stationName, true)
 	if err != nil {
-		serv.Errorf("GetMessageDetails: Message ID: Message ID: " + body.MessageId + ": " + body.MessageId + ": " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		serv.Errorf("RemoveSchemaFromStation: At station" + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
 		return
 	}
 
-	if !station.IsNative {
-		msg := models.MessageResponse{
-			MessageSeq: body.MessageSeq,
-			Message: models.MessagePayload{
-				TimeSent: sm.Time,
-				Size:     len(sm.Subject) + len(sm.Data) + len(sm.Header),
-				Data:     hex.EncodeToString(sm.Data),
-				Headers:  map[string]string{},
-			},
-			Producer: models.ProducerDetails{
-				Name:          "",
-				ConnectionId:  primitive.ObjectID{},
-				ClientAddress: "",
-				CreatedByUser: "",
-				IsActive:      false,
-				IsDeleted:     false,
-			},
-			PoisonedCgs: []models.PoisonedCg{},
-		}
-		c.IndentedJSON(200, msg)
-		return
+	user, err := getUserDetailsFromMiddleware(c)
+	if err != nil {
+		serv.Errorf("RemoveSchemaFromStation: At station" + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(401, gin.H{"message": "Unauthorized", "code": ErrCodeUnauthorized})
 	}
-	headersJson, err := DecodeHeader(sm.Header)
+	message := "Schema " + station.Schema.SchemaName + " has been deleted from station " + stationName.Ext() + " by user " + user.Username
+	serv.Noticef(message)
+	var auditLogs []interface{}
+	newAuditLog := models.AuditLog{
+		ID:            primitive.NewObjectID(),
+		StationName:   stationName.Intern(),
+		Message:       message,
+		CreatedByUser: user.Username,
+		CreationDate:  time.Now(),
+		UserType:      user.UserType,
+	}
+	auditLogs = append(auditLogs, newAuditLog)
+	err = CreateAuditLogs(auditLogs)
 	if err != nil {
-		serv.Errorf("GetMessageDetails: Message ID: " + body.MessageId + ": " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-		return
+		serv.Errorf("RemoveSchemaFromStation: At station" + body.StationName + " - create audit logs error: " + err.Error())
 	}
 
-	connectionIdHeader := headersJson["$memphis_connectionId"]
-	producedByHeader := strings.ToLower(headersJson["$memphis_producedBy"])
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategorySchema)
+	if shouldSendAnalytics {
+		analytics.SendEvent(user.Username, "user-remove-schema-from-station")
+	}
 
-	//This check for backward compatability
-	if connectionIdHeader == "" || producedByHeader == "" {
-		connectionIdHeader = headersJson["connectionId"]
-		producedByHeader = strings.ToLower(headersJson["producedBy"])
-		if connectionIdHeader == "" || producedByHeader == "" {
-			serv.Warnf("GetMessageDetails: Error while getting notified about a poison message: Missing mandatory message headers, please upgrade the SDK version you are using")
-			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": "Error while getting notified about a poison message: Missing mandatory message headers, please upgrade the SDK version you are using"})
-			return
-		}
+	c.IndentedJSON(200, gin.H{})
+}
+
+// schemaWatchTimeout returns how long a schema changes watch waits for a change, the default when not set and capped to the max
+func schemaWatchTimeout(timeoutSec int) time.Duration {
+	if timeoutSec <= 0 {
+		return defaultSchemaWatchTimeout
+	}
+	timeout := time.Duration(timeoutSec) * time.Second
+	if timeout > maxSchemaWatchTimeout {
+		return maxSchemaWatchTimeout
 	}
+	return timeout
+}
 
-	for header := range headersJson {
-		if strings.HasPrefix(header, "$memphis") {
-			delete(headersJson, header)
-		}
+// schemaChangeFromUpdate describes a schema update broadcast to the station's producers,
+// an init update is sent when a schema is attached or upgraded and a drop update when it is detached
+func schemaChangeFromUpdate(sn StationName, update models.ProducerSchemaUpdate, changedAt time.Time) models.StationSchemaChange {
+	change := models.StationSchemaChange{
+		StationName: sn.Ext(),
+		Time:        changedAt,
+	}
+	switch update.UpdateType {
+	case models.SchemaUpdateTypeInit:
+		change.UpdateType = "init"
+		change.SchemaName = update.Init.SchemaName
+		change.VersionNumber = update.Init.ActiveVersion.VersionNumber
+		change.Enforcement = update.Init.Enforcement
+	case models.SchemaUpdateTypeDrop:
+		change.UpdateType = "drop"
+	}
+	return change
+}
+
+// stationSchemaChangeSince describes the station's current schema when it differs from the schema the client last saw,
+// any update of the station after the client's last_update is reported as well since it may have changed the enforcement
+func stationSchemaChangeSince(sn StationName, station models.Station, schemaName string, versionNumber int, lastUpdate time.Time) (models.StationSchemaChange, bool) {
+	if station.Schema.SchemaName == schemaName && station.Schema.VersionNumber == versionNumber && !station.LastUpdate.After(lastUpdate) {
+		return models.StationSchemaChange{}, false
+	}
+	change := models.StationSchemaChange{
+		StationName: sn.Ext(),
+		UpdateType:  "drop",
+		Time:        station.LastUpdate,
 	}
+	if station.Schema.SchemaName != "" {
+		change.UpdateType = "init"
+		change.SchemaName = station.Schema.SchemaName
+		change.VersionNumber = station.Schema.VersionNumber
+		change.Enforcement = getStationSchemaEnforcement(station)
+	}
+	return change, true
+}
 
-	connectionId, _ := primitive.ObjectIDFromHex(connectionIdHeader)
-	poisonedCgs, err := GetPoisonedCgsByMessage(stationName.Intern(), models.MessageDetails{MessageSeq: int(sm.Sequence), ProducedBy: producedByHeader, TimeSent: sm.Time})
+// WatchStationSchemaChanges long polls the schema updates broadcast to the station's producers, it returns the first
+// attach, upgrade or detach of the station's schema, or changed false once the timeout expires and the client should poll again.
+// a client passing the schema and last_update it last saw gets the current state right away when it changed in between polls
+func (sh StationsHandler) WatchStationSchemaChanges(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.WatchStationSchemaChangesSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
 	if err != nil {
-		serv.Errorf("GetMessageDetails: Message ID: " + body.MessageId + ": " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		serv.Warnf("WatchStationSchemaChanges: At station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
 		return
 	}
 
-	for i, cg := range poisonedCgs {
-		cgInfo, err := sh.S.GetCgInfo(stationName, cg.CgName)
+	// subscribing before reading the station so no change is missed in between
+	updates := make(chan models.ProducerSchemaUpdate, 1)
+	subject := fmt.Sprintf(schemaUpdatesSubjectTemplate, stationName.Intern())
+	sid := subject + "_watch_" + sh.S.memphis.nuid.Next()
+	sub, err := sh.S.subscribeOnGlobalAcc(subject, sid, func(_ *client, subject, reply string, msg []byte) {
+		var update models.ProducerSchemaUpdate
+		err := json.Unmarshal(msg, &update)
 		if err != nil {
-			serv.Errorf("GetMessageDetails: Message ID: " + body.MessageId + ": " + err.Error())
-			c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+			serv.Errorf("WatchStationSchemaChanges: At station " + stationName.Ext() + ": " + err.Error())
 			return
 		}
-
-		totalPoisonMsgs, err := GetTotalPoisonMsgsByCg(stationName.Ext(), cg.CgName)
-		if err != nil {
-			serv.Errorf("GetMessageDetails: Message ID: " + body.MessageId + ": " + err.Error())
-			c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-			return
+		select {
+		case updates <- update:
+		default:
 		}
+	})
+	if err != nil {
+		serv.Errorf("WatchStationSchemaChanges: At station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	defer sh.S.unsubscribeOnGlobalAcc(sub)
 
-		cgMembers, err := GetConsumerGroupMembers(cg.CgName, station)
-		if err != nil {
-			serv.Errorf("GetMessageDetails: Message ID: " + body.MessageId + ": " + err.Error())
-			c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("WatchStationSchemaChanges: At station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("WatchStationSchemaChanges: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+	if !body.LastUpdate.IsZero() {
+		change, changed := stationSchemaChangeSince(stationName, station, body.SchemaName, body.VersionNumber, body.LastUpdate)
+		if changed {
+			c.IndentedJSON(200, gin.H{"changed": true, "change": change})
 			return
 		}
+	}
 
-		isActive, isDeleted := getCgStatus(cgMembers)
+	timer := time.NewTimer(schemaWatchTimeout(body.TimeoutSec))
+	defer timer.Stop()
+	select {
+	case update := <-updates:
+		c.IndentedJSON(200, gin.H{"changed": true, "change": schemaChangeFromUpdate(stationName, update, time.Now())})
+	case <-timer.C:
+		c.IndentedJSON(200, gin.H{"changed": false})
+	case <-c.Request.Context().Done():
+	}
+}
 
-		poisonedCgs[i].MaxAckTimeMs = cgMembers[0].MaxAckTimeMs
-		poisonedCgs[i].MaxMsgDeliveries = cgMembers[0].MaxMsgDeliveries
-		poisonedCgs[i].UnprocessedMessages = int(cgInfo.NumPending)
-		poisonedCgs[i].InProcessMessages = cgInfo.NumAckPending
-		poisonedCgs[i].TotalPoisonMessages = totalPoisonMsgs
-		poisonedCgs[i].IsActive = isActive
-		poisonedCgs[i].IsDeleted = isDeleted
+func (sh StationsHandler) GetUpdatesForSchemaByStation(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.GetUpdatesForSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
 	}
 
-	filter := bson.M{"name": producedByHeader, "station_id": station.ID, "connection_id": connectionId}
-	var producer models.Producer
-	err = producersCollection.FindOne(context.TODO(), filter).Decode(&producer)
+	stationName, err := StationNameFromStr(body.StationName)
 	if err != nil {
-		serv.Errorf("GetMessageDetails: " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		serv.Warnf("GetUpdatesForSchemaByStation: At station" + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
 		return
 	}
 
-	_, conn, err := IsConnectionExist(connectionId)
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
 	if err != nil {
-		serv.Errorf("GetMessageDetails: " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		serv.Errorf("GetUpdatesForSchemaByStation: At station" + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("GetUpdatesForSchemaByStation: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
 		return
 	}
 
-	sort.Slice(poisonedCgs, func(i, j int) bool {
-		return poisonedCgs[i].PoisoningTime.After(poisonedCgs[j].PoisoningTime)
-	})
+	var schema models.Schema
+	err = schemasCollection.FindOne(ctx, bson.M{"name": station.Schema.SchemaName}).Decode(&schema)
+	if err != nil {
+		serv.Errorf("GetUpdatesForSchemaByStation: At station" + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
 
-	msg := models.MessageResponse{
-		MessageSeq: body.MessageSeq,
-		Message: models.MessagePayload{
-			TimeSent: sm.Time,
-			Size:     len(sm.Subject) + len(sm.Data) + len(sm.Header),
-			Data:     hex.EncodeToString(sm.Data),
-			Headers:  headersJson,
-		},
-		Producer: models.ProducerDetails{
-			Name:          producedByHeader,
-			ConnectionId:  connectionId,
-			ClientAddress: conn.ClientAddress,
-			CreatedByUser: producer.CreatedByUser,
-			IsActive:      producer.IsActive,
-			IsDeleted:     producer.IsDeleted,
-		},
-		PoisonedCgs: poisonedCgs,
+	schemasHandler := SchemasHandler{S: sh.S}
+	extedndedSchemaDetails, err := schemasHandler.getExtendedSchemaDetailsUpdateAvailable(station.Schema.VersionNumber, schema)
+
+	if err != nil {
+		serv.Errorf("GetUpdatesForSchemaByStation: At station" + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": err.Error(), "code": ErrCodeServerError})
+		return
+	}
+
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategorySchema)
+	if shouldSendAnalytics {
+		user, _ := getUserDetailsFromMiddleware(c)
+		analytics.SendEvent(user.Username, "user-apply-schema-updates-on-station")
+	}
+
+	c.IndentedJSON(200, extedndedSchemaDetails)
+}
+
+func (sh StationsHandler) TierdStorageClicked(c *gin.Context) {
+	shouldSendAnalytics, _ := shouldSendAnalyticsForCategory(analyticsCategoryStation)
+	if shouldSendAnalytics {
+		user, _ := getUserDetailsFromMiddleware(c)
+		analytics.SendEvent(user.Username, "user-pushed-tierd-storage-button")
 	}
-	c.IndentedJSON(200, msg)
+
+	c.IndentedJSON(200, gin.H{})
 }
 
-func (sh StationsHandler) UseSchema(c *gin.Context) {
-	var body models.UseSchema
+func (sh StationsHandler) UpdateDlsConfig(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.UpdateDlsConfigSchema
 	ok := utils.Validate(c, &body, false, nil)
 	if !ok {
 		return
 	}
 
-	schemaName := strings.ToLower(body.SchemaName)
-	exist, schema, err := IsSchemaExist(schemaName)
+	stationName, err := StationNameFromStr(body.StationName)
 	if err != nil {
-		serv.Errorf("UseSchema: Schema " + body.SchemaName + ": " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server Error"})
-		return
-	}
-	if !exist {
-		errMsg := "Schema " + schemaName + " does not exist"
-		serv.Warnf("UseSchema: " + errMsg)
-		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg})
+		serv.Warnf("DlsConfiguration: At station" + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
 		return
 	}
 
-	schemaVersion, err := getActiveVersionBySchemaId(schema.ID)
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
 	if err != nil {
-		serv.Errorf("UseSchema: Schema " + body.SchemaName + ": " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": err.Error()})
+		serv.Errorf("DlsConfiguration: At station" + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
 		return
 	}
-	schemaDetailsResponse := models.StationOverviewSchemaDetails{SchemaName: schemaName, VersionNumber: schemaVersion.VersionNumber, UpdatesAvailable: false}
-	schemaDetails := models.SchemaDetails{SchemaName: schemaName, VersionNumber: schemaVersion.VersionNumber}
-
-	user, err := getUserDetailsFromMiddleware(c)
-	if err != nil {
-		serv.Errorf("UseSchema: Schema " + body.SchemaName + ": " + err.Error())
-		c.AbortWithStatusJSON(401, gin.H{"message": "Unauthorized"})
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("DlsConfiguration: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
 	}
 
-	for _, stationName := range body.StationNames {
-		stationName, err := StationNameFromStr(stationName)
+	// a missing webhook keeps the current one, an empty url removes it
+	webhook := station.DlsConfiguration.Webhook
+	if body.Webhook != nil {
+		restoreRedactedDlsWebhook(body.Webhook, station.DlsConfiguration.Webhook)
+		err = validateDlsWebhook(body.Webhook)
 		if err != nil {
-			serv.Warnf("UseSchema: Schema " + body.SchemaName + " at station " + stationName.Ext() + ": " + err.Error())
-			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error()})
+			serv.Warnf("DlsConfiguration: At station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
 			return
 		}
+		webhook = body.Webhook
+		if webhook.Url == "" {
+			webhook = nil
+		}
+	}
 
-		exist, station, err := IsStationExist(stationName)
-		if err != nil {
-			serv.Errorf("UseSchema: Schema " + body.SchemaName + " at station " + stationName.Ext() + ": " + err.Error())
-			c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
-			return
+	if station.DlsConfiguration.Poison != body.Poison || station.DlsConfiguration.Schemaverse != body.Schemaverse || !reflect.DeepEqual(station.DlsConfiguration.Webhook, webhook) {
+		dlsConfigurationNew := models.DlsConfiguration{
+			Poison:      body.Poison,
+			Schemaverse: body.Schemaverse,
+			Webhook:     webhook,
 		}
-		if !exist {
-			errMsg := "Station " + station.Name + " does not exist"
-			serv.Warnf("UseSchema: Schema " + body.SchemaName + ": " + errMsg)
-			c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg})
-			return
+		filter := bson.M{
+			"name": body.StationName,
+			"$or": []interface{}{
+				bson.M{"is_deleted": false},
+				bson.M{"is_deleted": bson.M{"$exists": false}},
+			}}
+
+		update := bson.M{
+			"$set": bson.M{
+				"dls_configuration": dlsConfigurationNew,
+			},
 		}
+		opts := options.Update().SetUpsert(true)
 
-		_, err = stationsCollection.UpdateOne(context.TODO(), bson.M{"name": stationName.Ext(), "is_deleted": false}, bson.M{"$set": bson.M{"schema": schemaDetails}})
+		_, err := stationsCollection.UpdateOne(ctx, filter, update, opts)
 		if err != nil {
-			serv.Errorf("UseSchema: Schema " + body.SchemaName + " at station " + stationName.Ext() + ": " + err.Error())
-			c.AbortWithStatusJSON(500, gin.H{"message": err.Error()})
+			serv.Errorf("DlsConfiguration: At station" + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
 			return
 		}
+	}
+	dlsConfiguration := redactDlsConfiguration(models.DlsConfiguration{Webhook: webhook})
+	c.IndentedJSON(200, gin.H{"poison": body.Poison, "schemaverse": body.Schemaverse, "webhook": dlsConfiguration.Webhook})
+}
 
-		message := "Schema " + schemaName + " has been attached to station " + stationName.Ext() + " by user " + user.Username
-		serv.Noticef(message)
+func (sh StationsHandler) UpdateMaxMsgSize(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
 
-		var auditLogs []interface{}
-		newAuditLog := models.AuditLog{
-			ID:            primitive.NewObjectID(),
-			StationName:   stationName.Intern(),
-			Message:       message,
-			CreatedByUser: user.Username,
-			CreationDate:  time.Now(),
-			UserType:      user.UserType,
+	var body models.UpdateMaxMsgSizeSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf("UpdateMaxMsgSize: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	err = validateMaxMsgSize(body.MaxMsgSizeBytes)
+	if err != nil {
+		serv.Warnf("UpdateMaxMsgSize: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("UpdateMaxMsgSize: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("UpdateMaxMsgSize: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	if getStationMaxMsgSize(station) != body.MaxMsgSizeBytes {
+		streamInfo, err := sh.S.memphisStreamInfo(stationName.Intern())
+		if err != nil {
+			serv.Errorf("UpdateMaxMsgSize: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
 		}
-		auditLogs = append(auditLogs, newAuditLog)
-		err = CreateAuditLogs(auditLogs)
+		streamConfig := streamInfo.Config
+		streamConfig.MaxMsgSize = int32(body.MaxMsgSizeBytes)
+		err = sh.S.memphisUpdateStream(&streamConfig)
 		if err != nil {
-			serv.Errorf("UseSchema: Schema " + body.SchemaName + " at station " + stationName.Ext() + " - create audit logs: " + err.Error())
+			serv.Errorf("UpdateMaxMsgSize: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
 		}
 
-		updateContent, err := generateSchemaUpdateInit(schema)
+		_, err = stationsCollection.UpdateOne(ctx,
+			bson.M{"_id": station.ID},
+			bson.M{"$set": bson.M{"max_msg_size_bytes": body.MaxMsgSizeBytes, "last_update": time.Now()}},
+		)
 		if err != nil {
-			serv.Errorf("UseSchema: Schema " + body.SchemaName + " at station " + stationName.Ext() + ": " + err.Error())
+			serv.Errorf("UpdateMaxMsgSize: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
 			return
 		}
-		update := models.ProducerSchemaUpdate{
-			UpdateType: models.SchemaUpdateTypeInit,
-			Init:       *updateContent,
-		}
-		sh.S.updateStationProducersOfSchemaChange(stationName, update)
-	}
-
-	shouldSendAnalytics, _ := shouldSendAnalytics()
-	if shouldSendAnalytics {
-		user, _ := getUserDetailsFromMiddleware(c)
-		analytics.SendEvent(user.Username, "user-attach-schema-to-station")
 	}
 
-	c.IndentedJSON(200, schemaDetailsResponse)
+	c.IndentedJSON(200, gin.H{"max_msg_size_bytes": body.MaxMsgSizeBytes})
 }
 
-func (s *Server) useSchemaDirect(c *client, reply string, msg []byte) {
-	var asr attachSchemaRequest
-	if err := json.Unmarshal(msg, &asr); err != nil {
-		errMsg := "failed attaching schema " + asr.Name + ": " + err.Error()
-		s.Errorf("useSchemaDirect: At station " + asr.StationName + " " + errMsg)
-		respondWithErr(s, reply, errors.New(errMsg))
+// PinRetention stops the station's retention from trimming messages for the given duration, e.g. while they are about to be replayed,
+// the stored retention is left untouched and restored on the stream once the pin expires
+func (sh StationsHandler) PinRetention(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.PinRetentionSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
 		return
 	}
-	stationName, err := StationNameFromStr(asr.StationName)
-	if err != nil {
-		serv.Warnf("useSchemaDirect: Schema " + asr.Name + " at station " + asr.StationName + ": " + err.Error())
-		respondWithErr(s, reply, err)
+
+	duration := time.Duration(body.DurationMinutes) * time.Minute
+	if duration > maxRetentionPinDuration {
+		errMsg := "the retention can be pinned for up to " + strconv.Itoa(int(maxRetentionPinDuration.Minutes())) + " minutes"
+		serv.Warnf("PinRetention: Station " + body.StationName + ": " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeInvalidRequest})
 		return
 	}
 
-	exist, _, err := IsStationExist(stationName)
+	stationName, err := StationNameFromStr(body.StationName)
 	if err != nil {
-		serv.Errorf("useSchemaDirect: Schema " + asr.Name + " at station " + asr.StationName + ": " + err.Error())
-		respondWithErr(s, reply, err)
+		serv.Warnf("PinRetention: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
 		return
 	}
 
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("PinRetention: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
 	if !exist {
-		errMsg := "Station " + stationName.external + " does not exist"
-		serv.Warnf("useSchemaDirect: " + errMsg)
-		respondWithErr(s, reply, errors.New("memphis: "+errMsg))
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("PinRetention: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
 		return
 	}
-
-	var schemaDetails models.SchemaDetails
-	schemaName := strings.ToLower(asr.Name)
-	exist, schema, err := IsSchemaExist(schemaName)
-	if err != nil {
-		serv.Errorf("useSchemaDirect: Schema " + asr.Name + " at station " + asr.StationName + ": " + err.Error())
-		respondWithErr(s, reply, err)
+	if retentionIgnoresValue(station.RetentionType) {
+		errMsg := "The retention of station " + stationName.Ext() + " does not trim messages by age, amount or size"
+		serv.Warnf("PinRetention: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeRetentionInvalid})
 		return
 	}
-	if !exist {
-		errMsg := "Schema " + schemaName + " does not exist" + err.Error()
-		serv.Warnf("useSchemaDirect: " + errMsg)
-		respondWithErr(s, reply, errors.New(errMsg))
+
+	user, err := getUserDetailsFromMiddleware(c)
+	if err != nil {
+		serv.Errorf("PinRetention: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(401, gin.H{"message": "Unauthorized", "code": ErrCodeUnauthorized})
 		return
 	}
 
-	schemaVersion, err := getActiveVersionBySchemaId(schema.ID)
+	// pinning again replaces the expiration of the current pin
+	pin := models.RetentionPin{ExpiresAt: time.Now().Add(duration), PinnedBy: user.Username, UserType: user.UserType}
+	_, err = stationsCollection.UpdateOne(ctx,
+		bson.M{"_id": station.ID},
+		bson.M{"$set": bson.M{"retention_pin": pin, "last_update": time.Now()}},
+	)
 	if err != nil {
-		serv.Errorf("useSchemaDirect: Schema " + asr.Name + " at station " + asr.StationName + ": " + err.Error())
-		respondWithErr(s, reply, err)
+		serv.Errorf("PinRetention: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
 		return
 	}
-	schemaDetails = models.SchemaDetails{SchemaName: schemaName, VersionNumber: schemaVersion.VersionNumber}
 
-	_, err = stationsCollection.UpdateOne(context.TODO(), bson.M{"name": stationName.Ext(), "is_deleted": false}, bson.M{"$set": bson.M{"schema": schemaDetails}})
+	station.RetentionPin = &pin
+	err = sh.S.applyStationRetention(stationName, station)
 	if err != nil {
-		serv.Errorf("useSchemaDirect: Schema " + asr.Name + " at station " + asr.StationName + ": " + err.Error())
-		respondWithErr(s, reply, err)
+		serv.Errorf("PinRetention: Station " + body.StationName + ": " + err.Error())
+		// the rollback must run even when the request was cancelled, otherwise the pin would be left without a stream to match
+		_, rollbackErr := stationsCollection.UpdateOne(context.TODO(),
+			bson.M{"_id": station.ID, "retention_pin": pin},
+			bson.M{"$unset": bson.M{"retention_pin": ""}},
+		)
+		if rollbackErr != nil {
+			serv.Errorf("PinRetention: Station " + body.StationName + " - rollback: " + rollbackErr.Error())
+		}
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
 		return
 	}
+	time.AfterFunc(duration, sh.S.revertExpiredRetentionPins)
 
-	username := c.getClientInfo(true).Name
-	message := "Schema " + schemaName + " has been attached to station " + stationName.Ext() + " by user " + username
+	message := "Retention of station " + stationName.Ext() + " has been pinned until " + pin.ExpiresAt.UTC().Format(time.RFC3339) + " by user " + user.Username
 	serv.Noticef(message)
-
 	var auditLogs []interface{}
 	newAuditLog := models.AuditLog{
 		ID:            primitive.NewObjectID(),
-		StationName:   stationName.Intern(),
+		StationName:   stationName.Ext(),
 		Message:       message,
-		CreatedByUser: username,
+		CreatedByUser: user.Username,
 		CreationDate:  time.Now(),
-		UserType:      "sdk",
+		UserType:      user.UserType,
 	}
 	auditLogs = append(auditLogs, newAuditLog)
 	err = CreateAuditLogs(auditLogs)
 	if err != nil {
-		serv.Errorf("useSchemaDirect : Schema " + asr.Name + " at station " + asr.StationName + " - create audit logs: " + err.Error())
+		serv.Warnf("PinRetention: Station " + body.StationName + " - create audit logs error: " + err.Error())
 	}
 
-	shouldSendAnalytics, _ := shouldSendAnalytics()
-	if shouldSendAnalytics {
-		analytics.SendEvent("sdk", "user-attach-schema-to-station")
-	}
+	c.IndentedJSON(200, gin.H{
+		"station_name":         stationName.Ext(),
+		"retention_pin":        pin,
+		"retention_descriptor": getRetentionDescriptor(station.RetentionType, station.RetentionValue),
+	})
+}
 
-	updateContent, err := generateSchemaUpdateInit(schema)
+// revertExpiredRetentionPins restores the retention of the stations whose pin has expired,
+// the pin is matched on removal so the revert is audited once even when several brokers race on it
+func (s *Server) revertExpiredRetentionPins() {
+	var stations []models.Station
+	filter := bson.M{"is_deleted": false, "retention_pin.expires_at": bson.M{"$lte": time.Now()}}
+	cursor, err := stationsCollection.Find(context.TODO(), filter)
 	if err != nil {
-		serv.Errorf("useSchemaDirect: Schema " + asr.Name + " at station " + asr.StationName + ": " + err.Error())
+		s.Errorf("revertExpiredRetentionPins: " + err.Error())
 		return
 	}
-
-	update := models.ProducerSchemaUpdate{
-		UpdateType: models.SchemaUpdateTypeInit,
-		Init:       *updateContent,
+	if err = cursor.All(context.TODO(), &stations); err != nil {
+		s.Errorf("revertExpiredRetentionPins: " + err.Error())
+		return
 	}
 
-	serv.updateStationProducersOfSchemaChange(stationName, update)
-	respondWithErr(s, reply, nil)
-}
-
-func removeSchemaFromStation(s *Server, sn StationName, updateDB bool) error {
-	exist, _, err := IsStationExist(sn)
-	if err != nil {
-		return err
-	}
-	if !exist {
-		return errors.New("Station " + sn.external + " does not exist")
-	}
+	for _, station := range stations {
+		pin := station.RetentionPin
+		stationName, err := StationNameFromStr(station.Name)
+		if err != nil {
+			s.Errorf("revertExpiredRetentionPins: Station " + station.Name + ": " + err.Error())
+			continue
+		}
 
-	if updateDB {
-		_, err = stationsCollection.UpdateOne(context.TODO(),
-			bson.M{
-				"name": sn.Ext(),
-				"$or": []interface{}{
-					bson.M{"is_deleted": false},
-					bson.M{"is_deleted": bson.M{"$exists": false}},
-				},
-			},
-			bson.M{"$set": bson.M{"schema": bson.M{}}},
+		station.RetentionPin = nil
+		err = s.applyStationRetention(stationName, station)
+		if err != nil {
+			s.Errorf("revertExpiredRetentionPins: Station " + station.Name + ": " + err.Error())
+			continue
+		}
+		res, err := stationsCollection.UpdateOne(context.TODO(),
+			bson.M{"_id": station.ID, "retention_pin": pin},
+			bson.M{"$unset": bson.M{"retention_pin": ""}, "$set": bson.M{"last_update": time.Now()}},
 		)
 		if err != nil {
-			return err
+			s.Errorf("revertExpiredRetentionPins: Station " + station.Name + ": " + err.Error())
+			continue
+		}
+		if res.ModifiedCount == 0 {
+			continue
 		}
-	}
 
-	update := models.ProducerSchemaUpdate{
-		UpdateType: models.SchemaUpdateTypeDrop,
+		message := "Retention of station " + stationName.Ext() + " has been restored to " + getRetentionDescriptor(station.RetentionType, station.RetentionValue) + " after the pin of user " + pin.PinnedBy + " expired"
+		s.Noticef(message)
+		var auditLogs []interface{}
+		newAuditLog := models.AuditLog{
+			ID:            primitive.NewObjectID(),
+			StationName:   stationName.Ext(),
+			Message:       message,
+			CreatedByUser: pin.PinnedBy,
+			CreationDate:  time.Now(),
+			UserType:      pin.UserType,
+		}
+		auditLogs = append(auditLogs, newAuditLog)
+		err = CreateAuditLogs(auditLogs)
+		if err != nil {
+			s.Warnf("revertExpiredRetentionPins: Station " + station.Name + " - create audit logs error: " + err.Error())
+		}
 	}
-
-	s.updateStationProducersOfSchemaChange(sn, update)
-	return nil
 }
 
-func (s *Server) removeSchemaFromStationDirect(c *client, reply string, msg []byte) {
-	var dsr detachSchemaRequest
-	if err := json.Unmarshal(msg, &dsr); err != nil {
-		s.Errorf("removeSchemaFromStationDirect: failed removing schema at station " + dsr.StationName + ": " + err.Error())
-		respondWithErr(s, reply, err)
+func (sh StationsHandler) UpdateDeletionProtection(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.UpdateDeletionProtectionSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
 		return
 	}
-	stationName, err := StationNameFromStr(dsr.StationName)
+
+	stationName, err := StationNameFromStr(body.StationName)
 	if err != nil {
-		serv.Warnf("removeSchemaFromStationDirect: At station " + dsr.StationName + ": " + err.Error())
-		respondWithErr(s, reply, err)
+		serv.Warnf("UpdateDeletionProtection: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("UpdateDeletionProtection: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf("UpdateDeletionProtection: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
 		return
 	}
 
-	err = removeSchemaFromStation(serv, stationName, true)
-	if err != nil {
-		serv.Errorf("removeSchemaFromStationDirect: At station " + dsr.StationName + ": " + err.Error())
-		respondWithErr(s, reply, err)
-		return
+	if station.DeletionProtected != body.DeletionProtected {
+		user, err := getUserDetailsFromMiddleware(c)
+		if err != nil {
+			serv.Errorf("UpdateDeletionProtection: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(401, gin.H{"message": "Unauthorized", "code": ErrCodeUnauthorized})
+			return
+		}
+
+		_, err = stationsCollection.UpdateOne(ctx,
+			bson.M{"_id": station.ID},
+			bson.M{"$set": bson.M{"deletion_protected": body.DeletionProtected, "last_update": time.Now()}},
+		)
+		if err != nil {
+			serv.Errorf("UpdateDeletionProtection: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+
+		var message string
+		if body.DeletionProtected {
+			message = "Deletion protection has been enabled for station " + stationName.Ext() + " by user " + user.Username
+		} else {
+			message = "Deletion protection has been disabled for station " + stationName.Ext() + " by user " + user.Username
+		}
+		serv.Noticef(message)
+		var auditLogs []interface{}
+		newAuditLog := models.AuditLog{
+			ID:            primitive.NewObjectID(),
+			StationName:   stationName.Ext(),
+			Message:       message,
+			CreatedByUser: user.Username,
+			CreationDate:  time.Now(),
+			UserType:      user.UserType,
+		}
+		auditLogs = append(auditLogs, newAuditLog)
+		err = CreateAuditLogs(auditLogs)
+		if err != nil {
+			serv.Warnf("UpdateDeletionProtection: Station " + body.StationName + " - create audit logs error: " + err.Error())
+		}
 	}
-	respondWithErr(s, reply, nil)
+
+	c.IndentedJSON(200, gin.H{"deletion_protected": body.DeletionProtected})
 }
 
-func (sh StationsHandler) RemoveSchemaFromStation(c *gin.Context) {
-	if err := DenyForSandboxEnv(c); err != nil {
-		return
-	}
+func (sh StationsHandler) UpdateStationMetadata(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
 
-	var body models.RemoveSchemaFromStation
+	var body models.UpdateStationMetadataSchema
 	ok := utils.Validate(c, &body, false, nil)
 	if !ok {
 		return
@@ -1858,41 +7274,53 @@ func (sh StationsHandler) RemoveSchemaFromStation(c *gin.Context) {
 
 	stationName, err := StationNameFromStr(body.StationName)
 	if err != nil {
-		serv.Warnf("RemoveSchemaFromStation: At station" + body.StationName + ": " + err.Error())
-		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error()})
+		serv.Warnf("UpdateStationMetadata: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
 		return
 	}
-	exist, station, err := IsStationExist(stationName)
+	metadata, err := normalizeStationMetadata(body.Description, body.Metadata)
 	if err != nil {
-		serv.Errorf("RemoveSchemaFromStation: At station" + body.StationName + ": " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		serv.Warnf("UpdateStationMetadata: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("UpdateStationMetadata: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
 		return
 	}
 	if !exist {
 		errMsg := "Station " + body.StationName + " does not exist"
-		serv.Warnf("RemoveSchemaFromStation: " + errMsg)
-		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg})
+		serv.Warnf("UpdateStationMetadata: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
 		return
 	}
 
-	err = removeSchemaFromStation(sh.S, stationName, true)
+	user, err := getUserDetailsFromMiddleware(c)
 	if err != nil {
-		serv.Errorf("RemoveSchemaFromStation: At station" + body.StationName + ": " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		serv.Errorf("UpdateStationMetadata: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(401, gin.H{"message": "Unauthorized", "code": ErrCodeUnauthorized})
 		return
 	}
 
-	user, err := getUserDetailsFromMiddleware(c)
+	_, err = stationsCollection.UpdateOne(ctx,
+		bson.M{"_id": station.ID},
+		bson.M{"$set": bson.M{"description": body.Description, "metadata": metadata, "last_update": time.Now()}},
+	)
 	if err != nil {
-		serv.Errorf("RemoveSchemaFromStation: At station" + body.StationName + ": " + err.Error())
-		c.AbortWithStatusJSON(401, gin.H{"message": "Unauthorized"})
+		serv.Errorf("UpdateStationMetadata: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
 	}
-	message := "Schema " + station.Schema.SchemaName + " has been deleted from station " + stationName.Ext() + " by user " + user.Username
+
+	message := "The description and metadata of station " + stationName.Ext() + " have been updated by user " + user.Username
 	serv.Noticef(message)
 	var auditLogs []interface{}
 	newAuditLog := models.AuditLog{
 		ID:            primitive.NewObjectID(),
-		StationName:   stationName.Intern(),
+		StationName:   stationName.Ext(),
 		Message:       message,
 		CreatedByUser: user.Username,
 		CreationDate:  time.Now(),
@@ -1901,19 +7329,17 @@ func (sh StationsHandler) RemoveSchemaFromStation(c *gin.Context) {
 	auditLogs = append(auditLogs, newAuditLog)
 	err = CreateAuditLogs(auditLogs)
 	if err != nil {
-		serv.Errorf("RemoveSchemaFromStation: At station" + body.StationName + " - create audit logs error: " + err.Error())
+		serv.Warnf("UpdateStationMetadata: Station " + body.StationName + " - create audit logs error: " + err.Error())
 	}
 
-	shouldSendAnalytics, _ := shouldSendAnalytics()
-	if shouldSendAnalytics {
-		analytics.SendEvent(user.Username, "user-remove-schema-from-station")
-	}
-
-	c.IndentedJSON(200, gin.H{})
+	c.IndentedJSON(200, gin.H{"description": body.Description, "metadata": metadata})
 }
 
-func (sh StationsHandler) GetUpdatesForSchemaByStation(c *gin.Context) {
-	var body models.GetUpdatesForSchema
+func (sh StationsHandler) UpdateSchemaRequired(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.UpdateSchemaRequiredSchema
 	ok := utils.Validate(c, &body, false, nil)
 	if !ok {
 		return
@@ -1921,62 +7347,279 @@ func (sh StationsHandler) GetUpdatesForSchemaByStation(c *gin.Context) {
 
 	stationName, err := StationNameFromStr(body.StationName)
 	if err != nil {
-		serv.Warnf("GetUpdatesForSchemaByStation: At station" + body.StationName + ": " + err.Error())
-		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error()})
+		serv.Warnf("UpdateSchemaRequired: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
 		return
 	}
 
-	exist, station, err := IsStationExist(stationName)
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
 	if err != nil {
-		serv.Errorf("GetUpdatesForSchemaByStation: At station" + body.StationName + ": " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		serv.Errorf("UpdateSchemaRequired: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
 		return
 	}
 	if !exist {
 		errMsg := "Station " + body.StationName + " does not exist"
-		serv.Warnf("GetUpdatesForSchemaByStation: " + errMsg)
-		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg})
+		serv.Warnf("UpdateSchemaRequired: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
 		return
 	}
 
-	var schema models.Schema
-	err = schemasCollection.FindOne(context.TODO(), bson.M{"name": station.Schema.SchemaName}).Decode(&schema)
-	if err != nil {
-		serv.Errorf("GetUpdatesForSchemaByStation: At station" + body.StationName + ": " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+	if station.SchemaRequired != body.SchemaRequired {
+		user, err := getUserDetailsFromMiddleware(c)
+		if err != nil {
+			serv.Errorf("UpdateSchemaRequired: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(401, gin.H{"message": "Unauthorized", "code": ErrCodeUnauthorized})
+			return
+		}
+
+		_, err = stationsCollection.UpdateOne(ctx,
+			bson.M{"_id": station.ID},
+			bson.M{"$set": bson.M{"schema_required": body.SchemaRequired, "last_update": time.Now()}},
+		)
+		if err != nil {
+			serv.Errorf("UpdateSchemaRequired: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+
+		var message string
+		if body.SchemaRequired {
+			message = "Schema requirement has been enabled for station " + stationName.Ext() + " by user " + user.Username
+		} else {
+			message = "Schema requirement has been disabled for station " + stationName.Ext() + " by user " + user.Username
+		}
+		serv.Noticef(message)
+		var auditLogs []interface{}
+		newAuditLog := models.AuditLog{
+			ID:            primitive.NewObjectID(),
+			StationName:   stationName.Ext(),
+			Message:       message,
+			CreatedByUser: user.Username,
+			CreationDate:  time.Now(),
+			UserType:      user.UserType,
+		}
+		auditLogs = append(auditLogs, newAuditLog)
+		err = CreateAuditLogs(auditLogs)
+		if err != nil {
+			serv.Warnf("UpdateSchemaRequired: Station " + body.StationName + " - create audit logs error: " + err.Error())
+		}
+	}
+
+	c.IndentedJSON(200, gin.H{"schema_required": body.SchemaRequired})
+}
+
+func (sh StationsHandler) PauseStation(c *gin.Context) {
+	sh.setStationPaused(c, true)
+}
+
+func (sh StationsHandler) ResumeStation(c *gin.Context) {
+	sh.setStationPaused(c, false)
+}
+
+// setStationPaused persists the paused state of a station and applies it to its stream, the consumer groups
+// of a paused station get no messages and new consumers are not allowed to join it until it is resumed
+func (sh StationsHandler) setStationPaused(c *gin.Context, paused bool) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	funcName := "ResumeStation"
+	if paused {
+		funcName = "PauseStation"
+	}
+
+	var body models.PauseStationSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
 		return
 	}
 
-	schemasHandler := SchemasHandler{S: sh.S}
-	extedndedSchemaDetails, err := schemasHandler.getExtendedSchemaDetailsUpdateAvailable(station.Schema.VersionNumber, schema)
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf(funcName + ": Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
 
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
 	if err != nil {
-		serv.Errorf("GetUpdatesForSchemaByStation: At station" + body.StationName + ": " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": err.Error()})
+		serv.Errorf(funcName + ": Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf(funcName + ": " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
 		return
 	}
 
-	shouldSendAnalytics, _ := shouldSendAnalytics()
-	if shouldSendAnalytics {
-		user, _ := getUserDetailsFromMiddleware(c)
-		analytics.SendEvent(user.Username, "user-apply-schema-updates-on-station")
+	if station.IsPaused != paused {
+		user, err := getUserDetailsFromMiddleware(c)
+		if err != nil {
+			serv.Errorf(funcName + ": Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(401, gin.H{"message": "Unauthorized", "code": ErrCodeUnauthorized})
+			return
+		}
+
+		station.IsPaused = paused
+		err = sh.S.applyStationPaused(stationName, station)
+		if err != nil {
+			serv.Errorf(funcName + ": Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+
+		_, err = stationsCollection.UpdateOne(ctx,
+			bson.M{"_id": station.ID},
+			bson.M{"$set": bson.M{"is_paused": paused, "last_update": time.Now()}},
+		)
+		if err != nil {
+			serv.Errorf(funcName + ": Station " + body.StationName + ": " + err.Error())
+			// the stream has to keep matching the stored flag
+			station.IsPaused = !paused
+			revertErr := sh.S.applyStationPaused(stationName, station)
+			if revertErr != nil {
+				serv.Errorf(funcName + ": Station " + body.StationName + ": Failed reverting the stream: " + revertErr.Error())
+			}
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+
+		var message string
+		if paused {
+			message = "Station " + stationName.Ext() + " has been paused by user " + user.Username
+		} else {
+			message = "Station " + stationName.Ext() + " has been resumed by user " + user.Username
+		}
+		serv.Noticef(message)
+		var auditLogs []interface{}
+		newAuditLog := models.AuditLog{
+			ID:            primitive.NewObjectID(),
+			StationName:   stationName.Ext(),
+			Message:       message,
+			CreatedByUser: user.Username,
+			CreationDate:  time.Now(),
+			UserType:      user.UserType,
+		}
+		auditLogs = append(auditLogs, newAuditLog)
+		err = CreateAuditLogs(auditLogs)
+		if err != nil {
+			serv.Warnf(funcName + ": Station " + body.StationName + " - create audit logs error: " + err.Error())
+		}
 	}
 
-	c.IndentedJSON(200, extedndedSchemaDetails)
+	c.IndentedJSON(200, gin.H{"is_paused": paused})
 }
 
-func (sh StationsHandler) TierdStorageClicked(c *gin.Context) {
-	shouldSendAnalytics, _ := shouldSendAnalytics()
-	if shouldSendAnalytics {
-		user, _ := getUserDetailsFromMiddleware(c)
-		analytics.SendEvent(user.Username, "user-pushed-tierd-storage-button")
+func (sh StationsHandler) EnableReadOnly(c *gin.Context) {
+	sh.setStationReadOnly(c, true)
+}
+
+func (sh StationsHandler) DisableReadOnly(c *gin.Context) {
+	sh.setStationReadOnly(c, false)
+}
+
+// setStationReadOnly persists the read only state of a station and applies it to its stream,
+// a read only station rejects new messages and producers while its consumers keep consuming
+func (sh StationsHandler) setStationReadOnly(c *gin.Context, readOnly bool) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	funcName := "DisableReadOnly"
+	if readOnly {
+		funcName = "EnableReadOnly"
 	}
 
-	c.IndentedJSON(200, gin.H{})
+	var body models.ReadOnlyStationSchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	stationName, err := StationNameFromStr(body.StationName)
+	if err != nil {
+		serv.Warnf(funcName + ": Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf(funcName + ": Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+		return
+	}
+	if !exist {
+		errMsg := "Station " + body.StationName + " does not exist"
+		serv.Warnf(funcName + ": " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
+		return
+	}
+
+	if station.ReadOnly != readOnly {
+		user, err := getUserDetailsFromMiddleware(c)
+		if err != nil {
+			serv.Errorf(funcName + ": Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(401, gin.H{"message": "Unauthorized", "code": ErrCodeUnauthorized})
+			return
+		}
+
+		station.ReadOnly = readOnly
+		err = sh.S.applyStationReadOnly(stationName, station)
+		if err != nil {
+			serv.Errorf(funcName + ": Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+
+		_, err = stationsCollection.UpdateOne(ctx,
+			bson.M{"_id": station.ID},
+			bson.M{"$set": bson.M{"read_only": readOnly, "last_update": time.Now()}},
+		)
+		if err != nil {
+			serv.Errorf(funcName + ": Station " + body.StationName + ": " + err.Error())
+			// the stream has to keep matching the stored flag
+			station.ReadOnly = !readOnly
+			revertErr := sh.S.applyStationReadOnly(stationName, station)
+			if revertErr != nil {
+				serv.Errorf(funcName + ": Station " + body.StationName + ": Failed reverting the stream: " + revertErr.Error())
+			}
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
+		}
+
+		var message string
+		if readOnly {
+			message = "Station " + stationName.Ext() + " has been made read only by user " + user.Username
+		} else {
+			message = "Station " + stationName.Ext() + " has been made writable by user " + user.Username
+		}
+		serv.Noticef(message)
+		var auditLogs []interface{}
+		newAuditLog := models.AuditLog{
+			ID:            primitive.NewObjectID(),
+			StationName:   stationName.Ext(),
+			Message:       message,
+			CreatedByUser: user.Username,
+			CreationDate:  time.Now(),
+			UserType:      user.UserType,
+		}
+		auditLogs = append(auditLogs, newAuditLog)
+		err = CreateAuditLogs(auditLogs)
+		if err != nil {
+			serv.Warnf(funcName + ": Station " + body.StationName + " - create audit logs error: " + err.Error())
+		}
+	}
+
+	c.IndentedJSON(200, gin.H{"read_only": readOnly})
 }
 
-func (sh StationsHandler) UpdateDlsConfig(c *gin.Context) {
-	var body models.UpdateDlsConfigSchema
+func (sh StationsHandler) UpdateSchemaEnforcement(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var body models.UpdateSchemaEnforcementSchema
 	ok := utils.Validate(c, &body, false, nil)
 	if !ok {
 		return
@@ -1984,51 +7627,84 @@ func (sh StationsHandler) UpdateDlsConfig(c *gin.Context) {
 
 	stationName, err := StationNameFromStr(body.StationName)
 	if err != nil {
-		serv.Warnf("DlsConfiguration: At station" + body.StationName + ": " + err.Error())
-		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error()})
+		serv.Warnf("UpdateSchemaEnforcement: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
 		return
 	}
 
-	exist, station, err := IsStationExist(stationName)
+	enforcement := strings.ToLower(body.Enforcement)
+	err = validateSchemaEnforcement(enforcement)
 	if err != nil {
-		serv.Errorf("DlsConfiguration: At station" + body.StationName + ": " + err.Error())
-		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		serv.Warnf("UpdateSchemaEnforcement: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error(), "code": errorCode(err)})
+		return
+	}
+
+	exist, station, err := IsStationExistWithContext(ctx, stationName)
+	if err != nil {
+		serv.Errorf("UpdateSchemaEnforcement: Station " + body.StationName + ": " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
 		return
 	}
 	if !exist {
 		errMsg := "Station " + body.StationName + " does not exist"
-		serv.Warnf("DlsConfiguration: " + errMsg)
-		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg})
+		serv.Warnf("UpdateSchemaEnforcement: " + errMsg)
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": errMsg, "code": ErrCodeStationNotFound})
 		return
 	}
 
-	if station.DlsConfiguration.Poison != body.Poison || station.DlsConfiguration.Schemaverse != body.Schemaverse {
-		dlsConfigurationNew := models.DlsConfiguration{
-			Poison:      body.Poison,
-			Schemaverse: body.Schemaverse,
+	if getStationSchemaEnforcement(station) != enforcement {
+		user, err := getUserDetailsFromMiddleware(c)
+		if err != nil {
+			serv.Errorf("UpdateSchemaEnforcement: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(401, gin.H{"message": "Unauthorized", "code": ErrCodeUnauthorized})
+			return
 		}
-		filter := bson.M{
-			"name": body.StationName,
-			"$or": []interface{}{
-				bson.M{"is_deleted": false},
-				bson.M{"is_deleted": bson.M{"$exists": false}},
-			}}
 
-		update := bson.M{
-			"$set": bson.M{
-				"dls_configuration": dlsConfigurationNew,
-			},
+		_, err = stationsCollection.UpdateOne(ctx,
+			bson.M{"_id": station.ID},
+			bson.M{"$set": bson.M{"schema_enforcement": enforcement, "last_update": time.Now()}},
+		)
+		if err != nil {
+			serv.Errorf("UpdateSchemaEnforcement: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
+			return
 		}
-		opts := options.Update().SetUpsert(true)
 
-		_, err := stationsCollection.UpdateOne(context.TODO(), filter, update, opts)
+		message := "Schema enforcement of station " + stationName.Ext() + " has been changed to " + enforcement + " by user " + user.Username
+		serv.Noticef(message)
+		var auditLogs []interface{}
+		newAuditLog := models.AuditLog{
+			ID:            primitive.NewObjectID(),
+			StationName:   stationName.Ext(),
+			Message:       message,
+			CreatedByUser: user.Username,
+			CreationDate:  time.Now(),
+			UserType:      user.UserType,
+		}
+		auditLogs = append(auditLogs, newAuditLog)
+		err = CreateAuditLogs(auditLogs)
 		if err != nil {
-			serv.Errorf("DlsConfiguration: At station" + body.StationName + ": " + err.Error())
-			c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+			serv.Warnf("UpdateSchemaEnforcement: Station " + body.StationName + " - create audit logs error: " + err.Error())
+		}
+
+		updateContent, err := getSchemaUpdateInitFromStation(stationName)
+		if err != nil && err != ErrNoSchema {
+			serv.Errorf("UpdateSchemaEnforcement: Station " + body.StationName + ": " + err.Error())
+			c.AbortWithStatusJSON(500, gin.H{"message": "Server error", "code": ErrCodeServerError})
 			return
 		}
+		if err == nil {
+			updateContent.Enforcement = enforcement
+			update := models.ProducerSchemaUpdate{
+				UpdateType: models.SchemaUpdateTypeInit,
+				Init:       *updateContent,
+			}
+			sh.S.updateStationProducersOfSchemaChange(stationName, update)
+		}
 	}
-	c.IndentedJSON(200, gin.H{"poison": body.Poison, "schemaverse": body.Schemaverse})
+
+	c.IndentedJSON(200, gin.H{"enforcement": enforcement})
 }
 
 func (s *Server) LaunchDlsForOldStations() error {
@@ -2056,10 +7732,7 @@ func (s *Server) LaunchDlsForOldStations() error {
 		_, err = s.memphisStreamInfo(streamName)
 		if err != nil {
 			if IsNatsErr(err, JSStreamNotFoundErr) {
-				dlsConfigurationNew := models.DlsConfiguration{
-					Poison:      true,
-					Schemaverse: true,
-				}
+				dlsConfigurationNew := getStationDefaults().DlsConfiguration
 				filter := bson.M{
 					"name": station.Name,
 					"$or": []interface{}{
@@ -0,0 +1,411 @@
+// Credit for The NATS.IO Authors
+// Copyright 2021-2022 The Memphis Authors
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !skip_js_tests
+// +build !skip_js_tests
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"memphis-broker/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nuid"
+)
+
+// runStationsServer starts a JetStream server for the station tests, it is shut down and its store removed when the test ends
+func runStationsServer(t *testing.T) *Server {
+	t.Helper()
+	s := RunBasicJetStreamServer()
+	s.memphis.nuid = nuid.New() // names the temporary consumers memphis creates
+	config := s.JetStreamConfig()
+	t.Cleanup(func() {
+		s.Shutdown()
+		if config != nil {
+			removeDir(t, config.StoreDir)
+		}
+	})
+	return s
+}
+
+// testStation returns a station kept in memory for an hour, tests set the fields they cover on top of it
+func testStation(name string) models.Station {
+	return models.Station{Name: name, RetentionType: "message_age_sec", RetentionValue: 3600, StorageType: "memory", Replicas: 1}
+}
+
+// addStationStream adds the stream of the station to the server
+func addStationStream(t *testing.T, s *Server, station models.Station) (StationName, *stream) {
+	t.Helper()
+	sn, err := StationNameFromStr(station.Name)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing the station name: %v", err)
+	}
+	config := stationStreamConfig(sn, station)
+	mset, err := s.GlobalAccount().addStream(&config)
+	if err != nil {
+		t.Fatalf("Unexpected error adding the stream of station %v: %v", station.Name, err)
+	}
+	return sn, mset
+}
+
+// addStationCg adds a consumer group pulling the messages of the station
+func addStationCg(t *testing.T, mset *stream, sn StationName, station models.Station, cc ConsumerConfig) {
+	t.Helper()
+	cc.AckPolicy = AckExplicit
+	cc.FilterSubject = stationMsgsSubject(sn, station)
+	if _, err := mset.addConsumer(&cc); err != nil {
+		t.Fatalf("Unexpected error adding consumer group %v: %v", cc.Durable, err)
+	}
+}
+
+// addHeadersRequiredStation creates the stream of a station accepting messages carrying the Memphis headers only
+func addHeadersRequiredStation(t *testing.T, s *Server) (StationName, *stream) {
+	t.Helper()
+	station := testStation("orders")
+	allowNonNative := false
+	station.AllowNonNative = &allowNonNative
+	return addStationStream(t, s, station)
+}
+
+func waitForStreamMsgs(t *testing.T, mset *stream, msgs uint64) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for mset.state().Msgs != msgs {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d messages, got %d", msgs, mset.state().Msgs)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// subscribeToDeliveries subscribes to the reply subject consumer group pulls are requested with
+func subscribeToDeliveries(t *testing.T, s *Server, handler func(reply string)) {
+	t.Helper()
+	sub, err := s.subscribeOnGlobalAcc("cg_reply", "cg_reply_sid", func(_ *client, _, reply string, _ []byte) {
+		handler(reply)
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error subscribing: %v", err)
+	}
+	t.Cleanup(func() { s.unsubscribeOnGlobalAcc(sub) })
+}
+
+func pullStationMsg(s *Server, sn StationName, cg string, request string) {
+	s.sendInternalAccountMsgWithReply(s.GlobalAccount(), fmt.Sprintf(JSApiRequestNextT, sn.Intern(), cg), "cg_reply", nil, []byte(request), true)
+}
+
+func TestMemphisReprocessedMsgHeaders(t *testing.T) {
+	s := runStationsServer(t)
+
+	sn, mset := addHeadersRequiredStation(t, s)
+	dlsMsg := models.DlsMessage{Message: models.MessagePayloadDls{Headers: map[string]string{"trace": "1"}}}
+	headers := reprocessedMsgHeaders(dlsMsg)
+	if headers["trace"] != "1" {
+		t.Fatalf("Expected the original headers to be kept, got %v", headers)
+	}
+	s.sendInternalMsgWithHeaderLocked(s.GlobalAccount(), sn.Intern()+".final", headers, []byte("Hello World!"))
+	waitForStreamMsgs(t, mset, 1)
+
+	dlsMsg.Message.Headers = map[string]string{"$memphis_connectionId": "conn", "$memphis_producedBy": "producer"}
+	headers = reprocessedMsgHeaders(dlsMsg)
+	if headers["$memphis_connectionId"] != "conn" || headers["$memphis_producedBy"] != "producer" {
+		t.Fatalf("Expected the original producer to be kept, got %v", headers)
+	}
+}
+
+func TestMemphisResentMsgHeaders(t *testing.T) {
+	s := runStationsServer(t)
+
+	sn, mset := addHeadersRequiredStation(t, s)
+	headers, err := resentMsgHeaders([]byte(`{"trace":"1","producedBy":"producer"}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if headers["trace"] != "1" || headers["producedBy"] != "" || headers["$memphis_producedBy"] != "$memphis_dls" {
+		t.Fatalf("Expected the message to be marked as produced by the DLS, got %v", headers)
+	}
+	s.sendInternalMsgWithHeaderLocked(s.GlobalAccount(), sn.Intern()+".final", headers, []byte("Hello World!"))
+	waitForStreamMsgs(t, mset, 1)
+}
+
+func TestMemphisRetainStationStream(t *testing.T) {
+	s := runStationsServer(t)
+
+	station := testStation("orders")
+	sn, mset := addStationStream(t, s, station)
+	addStationCg(t, mset, sn, station, ConsumerConfig{Durable: "cg"})
+
+	if err := retainStationStream(s, station); err != nil {
+		t.Fatalf("Unexpected error retaining the stream: %v", err)
+	}
+	if !mset.config().MemphisReadOnly {
+		t.Fatalf("Expected the retained stream to be read only")
+	}
+	if consumers := mset.getPublicConsumers(); len(consumers) != 0 {
+		t.Fatalf("Expected the consumer groups to be removed, got %d", len(consumers))
+	}
+
+	if err := retainStationStream(s, models.Station{Name: "missing"}); err != nil {
+		t.Fatalf("Expected a missing stream to be ignored, got %v", err)
+	}
+}
+
+func TestMemphisGetMsgsFromMirror(t *testing.T) {
+	s := runStationsServer(t)
+
+	sourceSn, _ := addStationStream(t, s, testStation("orders"))
+	mirror := testStation("orders-replica")
+	mirror.Mirror = "orders"
+	mirrorSn, mset := addStationStream(t, s, mirror)
+
+	s.sendInternalAccountMsg(s.GlobalAccount(), sourceSn.Intern()+".final", []byte("Hello World!"))
+	waitForStreamMsgs(t, mset, 1)
+
+	msgs, err := s.memphisGetMsgs(stationMsgsSubject(mirrorSn, mirror), mirrorSn.Intern(), 1, 1, 5*time.Second, false)
+	if err != nil {
+		t.Fatalf("Unexpected error getting messages from the mirror: %v", err)
+	}
+	if len(msgs) != 1 || !strings.HasPrefix(string(msgs[0].Data), "Hello World!") {
+		t.Fatalf("Expected the mirrored message, got %+v", msgs)
+	}
+}
+
+func TestMemphisRestrictedMirrorIngests(t *testing.T) {
+	s := runStationsServer(t)
+
+	sourceSn, _ := addStationStream(t, s, testStation("orders"))
+	// the mirrored message lacks the Memphis headers and the mirror is read only, neither can stop the mirroring
+	mirror := testStation("orders-replica")
+	allowNonNative := false
+	mirror.Mirror = "orders"
+	mirror.ReadOnly = true
+	mirror.AllowNonNative = &allowNonNative
+	_, mset := addStationStream(t, s, mirror)
+
+	s.sendInternalAccountMsg(s.GlobalAccount(), sourceSn.Intern()+".final", []byte("Hello World!"))
+
+	waitForStreamMsgs(t, mset, 1)
+}
+
+func TestMemphisGetMsgsFromExtraSubjects(t *testing.T) {
+	s := runStationsServer(t)
+
+	station := testStation("orders")
+	station.IsNative = true
+	station.Subjects = []string{"legacy.orders"}
+	sn, mset := addStationStream(t, s, station)
+
+	hdrs := map[string]string{"$memphis_connectionId": "conn", "$memphis_producedBy": "producer"}
+	s.sendInternalMsgWithHeaderLocked(s.GlobalAccount(), sn.Intern()+".final", hdrs, []byte("produced"))
+	s.sendInternalAccountMsg(s.GlobalAccount(), "legacy.orders", []byte("legacy"))
+	waitForStreamMsgs(t, mset, 2)
+
+	msgs, err := s.memphisGetMsgs(stationMsgsSubject(sn, station), sn.Intern(), 1, 2, 5*time.Second, true)
+	if err != nil {
+		t.Fatalf("Unexpected error getting messages: %v", err)
+	}
+	if len(msgs) != 2 {
+		t.Fatalf("Expected the messages of both subjects, got %d", len(msgs))
+	}
+	details, err := storedMsgsToMessageDetails(msgs, station.IsNative)
+	if err != nil {
+		t.Fatalf("Unexpected error listing the messages: %v", err)
+	}
+	if len(details) != 2 || details[0].ProducedBy != "producer" || details[1].ProducedBy != "" {
+		t.Fatalf("Expected the legacy message to be listed without a producer, got %+v", details)
+	}
+}
+
+func TestMemphisGetMessagesByTimeRangeSkipsGaps(t *testing.T) {
+	s := runStationsServer(t)
+
+	station := testStation("orders")
+	station.IsNative = true
+	sn, mset := addStationStream(t, s, station)
+	hdrs := map[string]string{"$memphis_connectionId": "conn", "$memphis_producedBy": "producer"}
+	for i := 0; i < 5; i++ {
+		s.sendInternalMsgWithHeaderLocked(s.GlobalAccount(), sn.Intern()+".final", hdrs, []byte("msg"))
+	}
+	waitForStreamMsgs(t, mset, 5)
+	if _, err := mset.deleteMsg(2); err != nil {
+		t.Fatalf("Unexpected error deleting a message: %v", err)
+	}
+
+	// the page starts after the gap, messages 3 to 5 are left
+	msgs, nextSeq, err := s.GetMessagesByTimeRange(station, time.Now().Add(-time.Hour), time.Now().Add(time.Hour), 3, 10)
+	if err != nil {
+		t.Fatalf("Unexpected error getting messages: %v", err)
+	}
+	if len(msgs) != 3 || nextSeq != 0 {
+		t.Fatalf("Expected the 3 messages left in the range, got %d and next sequence %d", len(msgs), nextSeq)
+	}
+}
+
+func TestMemphisPausedStationDelivery(t *testing.T) {
+	s := runStationsServer(t)
+
+	station := testStation("orders")
+	station.IsPaused = true
+	sn, mset := addStationStream(t, s, station)
+	addStationCg(t, mset, sn, station, ConsumerConfig{Durable: "cg"})
+
+	delivered := make(chan struct{}, 1)
+	subscribeToDeliveries(t, s, func(string) {
+		delivered <- struct{}{}
+	})
+
+	s.sendInternalAccountMsg(s.GlobalAccount(), sn.Intern()+".final", []byte("Hello World!"))
+	waitForStreamMsgs(t, mset, 1)
+	pullStationMsg(s, sn, "cg", "1")
+
+	select {
+	case <-delivered:
+		t.Fatalf("Expected no message to be delivered from a paused station")
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	station.IsPaused = false
+	config := stationStreamConfig(sn, station)
+	if err := mset.update(&config); err != nil {
+		t.Fatalf("Unexpected error resuming the station: %v", err)
+	}
+	select {
+	case <-delivered:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the message to be delivered once the station is resumed")
+	}
+}
+
+func TestMemphisResetCgPosition(t *testing.T) {
+	s := runStationsServer(t)
+
+	station := testStation("orders")
+	sn, mset := addStationStream(t, s, station)
+	addStationCg(t, mset, sn, station, ConsumerConfig{Durable: "cg"})
+
+	// a start sequence policy without a start sequence is refused by JetStream before the consumer group is touched
+	if err := s.ResetCgPosition(sn, "cg", DeliverByStartSequence, 0, nil); !errors.Is(err, ErrCgPositionRefused) {
+		t.Fatalf("Expected resetting to an invalid position to be refused, got %v", err)
+	}
+	o := mset.lookupConsumer("cg")
+	if o == nil {
+		t.Fatalf("Expected the original consumer to be kept")
+	}
+	if policy := o.config().DeliverPolicy; policy != DeliverAll {
+		t.Fatalf("Expected the original deliver policy, got %v", policy)
+	}
+	if n := mset.numConsumers(); n != 1 {
+		t.Fatalf("Expected the validation consumer to be removed, got %v consumers", n)
+	}
+
+	if err := s.ResetCgPosition(sn, "cg", DeliverNew, 0, nil); err != nil {
+		t.Fatalf("Unexpected error resetting the consumer group: %v", err)
+	}
+	if o = mset.lookupConsumer("cg"); o == nil || o.config().DeliverPolicy != DeliverNew {
+		t.Fatalf("Expected the consumer group to start from new messages")
+	}
+}
+
+func TestMemphisMsgDeliveryCount(t *testing.T) {
+	s := runStationsServer(t)
+
+	station := testStation("orders")
+	sn, mset := addStationStream(t, s, station)
+	addStationCg(t, mset, sn, station, ConsumerConfig{Durable: "cg"})
+
+	delivered := make(chan struct{}, 1)
+	subscribeToDeliveries(t, s, func(string) {
+		delivered <- struct{}{}
+	})
+
+	s.sendInternalAccountMsg(s.GlobalAccount(), sn.Intern()+".final", []byte("Hello World!"))
+	waitForStreamMsgs(t, mset, 1)
+	if _, ok := s.memphisMsgDeliveryCount(sn, "cg", 1); ok {
+		t.Fatalf("Expected no delivery count before the message is delivered")
+	}
+
+	pullStationMsg(s, sn, "cg", "1")
+	select {
+	case <-delivered:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the message to be delivered")
+	}
+	if dc, ok := s.memphisMsgDeliveryCount(sn, "cg", 1); !ok || dc != 1 {
+		t.Fatalf("Expected a single delivery, got %v %v", dc, ok)
+	}
+}
+
+func TestMemphisDeliveryExceededReason(t *testing.T) {
+	s := runStationsServer(t)
+
+	station := testStation("orders")
+	sn, mset := addStationStream(t, s, station)
+
+	reasons := make(chan string, 2)
+	advisories, err := s.subscribeOnGlobalAcc(JSAdvisoryConsumerMaxDeliveryExceedPre+".>", "max_deliveries_sid", func(_ *client, _, _ string, msg []byte) {
+		var advisory JSConsumerDeliveryExceededAdvisory
+		if err := json.Unmarshal(msg, &advisory); err == nil {
+			reasons <- advisory.MemphisReason
+		}
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error subscribing: %v", err)
+	}
+	defer s.unsubscribeOnGlobalAcc(advisories)
+
+	acks := make(chan string, 2)
+	subscribeToDeliveries(t, s, func(reply string) {
+		acks <- reply
+	})
+
+	s.sendInternalAccountMsg(s.GlobalAccount(), sn.Intern()+".final", []byte("Hello World!"))
+	waitForStreamMsgs(t, mset, 1)
+
+	for _, tc := range []struct {
+		cg     string
+		nak    bool
+		reason string
+	}{
+		{"timeout_cg", false, DlsReasonAckTimeout},
+		{"nak_cg", true, DlsReasonMaxDeliveries},
+	} {
+		addStationCg(t, mset, sn, station, ConsumerConfig{Durable: tc.cg, AckWait: 100 * time.Millisecond, MaxDeliver: 1})
+		pullStationMsg(s, sn, tc.cg, "1")
+		select {
+		case reply := <-acks:
+			if tc.nak {
+				s.sendInternalAccountMsg(s.GlobalAccount(), reply, AckNak)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected the message to be delivered to %v", tc.cg)
+		}
+
+		// the next pull finds the message out of deliveries once it is due again
+		time.Sleep(200 * time.Millisecond)
+		pullStationMsg(s, sn, tc.cg, `{"batch":1,"expires":200000000}`)
+		select {
+		case reason := <-reasons:
+			if reason != tc.reason {
+				t.Fatalf("Expected the reason %v for %v, got %v", tc.reason, tc.cg, reason)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected a max deliveries advisory for %v", tc.cg)
+		}
+	}
+}
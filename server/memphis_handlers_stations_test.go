@@ -0,0 +1,573 @@
+// Credit for The NATS.IO Authors
+// Copyright 2021-2022 The Memphis Authors
+// Licensed under the Apache License, Version 2.0 (the “License”);
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an “AS IS” BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"memphis-broker/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mustStationName returns the station name of a valid name or fails the test
+func mustStationName(t *testing.T, name string) StationName {
+	t.Helper()
+	sn, err := StationNameFromStr(name)
+	if err != nil {
+		t.Fatalf("%v: unexpected error %v", name, err)
+	}
+	return sn
+}
+
+func TestGetRetentionDescriptor(t *testing.T) {
+	cases := []struct {
+		retentionType  string
+		retentionValue int
+		expected       string
+	}{
+		{"message_age_sec", 604800, "7 days"},
+		{"message_age_sec", 3600, "1 hour"},
+		{"message_age_sec", 90, "90 seconds"},
+		{"bytes", 10 * 1024 * 1024 * 1024, "10 GB"},
+		{"bytes", 1536, "1.5 KB"},
+		{"bytes", 512, "512 bytes"},
+		{"messages", 1000000, "1,000,000 messages"},
+		{"messages", 1, "1 message"},
+		{"none", 0, "unlimited, messages are never deleted"},
+	}
+
+	for _, c := range cases {
+		descriptor := getRetentionDescriptor(c.retentionType, c.retentionValue)
+		if descriptor != c.expected {
+			t.Fatalf("%v %v: expected %q, got %q", c.retentionType, c.retentionValue, c.expected, descriptor)
+		}
+	}
+}
+
+func TestParseDlsMsgId(t *testing.T) {
+	validId := GetDlsMsgId("station", 42, "producer", time.Unix(0, 0).UTC())
+	sn, seq, err := parseDlsMsgId(validId)
+	if err != nil {
+		t.Fatalf("%v: unexpected error %v", validId, err)
+	}
+	if sn.Ext() != "station" || seq != 42 {
+		t.Fatalf("%v: expected station/42, got %v/%v", validId, sn.Ext(), seq)
+	}
+
+	for _, invalidId := range []string{"", "station", "station~producer", "station~producer~abc~time", "station~producer~1~time~extra"} {
+		if _, _, err := parseDlsMsgId(invalidId); err == nil {
+			t.Fatalf("%q: expected an error", invalidId)
+		}
+	}
+}
+
+func TestDiffStreamConfig(t *testing.T) {
+	sn := mustStationName(t, "station")
+	station := models.Station{Name: "station", RetentionType: "messages", RetentionValue: 10, StorageType: "file", Replicas: 1}
+	stored := stationStreamConfig(sn, station)
+
+	if drifts := diffStreamConfig(stored, stored); len(drifts) != 0 {
+		t.Fatalf("expected no drift, got %v", drifts)
+	}
+
+	actual := stored
+	actual.MaxMsgs = 20
+	actual.Storage = MemoryStorage
+	drifts := diffStreamConfig(stored, actual)
+	if len(drifts) != 2 || drifts[0].Field != "max_msgs" || drifts[1].Field != "storage_type" {
+		t.Fatalf("expected max_msgs and storage_type drifts, got %v", drifts)
+	}
+}
+
+func TestRetentionLimitStats(t *testing.T) {
+	stats := retentionLimitStats(50, 100, 10)
+	if stats.Trimming || stats.UsagePercent != 50 || stats.TrimmingStart == nil {
+		t.Fatalf("expected half usage with a trimming estimate, got %+v", stats)
+	}
+	if until := time.Until(*stats.TrimmingStart); until < 4*time.Second || until > 5*time.Second {
+		t.Fatalf("expected trimming to start in about 5 seconds, got %v", until)
+	}
+
+	stats = retentionLimitStats(100, 100, 10)
+	if !stats.Trimming || stats.TrimmingStart != nil {
+		t.Fatalf("expected trimming without an estimate, got %+v", stats)
+	}
+
+	stats = retentionLimitStats(100, -1, 10)
+	if stats.Trimming || stats.TrimmingStart != nil || stats.UsagePercent != 0 {
+		t.Fatalf("expected no stats for an unlimited value, got %+v", stats)
+	}
+}
+
+func TestValidateStationSubjects(t *testing.T) {
+	sn := mustStationName(t, "orders")
+
+	subjects, err := validateStationSubjects(sn, []string{"legacy.orders.>", "billing.*"})
+	if err != nil || len(subjects) != 2 {
+		t.Fatalf("expected the subjects to be valid, got %v %v", subjects, err)
+	}
+
+	for _, invalid := range [][]string{{">"}, {"*.orders"}, {"$memphis_dls.x"}, {"$JS.API.>"}, {"orders.>"}, {"a.>", "a.b"}, {"a..b"}} {
+		if _, err := validateStationSubjects(sn, invalid); err == nil {
+			t.Fatalf("%v: expected an error", invalid)
+		}
+	}
+
+	if subject := stationMsgsSubject(sn, models.Station{Subjects: subjects}); subject != "" {
+		t.Fatalf("expected a station with extra subjects to be read as a whole, got %s", subject)
+	}
+}
+
+func TestStationNameFromStrUnicode(t *testing.T) {
+	// the Kelvin sign lowercases to an ASCII 'k' and the Turkish 'İ' to an 'i' with a combining dot
+	for _, name := range []string{"Kafka", "İstanbul", "café", "orders-\U0001F680"} {
+		_, err := StationNameFromStr(name)
+		if err == nil || !strings.Contains(err.Error(), "non ASCII character") {
+			t.Fatalf("%v: expected a non ASCII character error, got %v", name, err)
+		}
+	}
+
+	sn, err := StationNameFromStr("Orders.EU")
+	if err != nil || sn.Ext() != "orders.eu" {
+		t.Fatalf("expected an ASCII name to be lowercased, got %v %v", sn.Ext(), err)
+	}
+}
+
+func TestKeyedRetention(t *testing.T) {
+	if err := validateCompactionKey("keyed", "", nil); err == nil {
+		t.Fatalf("expected a keyed retention without a compaction key to be rejected")
+	}
+	if err := validateCompactionKey("messages", "user_id", nil); err == nil {
+		t.Fatalf("expected a compaction key without the keyed retention to be rejected")
+	}
+	if err := validateCompactionKey("keyed", "user_id", []string{"legacy.>"}); err == nil {
+		t.Fatalf("expected the keyed retention with extra subjects to be rejected")
+	}
+
+	sn := mustStationName(t, "users")
+	station := models.Station{Name: "users", RetentionType: "keyed", CompactionKey: "user_id"}
+	if err := validateCompactionKey(station.RetentionType, station.CompactionKey, nil); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	cfg := stationStreamConfig(sn, station)
+	if cfg.MaxMsgsPer != 1 || cfg.MaxMsgs != -1 || cfg.MaxAge != 0 {
+		t.Fatalf("expected a last value per subject stream, got %+v", cfg)
+	}
+	if subject := stationMsgsSubject(sn, station); subject != "users.final.>" {
+		t.Fatalf("unexpected messages subject %v", subject)
+	}
+}
+
+func TestNormalizePartitionKeyHeader(t *testing.T) {
+	if header, err := normalizePartitionKeyHeader(""); err != nil || header != "" {
+		t.Fatalf("expected an empty header to disable partitioning, got %v, %v", header, err)
+	}
+	if header, err := normalizePartitionKeyHeader(" tenant_id "); err != nil || header != "tenant_id" {
+		t.Fatalf("expected the header to be trimmed, got %v, %v", header, err)
+	}
+	for _, header := range []string{"  ", "$memphis_producedBy", "tenant id", "tenant:id", "tenant\x7f"} {
+		if _, err := normalizePartitionKeyHeader(header); err == nil {
+			t.Fatalf("expected partition key header %q to be rejected", header)
+		}
+	}
+}
+
+func TestSchemaNotFoundError(t *testing.T) {
+	err := schemaNotFoundError("orders")
+	if err == nil || err.Error() != "Schema orders does not exist" {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if jsErr := NewJSStreamCreateError(err); jsErr.Description == "" {
+		t.Fatalf("expected the JS API error to carry the schema message")
+	}
+	if code := errorCode(err); code != ErrCodeSchemaMissing {
+		t.Fatalf("expected %v, got %v", ErrCodeSchemaMissing, code)
+	}
+}
+
+func TestFilterDlsMessages(t *testing.T) {
+	now := time.Now()
+	msgs := []models.DlsMessageResponse{
+		{ID: "1", Reason: DlsReasonMaxDeliveries, CreationDate: now.Add(-2 * time.Hour), PoisonedCgs: []models.PoisonedCg{{CgName: "cg1"}}},
+		{ID: "2", Reason: DlsReasonMaxDeliveries, CreationDate: now, PoisonedCgs: []models.PoisonedCg{{CgName: "cg2"}}},
+		{ID: "3", Reason: DlsReasonSchemaValidationErr, CreationDate: now},
+	}
+
+	if got := filterDlsMessages(msgs, "", "", time.Time{}, time.Time{}); len(got) != 3 {
+		t.Fatalf("expected all messages without filters, got %v", len(got))
+	}
+	if got := filterDlsMessages(msgs, "cg1", "", time.Time{}, time.Time{}); len(got) != 1 || got[0].ID != "1" {
+		t.Fatalf("unexpected cg filter result %+v", got)
+	}
+	if got := filterDlsMessages(msgs, "", DlsReasonSchemaValidationErr, time.Time{}, time.Time{}); len(got) != 1 || got[0].ID != "3" {
+		t.Fatalf("unexpected reason filter result %+v", got)
+	}
+	if got := filterDlsMessages(msgs, "", "", now.Add(-time.Hour), time.Time{}); len(got) != 2 {
+		t.Fatalf("expected 2 messages in the time range, got %v", len(got))
+	}
+}
+
+func TestCachedCentralDlsRoute(t *testing.T) {
+	sn := mustStationName(t, "orders")
+	if _, ok := getCachedCentralDlsRoute(sn); ok {
+		t.Fatalf("expected no cached route before the station was looked up")
+	}
+
+	centralDlsRoutesMu.Lock()
+	centralDlsRoutes[sn.Intern()] = centralDlsRoute{station: models.Station{Name: "orders", CentralDlsStation: "dlq"}, fetchedAt: time.Now()}
+	centralDlsRoutesMu.Unlock()
+	defer func() {
+		centralDlsRoutesMu.Lock()
+		delete(centralDlsRoutes, sn.Intern())
+		centralDlsRoutesMu.Unlock()
+	}()
+	if station, ok := getCachedCentralDlsRoute(sn); !ok || station.CentralDlsStation != "dlq" {
+		t.Fatalf("unexpected cached route %+v, %v", station, ok)
+	}
+
+	centralDlsRoutesMu.Lock()
+	centralDlsRoutes[sn.Intern()] = centralDlsRoute{station: models.Station{Name: "orders"}, fetchedAt: time.Now().Add(-2 * centralDlsRoutesTTL)}
+	centralDlsRoutesMu.Unlock()
+	if _, ok := getCachedCentralDlsRoute(sn); ok {
+		t.Fatalf("expected an expired route to be looked up again")
+	}
+}
+
+func TestIdempotencyWindowVsRetention(t *testing.T) {
+	if err := validateIdempotencyWindow("message_age_sec", 60, 60000); err != nil {
+		t.Fatalf("a window equal to the retention should be accepted: %v", err)
+	}
+	err := validateIdempotencyWindow("message_age_sec", 60, 120000)
+	if err == nil || errorCode(err) != ErrCodeIdempotencyWindowInvalid {
+		t.Fatalf("expected %v, got %v", ErrCodeIdempotencyWindowInvalid, err)
+	}
+	if err := validateIdempotencyWindow("messages", 10, 120000); err != nil {
+		t.Fatalf("only age based retention limits the window: %v", err)
+	}
+
+	if window := defaultIdempotencyWindow("message_age_sec", 30, 120000); window != 30000 {
+		t.Fatalf("expected the default window to be capped to 30000, got %v", window)
+	}
+	if window := defaultIdempotencyWindow("message_age_sec", 3600, 120000); window != 120000 {
+		t.Fatalf("expected the default window to be kept, got %v", window)
+	}
+}
+
+func TestGetSchemaSkew(t *testing.T) {
+	statuses := []models.ProducerSchemaStatus{
+		{Name: "p1", SchemaVersion: "3"},
+		{Name: "p2", SchemaVersion: "2"},
+		{Name: "p3", SchemaVersion: unknownSchemaVersion},
+		{Name: "p4", SchemaVersion: "3"},
+		{Name: "p5", SchemaVersion: "4"},
+	}
+	skew := getSchemaSkew(statuses, 3)
+	if skew.ActiveProducers != 5 || skew.CurrentVersion != 2 || skew.OlderVersions != 1 || skew.NewerVersions != 1 || skew.UnknownVersion != 1 {
+		t.Fatalf("unexpected skew %+v", skew)
+	}
+	if len(skew.LaggingProducers) != 1 || skew.LaggingProducers[0] != "p2" {
+		t.Fatalf("expected p2 to be lagging, got %v", skew.LaggingProducers)
+	}
+	if len(skew.AheadProducers) != 1 || skew.AheadProducers[0] != "p5" {
+		t.Fatalf("expected p5 to be ahead, got %v", skew.AheadProducers)
+	}
+	if skew.Versions["3"] != 2 {
+		t.Fatalf("expected 2 producers on version 3, got %v", skew.Versions["3"])
+	}
+}
+
+func TestNormalizeStorageTypeFilter(t *testing.T) {
+	for in, want := range map[string]string{"file": "file", "disk": "file", "Disk": "file", "memory": "memory"} {
+		got, err := normalizeStorageTypeFilter(in)
+		if err != nil || got != want {
+			t.Fatalf("normalizeStorageTypeFilter(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	if _, err := normalizeStorageTypeFilter("ssd"); errorCode(err) != ErrCodeStorageTypeInvalid {
+		t.Fatalf("expected an invalid storage type error, got %v", err)
+	}
+}
+
+func TestSortCgPoisonCounts(t *testing.T) {
+	got := sortCgPoisonCounts(map[string]int{"cg-b": 2, "cg-a": 2, "cg-c": 7, "cg-d": 0})
+	want := []string{"cg-c", "cg-a", "cg-b", "cg-d"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v consumer groups, got %v", len(want), len(got))
+	}
+	for i, cgName := range want {
+		if got[i].CgName != cgName {
+			t.Fatalf("expected %v at position %v, got %v", cgName, i, got[i].CgName)
+		}
+	}
+}
+
+func TestGetIdempotencyWindowState(t *testing.T) {
+	configured := StreamConfig{Duplicates: 2 * time.Minute}
+	if state := getIdempotencyWindowState(configured, StreamConfig{Duplicates: 2 * time.Minute}); state.Diverged || state.ConfiguredMs != 120000 {
+		t.Fatalf("expected matching windows, got %+v", state)
+	}
+	if state := getIdempotencyWindowState(configured, StreamConfig{Duplicates: time.Minute}); !state.Diverged || state.ActualMs != 60000 {
+		t.Fatalf("expected diverged windows, got %+v", state)
+	}
+}
+
+func TestStationMirror(t *testing.T) {
+	sn := mustStationName(t, "orders-replica")
+	if mirror, err := normalizeStationMirror(sn, models.Station{}, false); err != nil || mirror != "" {
+		t.Fatalf("expected no mirror, got %v %v", mirror, err)
+	}
+	notAllowed := false
+	invalid := map[string]models.Station{
+		"mirroring itself":          {Mirror: "orders-replica"},
+		"with subjects":             {Mirror: "orders", Subjects: []string{"legacy.>"}},
+		"rejecting non native msgs": {Mirror: "orders", AllowNonNative: &notAllowed},
+		"requiring a schema":        {Mirror: "orders", SchemaRequired: true},
+		"with a keyed retention":    {Mirror: "orders", RetentionType: "keyed"},
+	}
+	for name, station := range invalid {
+		if _, err := normalizeStationMirror(sn, station, false); err == nil {
+			t.Fatalf("expected a mirror %s to be rejected", name)
+		}
+	}
+	if _, err := normalizeStationMirror(sn, models.Station{Mirror: "orders"}, true); err == nil {
+		t.Fatalf("expected a mirror adopting an existing stream to be rejected")
+	}
+
+	mirror := models.Station{Name: "orders-replica", Mirror: "orders"}
+	cfg := stationStreamConfig(sn, mirror)
+	if len(cfg.Subjects) != 0 || cfg.Mirror == nil || cfg.Mirror.Name != "orders" {
+		t.Fatalf("expected a stream mirroring orders, got %+v", cfg)
+	}
+	if subject := stationMsgsSubject(sn, mirror); subject != "orders.final" {
+		t.Fatalf("expected the mirror to read the source's subject, got %s", subject)
+	}
+}
+
+func TestSchemaChangeFromUpdate(t *testing.T) {
+	if timeout := schemaWatchTimeout(0); timeout != defaultSchemaWatchTimeout {
+		t.Fatalf("expected the default timeout, got %v", timeout)
+	}
+	if timeout := schemaWatchTimeout(3600); timeout != maxSchemaWatchTimeout {
+		t.Fatalf("expected the timeout to be capped, got %v", timeout)
+	}
+
+	sn := mustStationName(t, "orders")
+	now := time.Now()
+	change := schemaChangeFromUpdate(sn, models.ProducerSchemaUpdate{
+		UpdateType: models.SchemaUpdateTypeInit,
+		Init: models.ProducerSchemaUpdateInit{
+			SchemaName:    "order",
+			ActiveVersion: models.ProducerSchemaUpdateVersion{VersionNumber: 3},
+			Enforcement:   "reject",
+		},
+	}, now)
+	if change.UpdateType != "init" || change.SchemaName != "order" || change.VersionNumber != 3 || change.StationName != "orders" {
+		t.Fatalf("unexpected change %+v", change)
+	}
+	change = schemaChangeFromUpdate(sn, models.ProducerSchemaUpdate{UpdateType: models.SchemaUpdateTypeDrop}, now)
+	if change.UpdateType != "drop" || change.SchemaName != "" {
+		t.Fatalf("unexpected change %+v", change)
+	}
+}
+
+func TestValidateRetentionValue(t *testing.T) {
+	for _, retentionType := range []string{"messages", "bytes", "message_age_sec"} {
+		if code := errorCode(validateRetentionValue(retentionType, 0)); code != ErrCodeRetentionInvalid {
+			t.Fatalf("expected a zero %v retention to be rejected, got %v", retentionType, code)
+		}
+		if err := validateRetentionValue(retentionType, -5); err == nil {
+			t.Fatalf("expected a negative %v retention to be rejected", retentionType)
+		}
+		if err := validateRetentionValue(retentionType, 10); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+	if err := validateRetentionValue(unlimitedRetentionType, 0); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := validateRetentionValue(keyedRetentionType, 0); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestReprocessedMsgSubject(t *testing.T) {
+	sn := mustStationName(t, "users")
+	if subject, err := reprocessedMsgSubject(sn, models.Station{}, nil); err != nil || subject != "users.final" {
+		t.Fatalf("expected the final subject, got %s %v", subject, err)
+	}
+	keyed := models.Station{RetentionType: "keyed", CompactionKey: "user-id"}
+	if subject, err := reprocessedMsgSubject(sn, keyed, map[string]string{"user-id": "42"}); err != nil || subject != "users.final.42" {
+		t.Fatalf("expected the keyed subject, got %s %v", subject, err)
+	}
+	for _, headers := range []map[string]string{{}, {"user-id": "a.b"}} {
+		if _, err := reprocessedMsgSubject(sn, keyed, headers); err == nil {
+			t.Fatalf("expected a message without a valid key to be rejected, headers %v", headers)
+		}
+	}
+}
+
+func TestRedactDlsConfiguration(t *testing.T) {
+	stored := models.DlsConfiguration{Poison: true, Webhook: &models.DlsWebhook{
+		Url:       "https://hooks.example.com",
+		Headers:   map[string]string{"Authorization": "Bearer secret"},
+		TlsCaCert: "cert",
+	}}
+	redacted := redactDlsConfiguration(stored)
+	if redacted.Webhook.Headers["Authorization"] != dlsWebhookRedactedValue || redacted.Webhook.TlsCaCert != dlsWebhookRedactedValue {
+		t.Fatalf("expected the webhook secrets to be redacted, got %+v", redacted.Webhook)
+	}
+	if stored.Webhook.Headers["Authorization"] != "Bearer secret" || stored.Webhook.TlsCaCert != "cert" {
+		t.Fatalf("expected the stored webhook to be untouched, got %+v", stored.Webhook)
+	}
+
+	restoreRedactedDlsWebhook(redacted.Webhook, stored.Webhook)
+	if redacted.Webhook.Headers["Authorization"] != "Bearer secret" || redacted.Webhook.TlsCaCert != "cert" {
+		t.Fatalf("expected the redacted values to be restored, got %+v", redacted.Webhook)
+	}
+	added := &models.DlsWebhook{Url: "https://hooks.example.com", Headers: map[string]string{"X-Token": dlsWebhookRedactedValue}}
+	restoreRedactedDlsWebhook(added, stored.Webhook)
+	if err := validateDlsWebhook(added); err == nil {
+		t.Fatalf("expected a redacted value without a stored one to be rejected")
+	}
+}
+
+func TestStationDefinitionDlsWebhook(t *testing.T) {
+	station := models.Station{Name: "orders", DlsConfiguration: models.DlsConfiguration{Poison: true, Webhook: &models.DlsWebhook{
+		Url:       "https://hooks.example.com",
+		Headers:   map[string]string{"Authorization": "Bearer secret"},
+		TlsCaCert: "cert",
+	}}}
+	definition := stationDefinition(station, nil)
+	webhook := definition.DlsConfiguration.Webhook
+	if webhook.Url != "https://hooks.example.com" || len(webhook.Headers) != 0 || webhook.TlsCaCert != "" {
+		t.Fatalf("expected the webhook secrets to be left out of the export, got %+v", webhook)
+	}
+	if err := validateDlsWebhook(webhook); err != nil {
+		t.Fatalf("expected the exported webhook to import, got %v", err)
+	}
+	if station.DlsConfiguration.Webhook.Headers["Authorization"] != "Bearer secret" {
+		t.Fatalf("expected the stored webhook to be untouched")
+	}
+}
+
+func TestDlsWebhookClient(t *testing.T) {
+	first, err := dlsWebhookClient(models.DlsWebhook{Url: "https://a.example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	second, err := dlsWebhookClient(models.DlsWebhook{Url: "https://b.example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if first != second {
+		t.Fatalf("expected webhooks with the same TLS setup to share a client")
+	}
+	insecure, err := dlsWebhookClient(models.DlsWebhook{Url: "https://a.example.com", TlsSkipVerify: true})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if insecure == first {
+		t.Fatalf("expected webhooks with a different TLS setup to use their own client")
+	}
+}
+
+func TestStationSchemaChangeSince(t *testing.T) {
+	sn := mustStationName(t, "orders")
+	lastUpdate := time.Now()
+	station := models.Station{Schema: models.SchemaDetails{SchemaName: "order", VersionNumber: 2}, LastUpdate: lastUpdate}
+	if _, changed := stationSchemaChangeSince(sn, station, "order", 2, lastUpdate); changed {
+		t.Fatalf("expected no change for the state the client saw")
+	}
+	change, changed := stationSchemaChangeSince(sn, station, "order", 1, lastUpdate)
+	if !changed || change.UpdateType != "init" || change.VersionNumber != 2 {
+		t.Fatalf("expected the upgrade to be reported, got %v %+v", changed, change)
+	}
+	station.LastUpdate = lastUpdate.Add(time.Second)
+	if _, changed = stationSchemaChangeSince(sn, station, "order", 2, lastUpdate); !changed {
+		t.Fatalf("expected a later update of the station to be reported")
+	}
+	change, changed = stationSchemaChangeSince(sn, models.Station{LastUpdate: lastUpdate}, "order", 2, lastUpdate)
+	if !changed || change.UpdateType != "drop" {
+		t.Fatalf("expected the detach to be reported, got %v %+v", changed, change)
+	}
+}
+
+func TestApplyAdoptedStreamConfig(t *testing.T) {
+	station := models.Station{RetentionType: "messages", RetentionValue: 10, IdempotencyWindow: 120000, MaxMsgSizeBytes: 1024}
+	applyAdoptedStreamConfig(&station, StreamConfig{MaxAge: time.Hour, MaxMsgs: -1, MaxBytes: -1, Storage: MemoryStorage, Replicas: 3, Duplicates: 5 * time.Second, MaxMsgSize: 2048})
+	if station.RetentionType != "message_age_sec" || station.RetentionValue != 3600 {
+		t.Fatalf("expected the stream's age retention, got %v %v", station.RetentionType, station.RetentionValue)
+	}
+	if station.StorageType != "memory" || station.Replicas != 3 || station.IdempotencyWindow != 5000 || station.MaxMsgSizeBytes != 2048 {
+		t.Fatalf("expected the stream's settings, got %+v", station)
+	}
+
+	applyAdoptedStreamConfig(&station, StreamConfig{MaxMsgs: -1, MaxBytes: -1, MaxMsgSize: -1, Storage: FileStorage, Replicas: 1, Duplicates: 2 * time.Minute})
+	if station.RetentionType != unlimitedRetentionType || station.StorageType != "file" || station.MaxMsgSizeBytes != 2048 {
+		t.Fatalf("expected an unlimited retention keeping the max message size, got %+v", station)
+	}
+}
+
+func TestMessageSchemaVersion(t *testing.T) {
+	if version := messageSchemaVersion(map[string]string{schemaVersionHeader: "3"}); version != 3 {
+		t.Fatalf("expected the stamped version, got %v", version)
+	}
+	for _, headers := range []map[string]string{{}, {schemaVersionHeader: "latest"}, {schemaVersionHeader: "-1"}} {
+		if version := messageSchemaVersion(headers); version != 0 {
+			t.Fatalf("expected an unknown version for %v, got %v", headers, version)
+		}
+	}
+}
+
+func TestGetReplicaHealth(t *testing.T) {
+	cluster := &ClusterInfo{Leader: "broker-0", Replicas: []*PeerInfo{
+		{Name: "broker-1", Current: true},
+		{Name: "broker-2", Current: false},
+	}}
+	health := getReplicaHealth(models.Station{Replicas: 3}, "broker-0", cluster)
+	if health.InSync != 2 || !health.UnderReplicated {
+		t.Fatalf("expected a lagging follower to leave the station under replicated, got %+v", health)
+	}
+	cluster.Replicas[1].Current = true
+	cluster.Replicas[0].Offline = true
+	if health = getReplicaHealth(models.Station{Replicas: 3}, "broker-0", cluster); health.InSync != 2 || !health.UnderReplicated {
+		t.Fatalf("expected an offline follower to leave the station under replicated, got %+v", health)
+	}
+	if health = getReplicaHealth(models.Station{Replicas: 1}, "broker-0", nil); health.InSync != 1 || health.UnderReplicated {
+		t.Fatalf("expected a single replica station to be healthy, got %+v", health)
+	}
+}
+
+func TestStationAllowsNonNative(t *testing.T) {
+	allow := false
+	if stationAllowsNonNative(models.Station{AllowNonNative: &allow}) || !stationAllowsNonNative(models.Station{}) {
+		t.Fatalf("expected stations to allow non native producers unless the flag is off")
+	}
+}
+
+func TestParseStationsFields(t *testing.T) {
+	fields, err := parseStationsFields([]string{"mirror, retention_pin", "allow_non_native,pending_schema"})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	for _, f := range []string{"id", "name", "mirror", "retention_pin", "allow_non_native", "pending_schema"} {
+		if !fields[f] {
+			t.Fatalf("expected field %v to be selected, got %v", f, fields)
+		}
+	}
+	if _, err := parseStationsFields([]string{"no_such_field"}); err == nil {
+		t.Fatalf("expected an unknown field to be rejected")
+	}
+}
@@ -36,6 +36,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -1082,6 +1083,44 @@ func (umh UserMgmtHandler) EditAnalytics(c *gin.Context) {
 	c.IndentedJSON(200, gin.H{})
 }
 
+func (umh UserMgmtHandler) EditAnalyticsCategory(c *gin.Context) {
+	if err := DenyForSandboxEnv(c); err != nil {
+		return
+	}
+	var body models.EditAnalyticsCategorySchema
+	ok := utils.Validate(c, &body, false, nil)
+	if !ok {
+		return
+	}
+
+	category := strings.ToLower(body.Category)
+	err := validateAnalyticsCategory(category)
+	if err != nil {
+		serv.Warnf("EditAnalyticsCategory: " + err.Error())
+		c.AbortWithStatusJSON(configuration.SHOWABLE_ERROR_STATUS_CODE, gin.H{"message": err.Error()})
+		return
+	}
+
+	flag := "false"
+	if body.SendAnalytics {
+		flag = "true"
+	}
+
+	opts := options.Update().SetUpsert(true)
+	_, err = systemKeysCollection.UpdateOne(context.TODO(),
+		bson.M{"key": "analytics_" + category},
+		bson.M{"$set": bson.M{"value": flag}},
+		opts,
+	)
+	if err != nil {
+		serv.Errorf("EditAnalyticsCategory: " + err.Error())
+		c.AbortWithStatusJSON(500, gin.H{"message": "Server error"})
+		return
+	}
+
+	c.IndentedJSON(200, gin.H{"category": category, "send_analytics": body.SendAnalytics})
+}
+
 func (umh UserMgmtHandler) DoneNextSteps(c *gin.Context) {
 	shouldSendAnalytics, _ := shouldSendAnalytics()
 	if shouldSendAnalytics {
@@ -14,6 +14,7 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -177,7 +178,7 @@ func memphisWSGetReqFillerFromSubj(s *Server, h *Handlers, subj string) (memphis
 			return nil, errors.New("invalid poison msg id")
 		}
 		return func() (any, error) {
-			return h.Stations.GetDlsMessageJourneyDetails(poisonMsgId)
+			return h.Stations.GetDlsMessageJourneyDetails(context.TODO(), poisonMsgId)
 		}, nil
 
 	case memphisWS_Subj_AllStationsData:
@@ -197,7 +198,7 @@ func memphisWSGetReqFillerFromSubj(s *Server, h *Handlers, subj string) (memphis
 }
 
 func memphisWSGetMainOverviewData(h *Handlers) (models.MainOverviewData, error) {
-	stations, err := h.Stations.GetAllStationsDetails()
+	stations, err := h.Stations.GetAllStationsDetails(context.TODO())
 	if err != nil {
 		return models.MainOverviewData{}, nil
 	}
@@ -232,11 +233,11 @@ func memphisWSGetStationOverviewData(s *Server, h *Handlers, stationName string)
 		return map[string]any{}, errors.New("Station " + stationName + " does not exist")
 	}
 
-	connectedProducers, disconnectedProducers, deletedProducers, err := h.Producers.GetProducersByStation(station)
+	connectedProducers, disconnectedProducers, deletedProducers, err := h.Producers.GetProducersByStation(context.TODO(), station)
 	if err != nil {
 		return map[string]any{}, err
 	}
-	connectedCgs, disconnectedCgs, deletedCgs, err := h.Consumers.GetCgsByStation(sn, station)
+	connectedCgs, disconnectedCgs, deletedCgs, err := h.Consumers.GetCgsByStation(context.TODO(), sn, station)
 	if err != nil {
 		return map[string]any{}, err
 	}
@@ -244,17 +245,18 @@ func memphisWSGetStationOverviewData(s *Server, h *Handlers, stationName string)
 	if err != nil {
 		return map[string]any{}, err
 	}
-	totalMessages, err := h.Stations.GetTotalMessages(station.Name)
+	streamInfo, err := s.memphisStreamInfo(sn.Intern())
 	if err != nil {
 		return map[string]any{}, err
 	}
+	totalMessages := int(streamInfo.State.Msgs)
 	avgMsgSize, err := h.Stations.GetAvgMsgSize(station)
 	if err != nil {
 		return map[string]any{}, err
 	}
 
 	messagesToFetch := 1000
-	messages, err := h.Stations.GetMessages(station, messagesToFetch)
+	messages, err := h.Stations.GetMessages(station, messagesToFetch, "")
 	if err != nil {
 		return map[string]any{}, err
 	}
@@ -263,11 +265,23 @@ func memphisWSGetStationOverviewData(s *Server, h *Handlers, stationName string)
 	if err != nil {
 		return map[string]any{}, err
 	}
+	schemaFailureRate, err := h.Stations.GetSchemaFailureRate(station, schemaFailureRateWindow)
+	if err != nil {
+		return map[string]any{}, err
+	}
+	lastProducedAt, lastConsumedAt, err := h.Stations.GetStationActivity(station.Name)
+	if err != nil {
+		return map[string]any{}, err
+	}
 
 	tags, err := h.Tags.GetTagsByStation(station.ID)
 	if err != nil {
 		return map[string]any{}, err
 	}
+	idempotencyWindow, err := h.Stations.GetIdempotencyWindowState(station, streamInfo.Config)
+	if err != nil {
+		return map[string]any{}, err
+	}
 	leader, followers, err := h.Stations.GetLeaderAndFollowers(station)
 	if err != nil {
 		return map[string]any{}, err
@@ -295,12 +309,25 @@ func memphisWSGetStationOverviewData(s *Server, h *Handlers, stationName string)
 			"messages":                 messages,
 			"poison_messages":          poisonMessages,
 			"schema_fail_messages":     schemaFailMessages,
+			"poison_consumer_count":    len(poisonMessages),
+			"schema_failed_count":      len(schemaFailMessages),
+			"schema_failure_rate":      schemaFailureRate,
+			"last_produced_at":         lastProducedAt,
+			"last_consumed_at":         lastConsumedAt,
 			"tags":                     tags,
 			"leader":                   leader,
 			"followers":                followers,
+			"replica_health":           getReplicaHealth(station, leader, streamInfo.Cluster),
 			"schema":                   struct{}{},
+			"retention_type":           station.RetentionType,
+			"retention_descriptor":     getRetentionDescriptor(station.RetentionType, station.RetentionValue),
+			"unlimited_retention":      isUnlimitedRetention(station.RetentionType),
 			"idempotency_window_in_ms": station.IdempotencyWindow,
-			"dls_configuration":        station.DlsConfiguration,
+			"idempotency_window":       idempotencyWindow,
+			"dls_configuration":        redactDlsConfiguration(station.DlsConfiguration),
+			"max_msg_size_bytes":       getStationMaxMsgSize(station),
+			"description":              station.Description,
+			"metadata":                 station.Metadata,
 		}
 		return response, nil
 	}
@@ -325,12 +352,25 @@ func memphisWSGetStationOverviewData(s *Server, h *Handlers, stationName string)
 		"messages":                 messages,
 		"poison_messages":          poisonMessages,
 		"schema_fail_messages":     schemaFailMessages,
+		"poison_consumer_count":    len(poisonMessages),
+		"schema_failed_count":      len(schemaFailMessages),
+		"schema_failure_rate":      schemaFailureRate,
+		"last_produced_at":         lastProducedAt,
+		"last_consumed_at":         lastConsumedAt,
 		"tags":                     tags,
 		"leader":                   leader,
 		"followers":                followers,
+		"replica_health":           getReplicaHealth(station, leader, streamInfo.Cluster),
 		"schema":                   schemaDetails,
+		"retention_type":           station.RetentionType,
+		"retention_descriptor":     getRetentionDescriptor(station.RetentionType, station.RetentionValue),
+		"unlimited_retention":      isUnlimitedRetention(station.RetentionType),
 		"idempotency_window_in_ms": station.IdempotencyWindow,
-		"dls_configuration":        station.DlsConfiguration,
+		"idempotency_window":       idempotencyWindow,
+		"dls_configuration":        redactDlsConfiguration(station.DlsConfiguration),
+		"max_msg_size_bytes":       getStationMaxMsgSize(station),
+		"description":              station.Description,
+		"metadata":                 station.Metadata,
 	}
 
 	return response, nil
@@ -345,7 +385,7 @@ func memphisWSGetSchemasOverviewData(h *Handlers) ([]models.ExtendedSchema, erro
 }
 
 func memphisWSGetStationsOverviewData(h *Handlers) ([]models.ExtendedStationDetails, error) {
-	stations, err := h.Stations.GetStationsDetails()
+	stations, err := h.Stations.GetStationsDetails(context.TODO(), "")
 	if err != nil {
 		return stations, err
 	}
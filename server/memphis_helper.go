@@ -58,6 +58,7 @@ const (
 	kindUpdateStream   = "$memphis_update_stream"
 	kindDeleteStream   = "$memphis_delete_stream"
 	kindStreamList     = "$memphis_stream_list"
+	kindConsumerList   = "$memphis_consumer_list"
 	kindGetMsg         = "$memphis_get_msg"
 	kindDeleteMsg      = "$memphis_delete_msg"
 )
@@ -65,6 +66,8 @@ const (
 // errors
 var (
 	ErrBadHeader                   = errors.New("could not decode header")
+	ErrCgPositionRefused           = errors.New("the consumer group can not start from the requested position")
+	ErrCgRecreatedAtOriginalStart  = errors.New("the consumer group could not be reset and was recreated at its original start position, the messages it had acknowledged will be redelivered")
 	LOGS_RETENTION_IN_DAYS         int
 	POISON_MSGS_RETENTION_IN_HOURS int
 )
@@ -126,6 +129,44 @@ func RemoveUser(username string) error {
 }
 
 func (s *Server) CreateStream(sn StationName, station models.Station) error {
+	streamConfig := stationStreamConfig(sn, station)
+	return s.memphisAddStream(&streamConfig)
+}
+
+// stationMsgsSubject returns the subject the messages of the station are stored on,
+// keyed stations store each message on the final subject suffixed with its compaction key,
+// a mirror keeps the subjects its source stored the messages on.
+// the messages of extra subjects are stored on their own subjects, so the whole stream is read
+func stationMsgsSubject(sn StationName, station models.Station) string {
+	if len(station.Subjects) > 0 {
+		return ""
+	}
+	if station.Mirror != "" {
+		if sourceSn, err := StationNameFromStr(station.Mirror); err == nil {
+			return sourceSn.Intern() + ".final"
+		}
+	}
+	if isKeyedRetention(station.RetentionType) {
+		return sn.Intern() + ".final.>"
+	}
+	return sn.Intern() + ".final"
+}
+
+// stationProduceSubject returns the subject producers publish on, with a placeholder for the key of keyed stations
+func stationProduceSubject(sn StationName, station models.Station) string {
+	if isKeyedRetention(station.RetentionType) {
+		return sn.Intern() + ".final.<" + station.CompactionKey + ">"
+	}
+	return sn.Intern() + ".final"
+}
+
+// stationSubjects returns the subjects the station's stream listens on, its own subject first
+func stationSubjects(sn StationName, station models.Station) []string {
+	return append([]string{sn.Intern() + ".>"}, station.Subjects...)
+}
+
+// stationStreamConfig is the stream config the station's stored settings translate to
+func stationStreamConfig(sn StationName, station models.Station) StreamConfig {
 	var maxMsgs int
 	if station.RetentionType == "messages" && station.RetentionValue > 0 {
 		maxMsgs = station.RetentionValue
@@ -147,6 +188,11 @@ func (s *Server) CreateStream(sn StationName, station models.Station) error {
 		maxAge = time.Duration(0)
 	}
 
+	// a pinned retention keeps every message until the pin expires
+	if station.RetentionPin != nil {
+		maxMsgs, maxBytes, maxAge = -1, -1, time.Duration(0)
+	}
+
 	var storage StorageType
 	if station.StorageType == "memory" {
 		storage = MemoryStorage
@@ -163,23 +209,74 @@ func (s *Server) CreateStream(sn StationName, station models.Station) error {
 		idempotencyWindow = time.Duration(station.IdempotencyWindow) * time.Millisecond
 	}
 
-	return s.
-		memphisAddStream(&StreamConfig{
-			Name:         sn.Intern(),
-			Subjects:     []string{sn.Intern() + ".>"},
-			Retention:    LimitsPolicy,
-			MaxConsumers: -1,
-			MaxMsgs:      int64(maxMsgs),
-			MaxBytes:     int64(maxBytes),
-			Discard:      DiscardOld,
-			MaxAge:       maxAge,
-			MaxMsgsPer:   -1,
-			MaxMsgSize:   int32(configuration.MAX_MESSAGE_SIZE_MB) * 1024 * 1024,
-			Storage:      storage,
-			Replicas:     station.Replicas,
-			NoAck:        false,
-			Duplicates:   idempotencyWindow,
-		})
+	// keyed stations keep the latest message per subject, each key being published on its own subject
+	maxMsgsPer := int64(-1)
+	if isKeyedRetention(station.RetentionType) {
+		maxMsgsPer = 1
+	}
+
+	streamConfig := StreamConfig{
+		Name:         sn.Intern(),
+		Subjects:     stationSubjects(sn, station),
+		Retention:    LimitsPolicy,
+		MaxConsumers: -1,
+		MaxMsgs:      int64(maxMsgs),
+		MaxBytes:     int64(maxBytes),
+		Discard:      DiscardOld,
+		MaxAge:       maxAge,
+		MaxMsgsPer:   maxMsgsPer,
+		MaxMsgSize:   int32(getStationMaxMsgSize(station)),
+		Storage:      storage,
+		Replicas:     station.Replicas,
+		NoAck:        false,
+		Duplicates:   idempotencyWindow,
+
+		MemphisHeadersRequired: !stationAllowsNonNative(station),
+		MemphisReadOnly:        station.ReadOnly,
+		MemphisPaused:          station.IsPaused,
+	}
+
+	// a mirror station's stream is fed by the stream of its source station and can not listen on subjects
+	if station.Mirror != "" {
+		sourceSn, err := StationNameFromStr(station.Mirror)
+		if err == nil {
+			streamConfig.Subjects = nil
+			streamConfig.Mirror = &StreamSource{Name: sourceSn.Intern()}
+		}
+	}
+
+	return streamConfig
+}
+
+// hasMemphisProducerHeaders reports whether a message carries the headers the Memphis SDKs stamp on every produced message
+func hasMemphisProducerHeaders(hdr []byte) bool {
+	if len(getHeader("$memphis_connectionId", hdr)) > 0 && len(getHeader("$memphis_producedBy", hdr)) > 0 {
+		return true
+	}
+	// the headers of older SDKs
+	return len(getHeader("connectionId", hdr)) > 0 && len(getHeader("producedBy", hdr)) > 0
+}
+
+// waitForStreamReady polls the stream until it has an elected leader, in stand alone mode the stream is ready once created
+func (s *Server) waitForStreamReady(streamName string, timeout time.Duration) error {
+	if !s.JetStreamIsClustered() {
+		return nil
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		streamInfo, err := s.memphisStreamInfo(streamName)
+		if err == nil && streamInfo.Cluster != nil && streamInfo.Cluster.Leader != _EMPTY_ {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			if err != nil {
+				return err
+			}
+			return errors.New("no leader has been elected for stream " + streamName + " within " + timeout.String())
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
 }
 
 func (s *Server) CreateDlsStream(sn StationName, station models.Station) error {
@@ -310,6 +407,42 @@ func (s *Server) memphisAddStream(sc *StreamConfig) error {
 	return resp.ToError()
 }
 
+// applyStationRetention updates the retention limits of the station's stream to the ones the station translates to
+func (s *Server) applyStationRetention(sn StationName, station models.Station) error {
+	streamInfo, err := s.memphisStreamInfo(sn.Intern())
+	if err != nil {
+		return err
+	}
+	desired := stationStreamConfig(sn, station)
+	streamConfig := streamInfo.Config
+	streamConfig.MaxMsgs = desired.MaxMsgs
+	streamConfig.MaxBytes = desired.MaxBytes
+	streamConfig.MaxAge = desired.MaxAge
+	return s.memphisUpdateStream(&streamConfig)
+}
+
+// applyStationReadOnly updates the station's stream to reject or accept new messages as the station's read only flag says
+func (s *Server) applyStationReadOnly(sn StationName, station models.Station) error {
+	streamInfo, err := s.memphisStreamInfo(sn.Intern())
+	if err != nil {
+		return err
+	}
+	streamConfig := streamInfo.Config
+	streamConfig.MemphisReadOnly = station.ReadOnly
+	return s.memphisUpdateStream(&streamConfig)
+}
+
+// applyStationPaused updates the station's stream to stop or restart the delivery to its consumers as the station's paused flag says
+func (s *Server) applyStationPaused(sn StationName, station models.Station) error {
+	streamInfo, err := s.memphisStreamInfo(sn.Intern())
+	if err != nil {
+		return err
+	}
+	streamConfig := streamInfo.Config
+	streamConfig.MemphisPaused = station.IsPaused
+	return s.memphisUpdateStream(&streamConfig)
+}
+
 func (s *Server) memphisUpdateStream(sc *StreamConfig) error {
 	requestSubject := fmt.Sprintf(JSApiStreamUpdateT, sc.Name)
 
@@ -349,8 +482,8 @@ func (s *Server) CreateConsumer(consumer models.Consumer, station models.Station
 	}
 
 	var MaxMsgDeliveries int
-	if consumer.MaxMsgDeliveries <= 0 || consumer.MaxMsgDeliveries > 10 {
-		MaxMsgDeliveries = 10
+	if consumer.MaxMsgDeliveries <= 0 || consumer.MaxMsgDeliveries > maxConsumerMsgDeliveries {
+		MaxMsgDeliveries = maxConsumerMsgDeliveries
 	} else {
 		MaxMsgDeliveries = consumer.MaxMsgDeliveries
 	}
@@ -366,7 +499,7 @@ func (s *Server) CreateConsumer(consumer models.Consumer, station models.Station
 		AckPolicy:     AckExplicit,
 		AckWait:       time.Duration(maxAckTimeMs) * time.Millisecond,
 		MaxDeliver:    MaxMsgDeliveries,
-		FilterSubject: stationName.Intern() + ".final",
+		FilterSubject: stationMsgsSubject(stationName, station),
 		ReplayPolicy:  ReplayInstant,
 		MaxAckPending: -1,
 		HeadersOnly:   false,
@@ -412,6 +545,50 @@ func (s *Server) memphisRemoveConsumer(streamName, cn string) error {
 	return resp.ToError()
 }
 
+// ResetCgPosition moves the consumer group to a new start position by recreating its durable consumer
+// with the same config, since JetStream does not allow updating the deliver policy of an existing consumer.
+// the new config is checked on a temporary consumer first, so a position JetStream refuses leaves the consumer group untouched
+func (s *Server) ResetCgPosition(stationName StationName, cgName string, deliverPolicy DeliverPolicy, startSeq uint64, startTime *time.Time) error {
+	info, err := s.GetCgInfo(stationName, cgName)
+	if err != nil {
+		return err
+	}
+
+	originalCc := *info.Config
+	cc := originalCc
+	cc.DeliverPolicy = deliverPolicy
+	cc.OptStartSeq = startSeq
+	cc.OptStartTime = startTime
+
+	validationCc := cc
+	validationCc.Durable = "$memphis_reset_cg_validation_consumer_" + s.memphis.nuid.Next()
+	validationCc.DeliverSubject = _EMPTY_
+	validationCc.DeliverGroup = _EMPTY_
+	err = s.memphisAddConsumer(stationName.Intern(), &validationCc)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrCgPositionRefused, err)
+	}
+	err = s.memphisRemoveConsumer(stationName.Intern(), validationCc.Durable)
+	if err != nil {
+		return err
+	}
+
+	err = s.RemoveConsumer(stationName, cgName)
+	if err != nil {
+		return err
+	}
+	err = s.memphisAddConsumer(stationName.Intern(), &cc)
+	if err != nil {
+		// the acknowledged progress went away with the removed consumer, the recreated one starts over from its original start position
+		restoreErr := s.memphisAddConsumer(stationName.Intern(), &originalCc)
+		if restoreErr != nil {
+			return fmt.Errorf("%v, recreating the consumer group failed as well: %v", err, restoreErr)
+		}
+		return fmt.Errorf("%w: %v", ErrCgRecreatedAtOriginalStart, err)
+	}
+	return nil
+}
+
 func (s *Server) GetCgInfo(stationName StationName, cgName string) (*ConsumerInfo, error) {
 	cgName = replaceDelimiters(cgName)
 	requestSubject := fmt.Sprintf(JSApiConsumerInfoT, stationName.Intern(), cgName)
@@ -430,6 +607,179 @@ func (s *Server) GetCgInfo(stationName StationName, cgName string) (*ConsumerInf
 	return resp.ConsumerInfo, nil
 }
 
+func (s *Server) memphisConsumerInfo(streamName, cn string) (*ConsumerInfo, error) {
+	requestSubject := fmt.Sprintf(JSApiConsumerInfoT, streamName, cn)
+
+	var resp JSApiConsumerInfoResponse
+	err := jsApiRequest(s, requestSubject, kindConsumerInfo, []byte(_EMPTY_), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	err = resp.ToError()
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.ConsumerInfo, nil
+}
+
+// memphisCountMsgsSince counts the messages stored in the stream since the given time using a temporary consumer
+func (s *Server) memphisCountMsgsSince(streamName, filterSubj string, since time.Time) (int, error) {
+	durableName := "$memphis_count_msgs_consumer_" + s.memphis.nuid.Next()
+	cc := ConsumerConfig{
+		FilterSubject: filterSubj,
+		OptStartTime:  &since,
+		DeliverPolicy: DeliverByStartTime,
+		Durable:       durableName,
+		AckPolicy:     AckExplicit,
+	}
+
+	err := s.memphisAddConsumer(streamName, &cc)
+	if err != nil {
+		return 0, err
+	}
+
+	consumerInfo, err := s.memphisConsumerInfo(streamName, durableName)
+	removeErr := s.memphisRemoveConsumer(streamName, durableName)
+	if err != nil {
+		return 0, err
+	}
+	if removeErr != nil {
+		return 0, removeErr
+	}
+
+	return int(consumerInfo.NumPending), nil
+}
+
+// memphisFirstSeqSince returns the first stream sequence whose timestamp is >= since
+// along with the amount of messages pending from it
+func (s *Server) memphisFirstSeqSince(streamName, filterSubj string, since time.Time) (uint64, int, error) {
+	durableName := "$memphis_time_lookup_consumer_" + s.memphis.nuid.Next()
+	cc := ConsumerConfig{
+		FilterSubject: filterSubj,
+		OptStartTime:  &since,
+		DeliverPolicy: DeliverByStartTime,
+		Durable:       durableName,
+		AckPolicy:     AckExplicit,
+	}
+
+	err := s.memphisAddConsumer(streamName, &cc)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	consumerInfo, err := s.memphisConsumerInfo(streamName, durableName)
+	removeErr := s.memphisRemoveConsumer(streamName, durableName)
+	if err != nil {
+		return 0, 0, err
+	}
+	if removeErr != nil {
+		return 0, 0, removeErr
+	}
+
+	return consumerInfo.Delivered.Stream + 1, int(consumerInfo.NumPending), nil
+}
+
+// memphisPendingFromSeq counts the messages stored in the stream from the given sequence on,
+// unlike subtracting sequences it is not thrown off by the gaps deletes and compaction leave
+func (s *Server) memphisPendingFromSeq(streamName, filterSubj string, startSeq uint64) (int, error) {
+	durableName := "$memphis_seq_lookup_consumer_" + s.memphis.nuid.Next()
+	cc := ConsumerConfig{
+		FilterSubject: filterSubj,
+		OptStartSeq:   startSeq,
+		DeliverPolicy: DeliverByStartSequence,
+		Durable:       durableName,
+		AckPolicy:     AckExplicit,
+	}
+
+	err := s.memphisAddConsumer(streamName, &cc)
+	if err != nil {
+		return 0, err
+	}
+
+	consumerInfo, err := s.memphisConsumerInfo(streamName, durableName)
+	removeErr := s.memphisRemoveConsumer(streamName, durableName)
+	if err != nil {
+		return 0, err
+	}
+	if removeErr != nil {
+		return 0, removeErr
+	}
+
+	return int(consumerInfo.NumPending), nil
+}
+
+// GetMessagesByTimeRange returns up to pageSize messages sent between from and to, starting at startSeq
+// when it is set or at the first message sent after from otherwise.
+// the returned sequence is where the next page starts, 0 means there are no more messages in the range
+func (s *Server) GetMessagesByTimeRange(station models.Station, from, to time.Time, startSeq uint64, pageSize int) ([]models.MessageDetails, uint64, error) {
+	stationName, err := StationNameFromStr(station.Name)
+	if err != nil {
+		return []models.MessageDetails{}, 0, err
+	}
+
+	filterSubj := stationMsgsSubject(stationName, station)
+	if !station.IsNative {
+		filterSubj = ""
+	}
+
+	firstSeq, pending, err := s.memphisFirstSeqSince(stationName.Intern(), filterSubj, from)
+	if err != nil {
+		return []models.MessageDetails{}, 0, err
+	}
+	if startSeq <= firstSeq {
+		startSeq = firstSeq
+	} else {
+		pending, err = s.memphisPendingFromSeq(stationName.Intern(), filterSubj, startSeq)
+		if err != nil {
+			return []models.MessageDetails{}, 0, err
+		}
+	}
+	if pending <= 0 {
+		return []models.MessageDetails{}, 0, nil
+	}
+
+	amount := pageSize
+	if pending < amount {
+		amount = pending
+	}
+	msgs, err := s.memphisGetMsgs(filterSubj,
+		stationName.Intern(),
+		startSeq,
+		amount,
+		5*time.Second,
+		station.IsNative, // in non-native stations we don't look for headers in messages
+	)
+	if err != nil {
+		return []models.MessageDetails{}, 0, err
+	}
+
+	sort.Slice(msgs, func(i, j int) bool {
+		return msgs[i].Sequence < msgs[j].Sequence
+	})
+	var nextSeq uint64
+	if len(msgs) > 0 && pending > amount {
+		nextSeq = msgs[len(msgs)-1].Sequence + 1
+	}
+	for i, msg := range msgs {
+		if msg.Time.After(to) {
+			msgs = msgs[:i]
+			nextSeq = 0
+			break
+		}
+	}
+
+	messages, err := storedMsgsToMessageDetails(msgs, station.IsNative)
+	if err != nil {
+		return []models.MessageDetails{}, 0, err
+	}
+	if messages == nil {
+		messages = []models.MessageDetails{}
+	}
+	return messages, nextSeq, nil
+}
+
 func (s *Server) RemoveStream(streamName string) error {
 	requestSubject := fmt.Sprintf(JSApiStreamDeleteT, streamName)
 
@@ -451,6 +801,46 @@ func (s *Server) GetTotalMessagesInStation(stationName StationName) (int, error)
 	return int(streamInfo.State.Msgs), nil
 }
 
+func (s *Server) GetTotalBytesInStation(stationName StationName) (int64, error) {
+	streamInfo, err := s.memphisStreamInfo(stationName.Intern())
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(streamInfo.State.Bytes), nil
+}
+
+// GetStationActivity returns when a message was last stored in the station and when a consumer group last acked one,
+// each is nil when it never happened
+func (s *Server) GetStationActivity(stationName StationName) (*time.Time, *time.Time, error) {
+	streamInfo, err := s.memphisStreamInfo(stationName.Intern())
+	if err != nil {
+		return nil, nil, err
+	}
+	var lastProduced *time.Time
+	if streamInfo.State.LastSeq > 0 && !streamInfo.State.LastTime.IsZero() {
+		lastTime := streamInfo.State.LastTime
+		lastProduced = &lastTime
+	}
+
+	consumers, err := s.memphisAllConsumersInfo(stationName.Intern())
+	if err != nil {
+		return nil, nil, err
+	}
+	var lastConsumed *time.Time
+	for _, consumer := range consumers {
+		if strings.HasPrefix(consumer.Name, "$memphis") { // skip internal consumers
+			continue
+		}
+		ackTime := consumer.AckFloor.Last
+		if ackTime != nil && (lastConsumed == nil || ackTime.After(*lastConsumed)) {
+			lastConsumed = ackTime
+		}
+	}
+
+	return lastProduced, lastConsumed, nil
+}
+
 func (s *Server) GetTotalMessagesAcrossAllStations() (int, error) {
 	messagesCounter := 0
 
@@ -486,6 +876,32 @@ func (s *Server) memphisStreamInfo(streamName string) (*StreamInfo, error) {
 	return resp.StreamInfo, nil
 }
 
+// memphisStreamDedupStats reads the duplicate detection stats of a stream hosted by this server,
+// in a cluster only the servers holding a replica of the stream have them
+func (s *Server) memphisStreamDedupStats(streamName string) (int, uint64, time.Time, error) {
+	mset, err := s.GlobalAccount().lookupStream(streamName)
+	if err != nil {
+		return 0, 0, time.Time{}, err
+	}
+	tracked, rejected, since := mset.dedupStats()
+	return tracked, rejected, since, nil
+}
+
+// memphisMsgDeliveryCount reads how many times a consumer group got a message from the consumer hosted by this server,
+// false when the consumer is not hosted here or no longer tracks the message
+func (s *Server) memphisMsgDeliveryCount(stationName StationName, cgName string, seq uint64) (int, bool) {
+	mset, err := s.GlobalAccount().lookupStream(stationName.Intern())
+	if err != nil {
+		return 0, false
+	}
+	o := mset.lookupConsumer(getInternalConsumerName(cgName))
+	if o == nil {
+		return 0, false
+	}
+	dc, ok := o.memphisDeliveryCount(seq)
+	return int(dc), ok
+}
+
 func (s *Server) memphisDeleteMsgFromStream(streamName string, seq uint64) (ApiResponse, error) {
 	requestSubject := fmt.Sprintf(JSApiMsgDeleteT, streamName)
 
@@ -545,7 +961,31 @@ func (s *Server) memphisAllStreamsInfo() ([]*StreamInfo, error) {
 	return resp.Streams, nil
 }
 
-func (s *Server) GetMessages(station models.Station, messagesToFetch int) ([]models.MessageDetails, error) {
+func (s *Server) memphisAllConsumersInfo(streamName string) ([]*ConsumerInfo, error) {
+	requestSubject := strings.Replace(JSApiConsumerList, "*", streamName, 1)
+
+	request := JSApiConsumersRequest{}
+	rawRequest, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	var resp JSApiConsumerListResponse
+	err = jsApiRequest(s, requestSubject, kindConsumerList, []byte(rawRequest), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	err = resp.ToError()
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Consumers, nil
+}
+
+// GetMessages returns the latest messages of the station by ascending sequence,
+// the oldest order returns the first messages consumers will get and the newest order the latest ones, newest first
+func (s *Server) GetMessages(station models.Station, messagesToFetch int, order string) ([]models.MessageDetails, error) {
 	stationName, err := StationNameFromStr(station.Name)
 	if err != nil {
 		return []models.MessageDetails{}, err
@@ -558,13 +998,20 @@ func (s *Server) GetMessages(station models.Station, messagesToFetch int) ([]mod
 	lastStreamSeq := streamInfo.State.LastSeq
 
 	var startSequence uint64 = 1
-	if totalMessages > uint64(messagesToFetch) {
+	if order == messagesOrderOldest {
+		if streamInfo.State.FirstSeq > 0 {
+			startSequence = streamInfo.State.FirstSeq
+		}
+		if totalMessages < uint64(messagesToFetch) {
+			messagesToFetch = int(totalMessages)
+		}
+	} else if totalMessages > uint64(messagesToFetch) {
 		startSequence = lastStreamSeq - uint64(messagesToFetch) + 1
 	} else {
 		messagesToFetch = int(totalMessages)
 	}
 
-	filterSubj := stationName.Intern() + ".final"
+	filterSubj := stationMsgsSubject(stationName, station)
 	if !station.IsNative {
 		filterSubj = ""
 	}
@@ -576,13 +1023,25 @@ func (s *Server) GetMessages(station models.Station, messagesToFetch int) ([]mod
 		5*time.Second,
 		station.IsNative, // in non-native stations we don't look for headers in messages
 	)
-	var messages []models.MessageDetails
 	if err != nil {
 		return []models.MessageDetails{}, err
 	}
 
-	stationIsNative := station.IsNative
+	messages, err := storedMsgsToMessageDetails(msgs, station.IsNative)
+	if err != nil {
+		return []models.MessageDetails{}, err
+	}
+	if order == messagesOrderNewest {
+		sort.Slice(messages, func(i, j int) bool {
+			return messages[i].MessageSeq > messages[j].MessageSeq
+		})
+	}
 
+	return messages, nil
+}
+
+func storedMsgsToMessageDetails(msgs []StoredMsg, stationIsNative bool) ([]models.MessageDetails, error) {
+	var messages []models.MessageDetails
 	for _, msg := range msgs {
 		messageDetails := models.MessageDetails{
 			MessageSeq: int(msg.Sequence),
@@ -597,9 +1056,14 @@ func (s *Server) GetMessages(station models.Station, messagesToFetch int) ([]mod
 		messageDetails.Data = data
 
 		if stationIsNative {
-			headersJson, err := DecodeHeader(msg.Header)
-			if err != nil {
-				return nil, err
+			// messages of non native publishers, e.g. the ones on the extra subjects of the station, are listed without a producer
+			headersJson := map[string]string{}
+			if len(msg.Header) > 0 {
+				var err error
+				headersJson, err = DecodeHeader(msg.Header)
+				if err != nil {
+					return nil, err
+				}
 			}
 
 			connectionIdHeader := headersJson["$memphis_connectionId"]
@@ -609,9 +1073,6 @@ func (s *Server) GetMessages(station models.Station, messagesToFetch int) ([]mod
 			if connectionIdHeader == "" || producedByHeader == "" {
 				connectionIdHeader = headersJson["connectionId"]
 				producedByHeader = strings.ToLower(headersJson["producedBy"])
-				if connectionIdHeader == "" || producedByHeader == "" {
-					return []models.MessageDetails{}, errors.New("Error while getting notified about a poison message: Missing mandatory message headers, please upgrade the SDK version you are using")
-				}
 			}
 
 			for header := range headersJson {
@@ -787,6 +1248,31 @@ func (s *Server) memphisGetMessage(streamName string, msgSeq uint64) (*StoredMsg
 	return resp.Message, nil
 }
 
+// memphisGetLastMessageBySubject gets the last message of the stream stored on the subject without creating a consumer
+func (s *Server) memphisGetLastMessageBySubject(streamName, subject string) (*StoredMsg, error) {
+	requestSubject := fmt.Sprintf(JSApiMsgGetT, streamName)
+
+	request := JSApiMsgGetRequest{LastFor: subject}
+
+	rawRequest, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp JSApiMsgGetResponse
+	err = jsApiRequest(s, requestSubject, kindGetMsg, rawRequest, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	err = resp.ToError()
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Message, nil
+}
+
 func (s *Server) queueSubscribe(subj, queueGroupName string, cb simplifiedMsgHandler) error {
 	acc := s.GlobalAccount()
 	c := acc.ic
@@ -826,11 +1312,23 @@ func (s *Server) respondOnGlobalAcc(reply string, msg []byte) {
 	s.sendInternalAccountMsg(acc, reply, msg)
 }
 
-func (s *Server) ResendPoisonMessage(subject string, data, headers []byte) error {
+// stampMemphisProducerHeaders adds the producer headers a station may require to a message the broker republishes,
+// the headers the message has been produced with are kept
+func stampMemphisProducerHeaders(hdrs map[string]string, producedBy string) {
+	if hdrs["$memphis_connectionId"] == "" {
+		hdrs["$memphis_connectionId"] = producedBy
+	}
+	if hdrs["$memphis_producedBy"] == "" {
+		hdrs["$memphis_producedBy"] = producedBy
+	}
+}
+
+// resentMsgHeaders returns the headers of a resent poison message, it is marked as produced by the DLS
+func resentMsgHeaders(headers []byte) (map[string]string, error) {
 	hdrs := make(map[string]string)
 	err := json.Unmarshal(headers, &hdrs)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	hdrs["$memphis_producedBy"] = "$memphis_dls"
@@ -838,6 +1336,15 @@ func (s *Server) ResendPoisonMessage(subject string, data, headers []byte) error
 	if hdrs["producedBy"] != "" {
 		delete(hdrs, "producedBy")
 	}
+	stampMemphisProducerHeaders(hdrs, "$memphis_dls")
+	return hdrs, nil
+}
+
+func (s *Server) ResendPoisonMessage(subject string, data, headers []byte) error {
+	hdrs, err := resentMsgHeaders(headers)
+	if err != nil {
+		return err
+	}
 
 	s.sendInternalMsgWithHeaderLocked(s.GlobalAccount(), subject, hdrs, data)
 	return nil
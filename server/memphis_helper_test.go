@@ -124,3 +124,21 @@ func TestValidateName(t *testing.T) {
 		t.Error()
 	}
 }
+
+func TestHasMemphisProducerHeaders(t *testing.T) {
+	if hasMemphisProducerHeaders(nil) {
+		t.Fatalf("expected a message with no headers to be rejected")
+	}
+	hdr := genHeader(nil, "$memphis_connectionId", "conn")
+	if hasMemphisProducerHeaders(hdr) {
+		t.Fatalf("expected a message missing the producer header to be rejected")
+	}
+	hdr = genHeader(hdr, "$memphis_producedBy", "producer")
+	if !hasMemphisProducerHeaders(hdr) {
+		t.Fatalf("expected a message with the Memphis headers to be accepted")
+	}
+	legacy := genHeader(genHeader(nil, "connectionId", "conn"), "producedBy", "producer")
+	if !hasMemphisProducerHeaders(legacy) {
+		t.Fatalf("expected a message with the legacy headers to be accepted")
+	}
+}
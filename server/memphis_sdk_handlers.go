@@ -25,20 +25,38 @@ type memphisResponse interface {
 }
 
 type createStationRequest struct {
-	StationName       string                  `json:"name"`
-	SchemaName        string                  `json:"schema_name"`
-	RetentionType     string                  `json:"retention_type"`
-	RetentionValue    int                     `json:"retention_value"`
-	StorageType       string                  `json:"storage_type"`
-	Replicas          int                     `json:"replicas"`
-	DedupEnabled      bool                    `json:"dedup_enabled"`      // TODO deprecated
-	DedupWindowMillis int                     `json:"dedup_window_in_ms"` // TODO deprecated
-	IdempotencyWindow int                     `json:"idempotency_window_in_ms"`
-	DlsConfiguration  models.DlsConfiguration `json:"dls_configuration"`
+	StationName        string                   `json:"name"`
+	SchemaName         string                   `json:"schema_name"`
+	RetentionType      string                   `json:"retention_type"`
+	RetentionValue     int                      `json:"retention_value"`
+	StorageType        string                   `json:"storage_type"`
+	Replicas           int                      `json:"replicas"`
+	DedupEnabled       bool                     `json:"dedup_enabled"`      // TODO deprecated
+	DedupWindowMillis  int                      `json:"dedup_window_in_ms"` // TODO deprecated
+	IdempotencyWindow  int                      `json:"idempotency_window_in_ms"`
+	DlsConfiguration   *models.DlsConfiguration `json:"dls_configuration"`
+	PartitionKeyHeader string                   `json:"partition_key_header"`
+	MaxMsgSizeBytes    int                      `json:"max_msg_size_bytes"`
+	DeletionProtected  bool                     `json:"deletion_protected"`
+	AdoptExisting      bool                     `json:"adopt_existing"`
+	SchemaEnforcement  string                   `json:"schema_enforcement"`
+	AllowedProducers   []string                 `json:"allowed_producers"`
+	AllowedConsumers   []string                 `json:"allowed_consumers"`
+	CentralDlsStation  string                   `json:"central_dls_station"`
+	MaxMsgDeliveries   int                      `json:"max_msg_deliveries"`
+	Subjects           []string                 `json:"subjects"`
+	MaxConsumerGroups  int                      `json:"max_consumer_groups"`
+	SchemaRequired     bool                     `json:"schema_required"`
+	CompactionKey      string                   `json:"compaction_key_header"`
+	Description        string                   `json:"description"`
+	Metadata           map[string]string        `json:"metadata"`
+	AllowNonNative     *bool                    `json:"allow_non_native"`
+	Mirror             string                   `json:"mirror"`
 }
 
 type destroyStationRequest struct {
-	StationName string `json:"station_name"`
+	StationName                string `json:"station_name"`
+	OverrideDeletionProtection bool   `json:"override_deletion_protection"`
 }
 
 type createProducerRequestV0 struct {
@@ -57,8 +75,11 @@ type createProducerRequestV1 struct {
 }
 
 type createProducerResponse struct {
-	SchemaUpdate models.ProducerSchemaUpdateInit `json:"schema_update"`
-	Err          string                          `json:"error"`
+	SchemaUpdate       models.ProducerSchemaUpdateInit `json:"schema_update"`
+	PartitionKeyHeader string                          `json:"partition_key_header"`
+	SchemaEnforcement  string                          `json:"schema_enforcement"`
+	CompactionKey      string                          `json:"compaction_key_header"`
+	Err                string                          `json:"error"`
 }
 
 type destroyProducerRequest struct {
@@ -82,7 +103,8 @@ type attachSchemaRequest struct {
 }
 
 type detachSchemaRequest struct {
-	StationName string `json:"station_name"`
+	StationName            string `json:"station_name"`
+	OverrideSchemaRequired bool   `json:"override_schema_required"`
 }
 
 type destroyConsumerRequest struct {
@@ -29,6 +29,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/klauspost/compress/s2"
@@ -77,6 +78,13 @@ type StreamConfig struct {
 	AllowDirect bool `json:"allow_direct,omitempty"`
 	// Allow higher performance and unified direct access for mirrors as well.
 	MirrorDirect bool `json:"mirror_direct,omitempty"`
+
+	// MemphisHeadersRequired rejects messages not produced by a Memphis SDK, the ones lacking its mandatory headers.
+	MemphisHeadersRequired bool `json:"memphis_headers_required,omitempty"`
+	// MemphisReadOnly rejects every new message while the stored ones can still be consumed.
+	MemphisReadOnly bool `json:"memphis_read_only,omitempty"`
+	// MemphisPaused stops the delivery to the station's consumers while new messages are still stored.
+	MemphisPaused bool `json:"memphis_paused,omitempty"`
 }
 
 // RePublish is for republishing messages once committed to a stream.
@@ -207,6 +215,8 @@ type stream struct {
 	qch       chan struct{}
 	active    bool
 	ddloaded  bool
+	ddhits    uint64
+	ddsince   time.Time
 
 	// Mirror
 	mirror *sourceInfo
@@ -217,6 +227,9 @@ type stream struct {
 	// Indicates we have direct consumers.
 	directs int
 
+	// Set while the Memphis station is paused, read atomically by the consumers.
+	memphisPaused int32
+
 	// For republishing.
 	tr *transform
 
@@ -424,9 +437,11 @@ func (a *Account) addStreamWithAssignment(config *StreamConfig, fsConfig *FileSt
 		stype:     cfg.Storage,
 		consumers: make(map[string]*consumer),
 		msgs:      s.newIPQueue(qpfx + "messages"), // of *inMsg
+		ddsince:   time.Now(),
 		qch:       make(chan struct{}),
 		uch:       make(chan struct{}, 4),
 	}
+	mset.setMemphisPaused(cfg.MemphisPaused)
 
 	// For no-ack consumers when we are interest retention.
 	if cfg.Retention != LimitsPolicy {
@@ -1418,6 +1433,7 @@ func (mset *stream) updateWithAdvisory(config *StreamConfig, sendAdvisory bool)
 
 	// Now update config and store's version of our config.
 	mset.cfg = *cfg
+	resumed := mset.setMemphisPaused(cfg.MemphisPaused)
 
 	// If we are the leader never suppress update advisory, simply send.
 	if mset.isLeader() && sendAdvisory {
@@ -1444,6 +1460,13 @@ func (mset *stream) updateWithAdvisory(config *StreamConfig, sendAdvisory bool)
 	}
 	mset.mu.Unlock()
 
+	// Kick the consumers of a resumed Memphis station to deliver what has been stored meanwhile.
+	if resumed {
+		for _, o := range mset.getConsumers() {
+			o.signalNewMessages()
+		}
+	}
+
 	if js != nil {
 		maxBytesDiff := cfg.MaxBytes - ocfg.MaxBytes
 		if maxBytesDiff > 0 {
@@ -3126,6 +3149,17 @@ func (mset *stream) numMsgIds() int {
 	return len(mset.ddmap)
 }
 
+// dedupStats returns the number of message ids being tracked for duplicate suppression,
+// and the number of duplicates rejected since the stream was loaded by this server.
+func (mset *stream) dedupStats() (int, uint64, time.Time) {
+	mset.mu.Lock()
+	defer mset.mu.Unlock()
+	if !mset.ddloaded {
+		mset.rebuildDedupe()
+	}
+	return len(mset.ddmap), mset.ddhits, mset.ddsince
+}
+
 // checkMsgId will process and check for duplicates.
 // Lock should be held.
 func (mset *stream) checkMsgId(id string) *ddentry {
@@ -3433,6 +3467,9 @@ func (mset *stream) processJetStreamMsg(subject, reply string, hdr, msg []byte,
 	js, jsa, doAck := mset.js, mset.jsa, !mset.cfg.NoAck
 	name, stype := mset.cfg.Name, mset.cfg.Storage
 	maxMsgSize := int(mset.cfg.MaxMsgSize)
+	// a mirror is only written by the mirroring of its source, rejecting those messages would stall it
+	isMirror := mset.cfg.Mirror != nil
+	headersRequired, readOnly := mset.cfg.MemphisHeadersRequired && !isMirror, mset.cfg.MemphisReadOnly && !isMirror
 	numConsumers := len(mset.consumers)
 	interestRetention := mset.cfg.Retention == InterestPolicy
 	// Snapshot if we are the leader and if we can respond.
@@ -3495,6 +3532,7 @@ func (mset *stream) processJetStreamMsg(subject, reply string, hdr, msg []byte,
 		if msgId = getMsgId(hdr); msgId != _EMPTY_ {
 			if dde := mset.checkMsgId(msgId); dde != nil {
 				mset.clfs++
+				mset.ddhits++
 				mset.mu.Unlock()
 				if canRespond {
 					response := append(pubAck, strconv.FormatUint(dde.seq, 10)...)
@@ -3605,6 +3643,32 @@ func (mset *stream) processJetStreamMsg(subject, reply string, hdr, msg []byte,
 		err      error
 	)
 
+	// Check the station accepts new messages at all.
+	if readOnly {
+		mset.clfs++
+		mset.mu.Unlock()
+		if canRespond {
+			resp.PubAck = &PubAck{Stream: name}
+			resp.Error = NewJSStreamGeneralError(ErrStationReadOnly)
+			b, _ := json.Marshal(resp)
+			mset.outq.sendMsg(reply, b)
+		}
+		return ErrStationReadOnly
+	}
+
+	// Check the message has been produced by a Memphis SDK when the station does not allow non native producers.
+	if headersRequired && !hasMemphisProducerHeaders(hdr) {
+		mset.clfs++
+		mset.mu.Unlock()
+		if canRespond {
+			resp.PubAck = &PubAck{Stream: name}
+			resp.Error = NewJSStreamGeneralError(ErrMissingMemphisHeaders)
+			b, _ := json.Marshal(resp)
+			mset.outq.sendMsg(reply, b)
+		}
+		return ErrMissingMemphisHeaders
+	}
+
 	// Check to see if we are over the max msg size.
 	if maxMsgSize >= 0 && (len(hdr)+len(msg)) > maxMsgSize {
 		mset.clfs++
@@ -4231,6 +4295,19 @@ func (mset *stream) getMsg(seq uint64) (*StoredMsg, error) {
 	}, nil
 }
 
+// setMemphisPaused pauses or resumes the delivery to the consumers of the stream, it reports whether a paused stream has been resumed.
+func (mset *stream) setMemphisPaused(paused bool) bool {
+	if paused {
+		atomic.StoreInt32(&mset.memphisPaused, 1)
+		return false
+	}
+	return atomic.SwapInt32(&mset.memphisPaused, 0) == 1
+}
+
+func (mset *stream) isMemphisPaused() bool {
+	return atomic.LoadInt32(&mset.memphisPaused) == 1
+}
+
 // getConsumers will return all the current consumers for this stream.
 func (mset *stream) getConsumers() []*consumer {
 	mset.mu.RLock()